@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 
 	"github.com/fatih/color"
@@ -101,7 +103,11 @@ func run() error {
 		},
 	}
 
-	return app.Run(os.Args)
+	// Cancel the in-flight API calls when the user interrupts the generation
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return app.RunContext(ctx, os.Args)
 }
 
 func parseFlags(ctx *cli.Context) (*config, error) {
@@ -43,7 +43,7 @@ func generateCloudResources(ctx context.Context, cfg *config) ([]stack, error) {
 		return nil, err
 	}
 
-	client, err := provider.CreateClients(config)
+	client, err := provider.CreateClients(ctx, config)
 	if err != nil {
 		return nil, err
 	}
@@ -71,10 +71,7 @@ func generateCloudResources(ctx context.Context, cfg *config) ([]stack, error) {
 	}
 
 	log.Println("Post-processing for cloud")
-	if err := stripDefaults(filepath.Join(cfg.outputDir, "cloud-resources.tf"), map[string]string{}); err != nil {
-		return nil, err
-	}
-	if err := wrapJSONFieldsInFunction(filepath.Join(cfg.outputDir, "cloud-resources.tf")); err != nil {
+	if err := postprocess(filepath.Join(cfg.outputDir, "cloud-resources.tf"), "cloud"); err != nil {
 		return nil, err
 	}
 
@@ -198,7 +195,7 @@ func createManagementStackServiceAccount(ctx context.Context, cloudClient *gcom.
 		return fmt.Errorf("failed to create temporary client for stack %q: %w", stack.Slug, err)
 	}
 
-	serviceAccountsResp, err := tempClient.ServiceAccounts.SearchOrgServiceAccountsWithPaging(service_accounts.NewSearchOrgServiceAccountsWithPagingParams())
+	serviceAccountsResp, err := tempClient.ServiceAccounts.SearchOrgServiceAccountsWithPaging(service_accounts.NewSearchOrgServiceAccountsWithPagingParams().WithContext(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to search service accounts for stack %q: %w", stack.Slug, err)
 	}
@@ -206,7 +203,7 @@ func createManagementStackServiceAccount(ctx context.Context, cloudClient *gcom.
 		if sa.Name == saName {
 			log.Printf("found existing management service account (%s) in stack %q\n", saName, stack.Slug)
 			// Delete the SA to recreate it via TF
-			_, err := tempClient.ServiceAccounts.DeleteServiceAccount(sa.ID)
+			_, err := tempClient.ServiceAccounts.DeleteServiceAccountWithParams(service_accounts.NewDeleteServiceAccountParams().WithServiceAccountID(sa.ID).WithContext(ctx))
 			if err != nil {
 				return fmt.Errorf("failed to delete existing management service account (%s) in stack %q: %w", saName, stack.Slug, err)
 			}
@@ -45,7 +45,7 @@ func generateGrafanaResources(ctx context.Context, auth, url, stackName string,
 		return err
 	}
 
-	client, err := provider.CreateClients(config)
+	client, err := provider.CreateClients(ctx, config)
 	if err != nil {
 		return err
 	}
@@ -61,17 +61,5 @@ func generateGrafanaResources(ctx context.Context, auth, url, stackName string,
 	}
 
 	log.Printf("Post-processing for %s\n", stackName)
-	if err := stripDefaults(filepath.Join(outPath, stackName+"-resources.tf"), map[string]string{
-		"org_id": " \"1\"",
-	}); err != nil {
-		return err
-	}
-	if err := abstractDashboards(filepath.Join(outPath, stackName+"-resources.tf")); err != nil {
-		return err
-	}
-	if err := wrapJSONFieldsInFunction(filepath.Join(outPath, stackName+"-resources.tf")); err != nil {
-		return err
-	}
-
-	return nil
+	return postprocess(filepath.Join(outPath, stackName+"-resources.tf"), "grafana")
 }
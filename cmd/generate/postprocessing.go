@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -16,6 +17,30 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
+// PostprocessingSteps are the steps run by the generate command on the generated resources file, by provider part.
+// They are run in order, and running them again on their output doesn't change it.
+var PostprocessingSteps = map[string][]func(fpath string) error{
+	"grafana": {
+		func(fpath string) error { return stripDefaults(fpath, map[string]string{"org_id": " \"1\""}) },
+		abstractDashboards,
+		wrapJSONFieldsInFunction,
+	},
+	"cloud": {
+		func(fpath string) error { return stripDefaults(fpath, map[string]string{}) },
+		wrapJSONFieldsInFunction,
+	},
+}
+
+// postprocess runs the post-processing steps of a provider part on a generated resources file
+func postprocess(fpath string, part string) error {
+	for _, step := range PostprocessingSteps[part] {
+		if err := step(fpath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func stripDefaults(fpath string, extraFieldsToRemove map[string]string) error {
 	file, err := readHCLFile(fpath)
 	if err != nil {
@@ -49,7 +74,13 @@ func wrapJSONFieldsInFunction(fpath string) error {
 			if err != nil || asMap == nil {
 				continue
 			}
-			tokens := hclwrite.TokensForValue(HCL2ValueFromConfigValue(asMap))
+			value, err := HCL2ValueFromConfigValue(asMap)
+			if err != nil {
+				// Leave the attribute as a JSON string
+				log.Printf("[WARN] could not convert %s.%s to HCL: %v", strings.Join(block.Labels(), "."), key, err)
+				continue
+			}
+			tokens := hclwrite.TokensForValue(value)
 			block.Body().SetAttributeRaw(key, hclwrite.TokensForFunctionCall("jsonencode", tokens))
 			hasChanges = true
 		}
@@ -75,20 +106,24 @@ func abstractDashboards(fpath string) error {
 	dashboardJsons := map[string][]byte{}
 	for _, block := range file.Body().Blocks() {
 		labels := block.Labels()
-		if len(labels) == 0 || labels[0] != "grafana_dashboard" {
+		if block.Type() != "resource" || len(labels) != 2 || labels[0] != "grafana_dashboard" {
 			continue
 		}
 
-		dashboard, err := attributeToJSON(block.Body().GetAttribute("config_json"))
+		configJSON := block.Body().GetAttribute("config_json")
+		if configJSON == nil {
+			continue
+		}
+		dashboard, err := attributeToJSON(configJSON)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to read the config_json of %s: %w", strings.Join(labels, "."), err)
 		}
 
 		if dashboard == nil {
 			continue
 		}
 
-		writeTo := filepath.Join(outPath, fmt.Sprintf("%s.json", block.Labels()[1]))
+		writeTo := filepath.Join(outPath, fmt.Sprintf("%s.json", labels[1]))
 
 		// Replace $${ with ${ in the json. No need to escape in the json file
 		dashboard = []byte(strings.ReplaceAll(string(dashboard), "$${", "${"))
@@ -114,11 +149,12 @@ func abstractDashboards(fpath string) error {
 	}
 	if hasChanges {
 		log.Printf("Updating file: %s\n", fpath)
-		os.Mkdir(outPath, 0755)
+		if err := os.MkdirAll(outPath, 0755); err != nil {
+			return err
+		}
 		for writeTo, dashboard := range dashboardJsons {
-			err := os.WriteFile(writeTo, dashboard, 0600)
-			if err != nil {
-				panic(err)
+			if err := os.WriteFile(writeTo, dashboard, 0600); err != nil {
+				return err
 			}
 		}
 		return os.WriteFile(fpath, file.Bytes(), 0600)
@@ -139,18 +175,23 @@ func attributeToMap(attr *hclwrite.Attribute) (map[string]interface{}, error) {
 	}
 	s = strings.ReplaceAll(s, "$${", "${") // These are escaped interpolations
 
+	// Keep numbers as they are, large IDs would lose precision as float64
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
 	var jsonMap map[string]interface{}
-	err = json.Unmarshal([]byte(s), &jsonMap)
-	if err != nil {
+	if err := decoder.Decode(&jsonMap); err != nil {
 		return nil, err
 	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected content after the JSON object")
+	}
 
 	return jsonMap, nil
 }
 
 func attributeToJSON(attr *hclwrite.Attribute) ([]byte, error) {
 	jsonMap, err := attributeToMap(attr)
-	if err != nil {
+	if err != nil || jsonMap == nil {
 		return nil, err
 	}
 
@@ -217,47 +258,60 @@ func stripDefaultsFromBlock(block *hclwrite.Block, extraFieldsToRemove map[strin
 
 // BELOW IS FROM https://github.com/hashicorp/terraform/blob/main/internal/configs/hcl2shim/values.go
 
-// UnknownVariableValue is a sentinel value that can be used
-// to denote that the value of a variable is unknown at this time.
-// RawConfig uses this information to build up data about
-// unknown keys.
-const UnknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
-
 // HCL2ValueFromConfigValue is the opposite of configValueFromHCL2: it takes
 // a value as would be returned from the old interpolator and turns it into
 // a cty.Value so it can be used within, for example, an HCL2 EvalContext.
-func HCL2ValueFromConfigValue(v interface{}) cty.Value {
+// Unlike the original, it also accepts the numbers of decoded JSON, and returns an error for unexpected types instead of panicking.
+func HCL2ValueFromConfigValue(v interface{}) (cty.Value, error) {
 	if v == nil {
-		return cty.NullVal(cty.DynamicPseudoType)
-	}
-	if v == UnknownVariableValue {
-		return cty.DynamicVal
+		return cty.NullVal(cty.DynamicPseudoType), nil
 	}
 
 	switch tv := v.(type) {
 	case bool:
-		return cty.BoolVal(tv)
+		return cty.BoolVal(tv), nil
 	case string:
-		return cty.StringVal(tv)
+		return cty.StringVal(tv), nil
 	case int:
-		return cty.NumberIntVal(int64(tv))
+		return cty.NumberIntVal(int64(tv)), nil
+	case int32:
+		return cty.NumberIntVal(int64(tv)), nil
+	case int64:
+		return cty.NumberIntVal(tv), nil
+	case float32:
+		return cty.NumberFloatVal(float64(tv)), nil
 	case float64:
-		return cty.NumberFloatVal(tv)
+		if math.IsNaN(tv) || math.IsInf(tv, 0) {
+			return cty.NilVal, fmt.Errorf("can't convert %v to cty.Value", tv)
+		}
+		return cty.NumberFloatVal(tv), nil
+	case json.Number:
+		val, err := cty.ParseNumberVal(tv.String())
+		if err != nil {
+			return cty.NilVal, fmt.Errorf("can't convert %q to cty.Value: %w", tv, err)
+		}
+		return val, nil
 	case []interface{}:
 		vals := make([]cty.Value, len(tv))
 		for i, ev := range tv {
-			vals[i] = HCL2ValueFromConfigValue(ev)
+			val, err := HCL2ValueFromConfigValue(ev)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			vals[i] = val
 		}
-		return cty.TupleVal(vals)
+		return cty.TupleVal(vals), nil
 	case map[string]interface{}:
 		vals := map[string]cty.Value{}
 		for k, ev := range tv {
-			vals[k] = HCL2ValueFromConfigValue(ev)
+			val, err := HCL2ValueFromConfigValue(ev)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			vals[k] = val
 		}
-		return cty.ObjectVal(vals)
+		return cty.ObjectVal(vals), nil
 	default:
-		// HCL/HIL should never generate anything that isn't caught by
-		// the above, so if we get here something has gone very wrong.
-		panic(fmt.Errorf("can't convert %#v to cty.Value", v))
+		return cty.NilVal, fmt.Errorf("can't convert %#v to cty.Value", v)
 	}
 }
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the post-processing tests")
+
+// TestPostprocessing runs the post-processing steps of each provider part on its corpus in testdata/postprocessing and compares the result with the golden directory.
+// Run with -update to regenerate the golden files.
+func TestPostprocessing(t *testing.T) {
+	t.Parallel()
+
+	for name := range PostprocessingSteps {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			corpusDir := filepath.Join("testdata", "postprocessing", name)
+			goldenDir := filepath.Join(corpusDir, "golden")
+			tempDir := t.TempDir()
+			input, err := os.ReadFile(filepath.Join(corpusDir, "resources.tf"))
+			require.NoError(t, err)
+			fpath := filepath.Join(tempDir, "resources.tf")
+			require.NoError(t, os.WriteFile(fpath, input, 0600))
+
+			require.NoError(t, postprocess(fpath, name))
+			got := readDirFiles(t, tempDir)
+
+			if *updateGolden {
+				require.NoError(t, os.RemoveAll(goldenDir))
+				for path, content := range got {
+					require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(goldenDir, path)), 0750))
+					require.NoError(t, os.WriteFile(filepath.Join(goldenDir, path), []byte(content), 0600))
+				}
+			}
+			assert.Equal(t, readDirFiles(t, goldenDir), got)
+
+			// The output must be valid HCL, and post-processing it again must not change it
+			_, diags := hclwrite.ParseConfig([]byte(got["resources.tf"]), fpath, hcl.Pos{Line: 1, Column: 1})
+			require.False(t, diags.HasErrors(), diags.Error())
+			require.NoError(t, postprocess(fpath, name))
+			assert.Equal(t, got, readDirFiles(t, tempDir), "post-processing is not idempotent")
+		})
+	}
+}
+
+func TestHCL2ValueFromConfigValue(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		value    interface{}
+		expected cty.Value
+	}{
+		{name: "nil", value: nil, expected: cty.NullVal(cty.DynamicPseudoType)},
+		{name: "bool", value: true, expected: cty.True},
+		{name: "string", value: "${var}", expected: cty.StringVal("${var}")},
+		{name: "int", value: 1, expected: cty.NumberIntVal(1)},
+		{name: "int32", value: int32(2), expected: cty.NumberIntVal(2)},
+		{name: "int64", value: int64(math.MaxInt64), expected: cty.NumberIntVal(math.MaxInt64)},
+		{name: "float32", value: float32(0.5), expected: cty.NumberFloatVal(0.5)},
+		{name: "float64", value: 1.5, expected: cty.NumberFloatVal(1.5)},
+		{name: "json.Number", value: json.Number("123456789012345678901234567890"), expected: cty.MustParseNumberVal("123456789012345678901234567890")},
+		{
+			name:     "nested",
+			value:    map[string]interface{}{"list": []interface{}{"a", json.Number("1"), nil}},
+			expected: cty.ObjectVal(map[string]cty.Value{"list": cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.NumberIntVal(1), cty.NullVal(cty.DynamicPseudoType)})}),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := HCL2ValueFromConfigValue(tc.value)
+			require.NoError(t, err)
+			assert.True(t, tc.expected.RawEquals(got), "expected %#v, got %#v", tc.expected, got)
+		})
+	}
+
+	for _, value := range []interface{}{
+		json.Number("not a number"),
+		math.NaN(),
+		math.Inf(1),
+		struct{}{},
+		map[string]interface{}{"nested": []interface{}{uint8(1)}},
+	} {
+		_, err := HCL2ValueFromConfigValue(value)
+		assert.Error(t, err, "expected an error for %#v", value)
+	}
+}
+
+func TestWrapJSONFieldsInFunction_unconvertible(t *testing.T) {
+	t.Parallel()
+
+	// Attributes that aren't JSON objects are left as strings
+	content := `resource "grafana_data_source" "test" {
+  json_data_encoded = "[1, 2]"
+  name              = "{not json"
+}
+`
+	fpath := filepath.Join(t.TempDir(), "resources.tf")
+	require.NoError(t, os.WriteFile(fpath, []byte(content), 0600))
+	require.NoError(t, wrapJSONFieldsInFunction(fpath))
+
+	got, err := os.ReadFile(fpath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(got))
+}
+
+func FuzzHCL2ValueFromConfigValue(f *testing.F) {
+	f.Add(`{"a": 1, "b": [true, null, "${x}"], "c": {"d": 1.5e300}}`)
+	f.Add(`{"id": 123456789012345678901234567890}`)
+	f.Add(`[]`)
+	f.Fuzz(func(t *testing.T, data string) {
+		for _, useNumber := range []bool{false, true} {
+			decoder := json.NewDecoder(strings.NewReader(data))
+			if useNumber {
+				decoder.UseNumber()
+			}
+			var value interface{}
+			if err := decoder.Decode(&value); err != nil {
+				return
+			}
+			converted, err := HCL2ValueFromConfigValue(value)
+			if err != nil {
+				t.Fatalf("failed to convert decoded JSON %q: %v", data, err)
+			}
+			hclwrite.TokensForValue(converted)
+		}
+	})
+}
+
+func FuzzWrapJSONFieldsInFunction(f *testing.F) {
+	f.Add(`{"title": "$${var}", "panels": [{"id": 1}]}`)
+	f.Add(`{"nested": {"list": [1, 2.5, null, false]}}`)
+	f.Add(`not json`)
+	f.Fuzz(func(t *testing.T, value string) {
+		fpath := filepath.Join(t.TempDir(), "resources.tf")
+		content := hclwrite.NewEmptyFile()
+		content.Body().AppendNewBlock("resource", []string{"grafana_dashboard", "test"}).Body().SetAttributeValue("config_json", cty.StringVal(value))
+		if err := os.WriteFile(fpath, content.Bytes(), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := wrapJSONFieldsInFunction(fpath); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readHCLFile(fpath); err != nil {
+			t.Fatalf("the output of %q isn't valid HCL: %v", value, err)
+		}
+	})
+}
+
+func FuzzStripDefaults(f *testing.F) {
+	for _, name := range []string{"grafana", "cloud"} {
+		content, err := os.ReadFile(filepath.Join("testdata", "postprocessing", name, "resources.tf"))
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(content))
+	}
+	f.Fuzz(func(t *testing.T, content string) {
+		file, diags := hclwrite.ParseConfig([]byte(content), "resources.tf", hcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			return
+		}
+		for _, block := range file.Body().Blocks() {
+			stripDefaultsFromBlock(block, map[string]string{"org_id": " \"1\""})
+		}
+	})
+}
+
+func FuzzAbstractDashboards(f *testing.F) {
+	f.Add(`{"title": "test", "uid": "test"}`)
+	f.Add(`{"templating": {"list": [{"query": "$${var}"}]}}`)
+	f.Add(`null`)
+	f.Fuzz(func(t *testing.T, configJSON string) {
+		fpath := filepath.Join(t.TempDir(), "resources.tf")
+		content := hclwrite.NewEmptyFile()
+		content.Body().AppendNewBlock("resource", []string{"grafana_dashboard", "test"}).Body().SetAttributeValue("config_json", cty.StringVal(configJSON))
+		if err := os.WriteFile(fpath, content.Bytes(), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		// Invalid JSON is reported as an error
+		_ = abstractDashboards(fpath)
+	})
+}
+
+// readDirFiles returns the content of the files of a directory, by relative path
+func readDirFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relativePath)] = string(content)
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
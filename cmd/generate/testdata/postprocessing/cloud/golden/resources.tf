@@ -0,0 +1,57 @@
+# __generated__ by Terraform
+# Please review these resources and move them into your main configuration files.
+
+# __generated__ by Terraform from "my-stack"
+resource "grafana_cloud_stack" "my_stack" {
+  delete_protection          = true
+  name                       = "my-stack"
+  region_slug                = "us"
+  slug                       = "my-stack"
+  url                        = "https://my-stack.grafana.net"
+  wait_for_readiness         = true
+  wait_for_readiness_timeout = "5m0s"
+}
+
+# __generated__ by Terraform from "us:my-policy"
+resource "grafana_cloud_access_policy" "my_policy" {
+  display_name = "My Policy"
+  name         = "my-policy"
+  region       = "us"
+  scopes       = ["metrics:read", "logs:read"]
+  realm {
+    identifier = "123456"
+    type       = "org"
+    label_policy {
+      selector = "{namespace=\"default\"}"
+    }
+  }
+  realm {
+    identifier = "789"
+    type       = "stack"
+  }
+}
+
+# __generated__ by Terraform from "my-stack:my-plugin"
+resource "grafana_cloud_plugin_installation" "my_plugin" {
+  slug       = "grafana-clock-panel"
+  stack_slug = "my-stack"
+  version    = "2.1.3"
+}
+
+# __generated__ by Terraform from "1:12345"
+resource "grafana_synthetic_monitoring_check" "http" {
+  enabled   = true
+  frequency = 60000
+  job       = "Check"
+  probes    = [1, 2]
+  target    = "https://grafana.com"
+  timeout   = 3000
+  settings {
+    http {
+      body                = "query=$${query}"
+      fail_if_ssl         = false
+      method              = "POST"
+      no_follow_redirects = false
+    }
+  }
+}
@@ -0,0 +1,87 @@
+# __generated__ by Terraform
+# Please review these resources and move them into your main configuration files.
+
+# __generated__ by Terraform from "1:my-folder"
+resource "grafana_folder" "my_folder" {
+  prevent_destroy_if_not_empty = false
+  title                        = "My Folder"
+  uid                          = "my-folder"
+}
+
+# __generated__ by Terraform from "2:other-folder"
+resource "grafana_folder" "other_org_folder" {
+  org_id = "2"
+  title  = "Other Org Folder"
+  uid    = "other-folder"
+}
+
+# __generated__ by Terraform from "1:my-dashboard"
+resource "grafana_dashboard" "my_dashboard" {
+  config_json = file("${path.module}/files/my_dashboard.json")
+  folder      = "my-folder"
+}
+
+# __generated__ by Terraform from "1:already-abstracted"
+resource "grafana_dashboard" "already_abstracted" {
+  config_json = file("${path.module}/files/already_abstracted.json")
+  folder      = "my-folder"
+}
+
+data "grafana_dashboard" "from_data_source" {
+  uid = "my-dashboard"
+}
+
+# __generated__ by Terraform from "1:my-contact-point"
+resource "grafana_contact_point" "my_contact_point" {
+  disable_provenance = true
+  name               = "My Contact Point"
+  email {
+    addresses               = ["one@example.com", "two@example.com"]
+    disable_resolve_message = false
+    single_email            = false
+  }
+}
+
+# __generated__ by Terraform from "1:my-folder:my-group"
+resource "grafana_rule_group" "my_group" {
+  disable_provenance = false
+  folder_uid         = "my-folder"
+  interval_seconds   = 60
+  name               = "my-group"
+  rule {
+    condition      = "B"
+    exec_err_state = "Error"
+    for            = "5m"
+    is_paused      = false
+    name           = "My Rule"
+    no_data_state  = "NoData"
+    data {
+      datasource_uid = "prometheus"
+      model          = "{\"expr\":\"up == 0\",\"intervalMs\":1000,\"maxDataPoints\":43200,\"refId\":\"A\"}"
+      query_type     = ""
+      ref_id         = "A"
+      relative_time_range {
+        from = 600
+        to   = 0
+      }
+    }
+    data {
+      datasource_uid = "__expr__"
+      model          = "{\"conditions\":[{\"evaluator\":{\"params\":[0],\"type\":\"gt\"}}],\"refId\":\"B\",\"type\":\"classic_conditions\"}"
+      query_type     = ""
+      ref_id         = "B"
+      relative_time_range {
+        from = 0
+        to   = 0
+      }
+    }
+  }
+}
+
+# __generated__ by Terraform from "1:not-json"
+resource "grafana_data_source" "not_json" {
+  json_data_encoded = "not json"
+  name              = "Not JSON"
+  type              = "prometheus"
+  url               = "http://prometheus:9090"
+}
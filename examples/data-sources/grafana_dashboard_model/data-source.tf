@@ -0,0 +1,48 @@
+data "grafana_dashboard_model" "test" {
+  uid   = "dashboard-model"
+  title = "Service Overview"
+  tags  = ["terraform"]
+
+  variable {
+    name  = "env"
+    type  = "custom"
+    query = "dev,prod"
+  }
+
+  panel {
+    title = "Requests"
+    grid_pos {
+      w = 12
+      h = 8
+    }
+    target {
+      datasource_type = "prometheus"
+      query_json      = jsonencode({ expr = "sum(rate(http_requests_total{env=\"$env\"}[5m]))" })
+    }
+  }
+
+  row {
+    title     = "Details"
+    y         = 8
+    collapsed = true
+
+    panel {
+      title = "Error rate"
+      type  = "stat"
+      grid_pos {
+        y = 9
+        w = 24
+        h = 4
+      }
+    }
+  }
+
+  link {
+    title = "Runbook"
+    url   = "https://example.com/runbook"
+  }
+}
+
+resource "grafana_dashboard" "test" {
+  config_json = data.grafana_dashboard_model.test.config_json
+}
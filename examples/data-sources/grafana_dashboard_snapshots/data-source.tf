@@ -0,0 +1,11 @@
+resource "grafana_dashboard_snapshot" "postmortem" {
+  name = "Postmortem: Checkout Latency"
+  config_json = jsonencode({
+    title = "Checkout Latency"
+  })
+}
+
+data "grafana_dashboard_snapshots" "postmortems" {
+  query      = "Checkout Latency"
+  depends_on = [grafana_dashboard_snapshot.postmortem]
+}
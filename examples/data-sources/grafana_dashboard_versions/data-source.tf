@@ -0,0 +1,11 @@
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    uid   = "dashboard-versions"
+    title = "Dashboard Versions"
+  })
+  message = "Created by Terraform"
+}
+
+data "grafana_dashboard_versions" "test" {
+  dashboard_uid = grafana_dashboard.test.uid
+}
@@ -0,0 +1,32 @@
+resource "grafana_folder" "teams" {
+  title = "Teams"
+}
+
+resource "grafana_folder" "platform" {
+  title             = "Platform"
+  parent_folder_uid = grafana_folder.teams.uid
+}
+
+resource "grafana_folder" "databases" {
+  title             = "Databases"
+  parent_folder_uid = grafana_folder.platform.uid
+}
+
+resource "grafana_dashboard" "databases" {
+  folder = grafana_folder.databases.uid
+  config_json = jsonencode({
+    title = "Database Overview"
+  })
+}
+
+data "grafana_folder_tree" "teams" {
+  root_folder_uid = grafana_folder.teams.uid
+  depends_on      = [grafana_folder.databases]
+}
+
+// The dashboards of the Teams folder and of all its subfolders
+data "grafana_dashboards" "teams" {
+  folder_uids = [grafana_folder.teams.uid]
+  recursive   = true
+  depends_on  = [grafana_dashboard.databases]
+}
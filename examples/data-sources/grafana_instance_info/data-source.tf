@@ -0,0 +1,6 @@
+data "grafana_instance_info" "current" {}
+
+resource "grafana_folder" "reports" {
+  count = data.grafana_instance_info.current.enterprise_features ? 1 : 0
+  title = "Reports"
+}
@@ -53,7 +53,7 @@ resource "grafana_dashboard" "with_library_panel" {
   })
 }
 
-// dashboard_ids list attribute should contain dashboard id 12345
+// dashboard_uids list attribute should contain the UID of the dashboard
 data "grafana_library_panel" "connected_to_dashboard" {
   uid = grafana_library_panel.dashboard.uid
 
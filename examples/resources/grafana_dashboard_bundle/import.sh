@@ -0,0 +1,2 @@
+terraform import grafana_dashboard_bundle.name "{{ folderUID }}"
+terraform import grafana_dashboard_bundle.name "{{ orgID }}:{{ folderUID }}"
@@ -0,0 +1,19 @@
+resource "grafana_folder" "team" {
+  title = "Team Dashboards"
+}
+
+resource "grafana_dashboard_bundle" "team" {
+  folder = grafana_folder.team.uid
+  prune  = true
+
+  dashboards = {
+    overview = jsonencode({
+      uid   = "team-overview"
+      title = "Team Overview"
+    })
+    alerts = jsonencode({
+      uid   = "team-alerts"
+      title = "Team Alerts"
+    })
+  }
+}
@@ -0,0 +1,2 @@
+terraform import grafana_dashboard_rollback.name "{{ dashboardUID }}"
+terraform import grafana_dashboard_rollback.name "{{ orgID }}:{{ dashboardUID }}"
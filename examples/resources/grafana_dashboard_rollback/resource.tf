@@ -0,0 +1,11 @@
+// Find the version to restore in the dashboard's history
+data "grafana_dashboard_versions" "ops" {
+  dashboard_uid = "ops-overview"
+}
+
+// Restore the dashboard, which is managed in the UI, to its version 3.
+// It is restored again on the next apply if someone saves it in the meantime.
+resource "grafana_dashboard_rollback" "ops" {
+  dashboard_uid = "ops-overview"
+  version       = 3
+}
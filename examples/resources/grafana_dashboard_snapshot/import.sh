@@ -0,0 +1,2 @@
+terraform import grafana_dashboard_snapshot.name "{{ key }}"
+terraform import grafana_dashboard_snapshot.name "{{ orgID }}:{{ key }}"
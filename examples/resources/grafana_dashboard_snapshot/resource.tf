@@ -0,0 +1,12 @@
+resource "grafana_dashboard" "incident" {
+  config_json = jsonencode({
+    title = "Incident Overview"
+    uid   = "incident-overview"
+  })
+}
+
+resource "grafana_dashboard_snapshot" "postmortem" {
+  name        = "Postmortem: Incident Overview"
+  config_json = grafana_dashboard.incident.config_json
+  expires     = 2592000 // 30 days
+}
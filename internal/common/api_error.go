@@ -0,0 +1,189 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	onCallAPI "github.com/grafana/amixr-api-go-client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	SMAPI "github.com/grafana/synthetic-monitoring-api-go-client"
+	frameworkDiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const requestIDHeader = "X-Request-Id"
+
+var (
+	// Errors from OpenAPI generated clients (Grafana Cloud, SLO) start with the HTTP status (ex: 404 Not Found)
+	statusPrefixRegexp = regexp.MustCompile(`^(\d{3}) `)
+	// Errors from the ML client and the legacy Grafana client are in the `status: 404, body: ...` format
+	statusBodyRegexp = regexp.MustCompile(`^status: (\d{3})(?:, body: (?s)(.*))?`)
+)
+
+// APIError is an error returned by one of the APIs used by the provider (Grafana, Grafana Cloud, Synthetic Monitoring, ML, OnCall or SLO)
+// It normalizes the status code, the error message returned by the API and the request ID, when they are available.
+type APIError struct {
+	StatusCode int
+	Message    string
+	RequestID  string
+
+	err error
+}
+
+func (e *APIError) Error() string {
+	return e.err.Error()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// IsNotFound returns true if the API returned a 404 status code
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// AsAPIError converts an error returned by any of the API clients to an APIError.
+// It returns false if the error doesn't contain an HTTP status code (ex: network errors).
+func AsAPIError(err error) (*APIError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	apiErr = &APIError{err: err}
+
+	// Grafana: Typed responses of the OpenAPI client (ex: GetDashboardByUIDNotFound)
+	var typedErr interface{ Code() int }
+	if errors.As(err, &typedErr) {
+		apiErr.StatusCode = typedErr.Code()
+		var payloadErr interface {
+			GetPayload() *models.ErrorResponseBody
+		}
+		if errors.As(err, &payloadErr) {
+			if payload := payloadErr.GetPayload(); payload != nil && payload.Message != nil {
+				apiErr.Message = *payload.Message
+			}
+		}
+		return apiErr, true
+	}
+
+	// Grafana: Responses that are not described in the OpenAPI spec
+	var runtimeErr *runtime.APIError
+	if errors.As(err, &runtimeErr) {
+		apiErr.StatusCode = runtimeErr.Code
+		if resp, ok := runtimeErr.Response.(runtime.ClientResponse); ok {
+			apiErr.Message = resp.Message()
+			apiErr.RequestID = resp.GetHeader(requestIDHeader)
+		}
+		return apiErr, true
+	}
+
+	// OnCall
+	var oncallErr *onCallAPI.ErrorResponse
+	if errors.As(err, &oncallErr) && oncallErr.Response != nil {
+		apiErr.StatusCode = oncallErr.Response.StatusCode
+		apiErr.Message = oncallErr.Message
+		apiErr.RequestID = oncallErr.Response.Header.Get(requestIDHeader)
+		return apiErr, true
+	}
+
+	// Synthetic Monitoring
+	var smErr *SMAPI.HTTPError
+	if errors.As(err, &smErr) {
+		apiErr.StatusCode = smErr.Code
+		apiErr.Message = smErr.Api.Msg
+		if smErr.Api.Error != "" {
+			apiErr.Message = strings.TrimSpace(apiErr.Message + ": " + smErr.Api.Error)
+		}
+		return apiErr, true
+	}
+
+	// Grafana Cloud and SLO: OpenAPI generated clients (GenericOpenAPIError)
+	var genericErr interface {
+		error
+		Body() []byte
+		Model() interface{}
+	}
+	if errors.As(err, &genericErr) {
+		if match := statusPrefixRegexp.FindStringSubmatch(genericErr.Error()); match != nil {
+			apiErr.StatusCode, _ = strconv.Atoi(match[1])
+			apiErr.Message = messageFromBody(genericErr.Body())
+			return apiErr, true
+		}
+		return nil, false
+	}
+
+	// ML and the legacy Grafana client
+	if match := statusBodyRegexp.FindStringSubmatch(err.Error()); match != nil {
+		apiErr.StatusCode, _ = strconv.Atoi(match[1])
+		apiErr.Message = messageFromBody([]byte(match[2]))
+		return apiErr, true
+	}
+
+	return nil, false
+}
+
+// messageFromBody returns the `message` field of a JSON error body, or the whole body if it's not in that format
+func messageFromBody(body []byte) string {
+	var parsed struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Message != "" {
+		return parsed.Message
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// APIErrorDiagnostics returns the diagnostics for an error returned by any of the API clients.
+// If the summary is empty, the error message is used. The detail includes the API error message and request ID, when available.
+func APIErrorDiagnostics(summary string, err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	if summary == "" {
+		summary = err.Error()
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   apiErrorDetail(err),
+		},
+	}
+}
+
+// APIErrorDiagnosticsPluginFramework is the plugin framework equivalent of APIErrorDiagnostics.
+func APIErrorDiagnosticsPluginFramework(summary string, err error) frameworkDiag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	if summary == "" {
+		summary = err.Error()
+	}
+	return frameworkDiag.Diagnostics{frameworkDiag.NewErrorDiagnostic(summary, apiErrorDetail(err))}
+}
+
+func apiErrorDetail(err error) string {
+	detail := err.Error()
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return detail
+	}
+	if apiErr.Message != "" && !strings.Contains(detail, apiErr.Message) {
+		detail += "\n" + apiErr.Message
+	}
+	if apiErr.RequestID != "" {
+		detail += fmt.Sprintf("\nRequest ID: %s", apiErr.RequestID)
+	}
+	return detail
+}
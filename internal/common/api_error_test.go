@@ -0,0 +1,130 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	onCallAPI "github.com/grafana/amixr-api-go-client"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	SMAPI "github.com/grafana/synthetic-monitoring-api-go-client"
+)
+
+// genericOpenAPIError mimics the GenericOpenAPIError type of the OpenAPI generated clients (Grafana Cloud, SLO)
+type genericOpenAPIError struct {
+	body  []byte
+	error string
+}
+
+func (e genericOpenAPIError) Error() string      { return e.error }
+func (e genericOpenAPIError) Body() []byte       { return e.body }
+func (e genericOpenAPIError) Model() interface{} { return nil }
+
+func TestAsAPIError(t *testing.T) {
+	message := "Dashboard not found"
+	smErr := &SMAPI.HTTPError{Code: 404, Status: "404 Not Found", Action: "probe get request"}
+	smErr.Api.Msg = "probe not found"
+
+	for _, tc := range []struct {
+		name               string
+		err                error
+		expectedOK         bool
+		expectedStatusCode int
+		expectedMessage    string
+		expectedRequestID  string
+	}{
+		{
+			name:               "grafana typed response",
+			err:                &dashboards.GetDashboardByUIDNotFound{Payload: &models.ErrorResponseBody{Message: &message}},
+			expectedOK:         true,
+			expectedStatusCode: 404,
+			expectedMessage:    message,
+		},
+		{
+			name:               "grafana undocumented response",
+			err:                runtime.NewAPIError("unknown error", nil, 502),
+			expectedOK:         true,
+			expectedStatusCode: 502,
+		},
+		{
+			name:               "openapi generated client",
+			err:                &genericOpenAPIError{error: "404 Not Found", body: []byte(`{"message":"stack not found"}`)},
+			expectedOK:         true,
+			expectedStatusCode: 404,
+			expectedMessage:    "stack not found",
+		},
+		{
+			name: "openapi generated client decoding error",
+			err:  &genericOpenAPIError{error: "invalid character"},
+		},
+		{
+			name: "oncall",
+			err: &onCallAPI.ErrorResponse{
+				Message: "Not found.",
+				Response: &http.Response{
+					StatusCode: 404,
+					Header:     http.Header{"X-Request-Id": []string{"abc"}},
+					Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "oncall", Path: "/api/v1/routes/1"}},
+				},
+			},
+			expectedOK:         true,
+			expectedStatusCode: 404,
+			expectedMessage:    "Not found.",
+			expectedRequestID:  "abc",
+		},
+		{
+			name:               "synthetic monitoring",
+			err:                smErr,
+			expectedOK:         true,
+			expectedStatusCode: 404,
+			expectedMessage:    "probe not found",
+		},
+		{
+			name:               "machine learning",
+			err:                errors.New(`status: 403, body: {"message":"forbidden"}`),
+			expectedOK:         true,
+			expectedStatusCode: 403,
+			expectedMessage:    "forbidden",
+		},
+		{
+			name:               "wrapped",
+			err:                fmt.Errorf("failed to read: %w", smErr),
+			expectedOK:         true,
+			expectedStatusCode: 404,
+			expectedMessage:    "probe not found",
+		},
+		{
+			name: "network error",
+			err:  errors.New("dial tcp: connection refused"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			apiErr, ok := AsAPIError(tc.err)
+			if ok != tc.expectedOK {
+				t.Fatalf("expected ok=%t, got %t", tc.expectedOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if apiErr.StatusCode != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, apiErr.StatusCode)
+			}
+			if apiErr.Message != tc.expectedMessage {
+				t.Errorf("expected message %q, got %q", tc.expectedMessage, apiErr.Message)
+			}
+			if apiErr.RequestID != tc.expectedRequestID {
+				t.Errorf("expected request ID %q, got %q", tc.expectedRequestID, apiErr.RequestID)
+			}
+			if apiErr.Error() != tc.err.Error() {
+				t.Errorf("expected error %q, got %q", tc.err.Error(), apiErr.Error())
+			}
+			if IsNotFoundError(tc.err) != (tc.expectedStatusCode == 404) {
+				t.Errorf("unexpected IsNotFoundError result")
+			}
+		})
+	}
+}
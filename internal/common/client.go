@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
 
@@ -28,19 +29,72 @@ type Client struct {
 	OnCallClient    *onCallAPI.Client
 	SLOClient       *slo.APIClient
 
-	alertingMutex sync.Mutex
+	// ReadOnly makes all resource create, update and delete operations fail. Reads and data sources are unaffected.
+	ReadOnly bool
+	// StoreDashboardSHA256 makes dashboards store the SHA256 hash of their model JSON in the state, instead of the JSON itself.
+	StoreDashboardSHA256 bool
+
+	alertingMutexes     map[string]*sync.Mutex
+	alertingMutexesLock sync.Mutex
+
+	instanceInfo      *GrafanaInstanceInfo
+	instanceInfoMutex sync.Mutex
 }
 
+// AlertingLockKeysFunc returns the keys of the alerting locks to hold while operating on a resource.
+// Operations on resources that share a key are serialized, other operations run concurrently.
+// Several keys are returned when an operation affects several parts of the configuration (ex: when a resource moves between folders).
+type AlertingLockKeysFunc func(d *schema.ResourceData, meta interface{}) ([]string, error)
+
 // WithAlertingMutex is a helper function that wraps a CRUD Terraform function with a mutex.
-func WithAlertingMutex[T schema.CreateContextFunc | schema.ReadContextFunc | schema.UpdateContextFunc | schema.DeleteContextFunc](f T) T {
+// Alerting APIs update shared configuration (ex: the Alertmanager configuration of an org) with read-modify-write calls,
+// so the mutex is keyed (ex: by org ID) to only serialize operations that can conflict.
+func WithAlertingMutex[T schema.CreateContextFunc | schema.ReadContextFunc | schema.UpdateContextFunc | schema.DeleteContextFunc](lockKeys AlertingLockKeysFunc, f T) T {
 	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-		lock := &meta.(*Client).alertingMutex
-		lock.Lock()
-		defer lock.Unlock()
+		keys, err := lockKeys(d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		unlock := meta.(*Client).LockAlerting(keys...)
+		defer unlock()
 		return f(ctx, d, meta)
 	}
 }
 
+// LockAlerting holds the alerting locks with the given keys (see WithAlertingMutex) until the returned function is called.
+// The locks are taken in a fixed order, so that operations holding several of them can't deadlock.
+func (c *Client) LockAlerting(keys ...string) (unlock func()) {
+	keys = slices.Clone(keys)
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	locks := make([]*sync.Mutex, len(keys))
+	for i, key := range keys {
+		locks[i] = c.alertingMutex(key)
+		locks[i].Lock()
+	}
+	return func() {
+		for i := len(locks) - 1; i >= 0; i-- {
+			locks[i].Unlock()
+		}
+	}
+}
+
+// alertingMutex returns the mutex that serializes the alerting operations with the given key
+func (c *Client) alertingMutex(key string) *sync.Mutex {
+	c.alertingMutexesLock.Lock()
+	defer c.alertingMutexesLock.Unlock()
+	if c.alertingMutexes == nil {
+		c.alertingMutexes = map[string]*sync.Mutex{}
+	}
+	lock, ok := c.alertingMutexes[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.alertingMutexes[key] = lock
+	}
+	return lock
+}
+
 func (c *Client) GrafanaSubpath(path string) string {
 	path = strings.TrimPrefix(path, c.GrafanaAPIURLParsed.Path)
 	return c.GrafanaAPIURLParsed.JoinPath(path).String()
@@ -0,0 +1,138 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockAlerting_sameKeySerializes(t *testing.T) {
+	client := &Client{}
+
+	var running, maxRunning atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer client.LockAlerting("org:1")()
+			n := running.Add(1)
+			for {
+				max := maxRunning.Load()
+				if n <= max || maxRunning.CompareAndSwap(max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), maxRunning.Load())
+}
+
+func TestLockAlerting_differentKeysRunConcurrently(t *testing.T) {
+	client := &Client{}
+
+	// Both operations must hold their lock at the same time to finish
+	var both sync.WaitGroup
+	both.Add(2)
+	done := make(chan struct{})
+	for _, key := range []string{"org:1", "org:2"} {
+		key := key
+		go func() {
+			defer client.LockAlerting(key)()
+			both.Done()
+			both.Wait()
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("operations on different keys were serialized")
+		}
+	}
+}
+
+func TestLockAlerting_overlappingKeys(t *testing.T) {
+	client := &Client{}
+
+	// Operations locking the same keys in different orders (ex: rule groups moving between folders in opposite directions) must not deadlock
+	keySets := [][]string{
+		{"org:1:folder:a", "org:1:folder:b"},
+		{"org:1:folder:b", "org:1:folder:a"},
+		{"org:1:folder:b", "org:1:folder:c", "org:1:folder:b"},
+		{"org:1:folder:c", "org:1:folder:a"},
+	}
+	counter := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		keys := keySets[i%len(keySets)]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Folder a is always locked, so the counter is protected
+			unlock := client.LockAlerting(append(keys, "org:1:folder:a")...)
+			defer unlock()
+			counter++
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("deadlock while locking overlapping keys")
+	}
+	assert.Equal(t, 100, counter)
+
+	// The keys passed by the caller aren't modified
+	keys := []string{"b", "a", "b"}
+	client.LockAlerting(keys...)()
+	assert.Equal(t, []string{"b", "a", "b"}, keys)
+}
+
+func TestWithAlertingMutex(t *testing.T) {
+	client := &Client{}
+	called := false
+	read := WithAlertingMutex[schema.ReadContextFunc](
+		func(d *schema.ResourceData, meta interface{}) ([]string, error) { return []string{"org:1"}, nil },
+		func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			called = true
+			// The lock is held during the call
+			assert.False(t, client.alertingMutex("org:1").TryLock())
+			return nil
+		},
+	)
+	require.False(t, read(context.Background(), nil, client).HasError())
+	assert.True(t, called)
+	assert.True(t, client.alertingMutex("org:1").TryLock())
+
+	// Key errors are returned without calling the function
+	called = false
+	read = WithAlertingMutex[schema.ReadContextFunc](
+		func(d *schema.ResourceData, meta interface{}) ([]string, error) { return nil, errors.New("no client") },
+		func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			called = true
+			return nil
+		},
+	)
+	diags := read(context.Background(), nil, client)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "no client", diags[0].Summary)
+	assert.False(t, called)
+}
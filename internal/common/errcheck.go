@@ -1,11 +1,13 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
 	"github.com/go-openapi/runtime"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -22,7 +24,7 @@ func CheckReadError(resourceType string, d *schema.ResourceData, err error) (ret
 	}
 
 	if !IsNotFoundError(err) {
-		return diag.Errorf("error reading %s with ID`%s`: %v", resourceType, d.Id(), err), true
+		return APIErrorDiagnostics(fmt.Sprintf("error reading %s with ID `%s`", resourceType, d.Id()), err), true
 	}
 
 	return WarnMissing(resourceType, d), true
@@ -40,9 +42,44 @@ func WarnMissing(resourceType string, d *schema.ResourceData) diag.Diagnostics {
 	return diags
 }
 
+// CheckReadErrorPluginFramework is the plugin framework equivalent of CheckReadError:
+// - If the resource no longer exists and 404s, it is removed from state with a warning and true is returned, to stop processing the read.
+// - If there is an error, it is added to the diagnostics and true is returned.
+// - Otherwise, false is returned to continue processing the read.
+func CheckReadErrorPluginFramework(ctx context.Context, resourceType, id string, err error, resp *resource.ReadResponse) (shouldReturn bool) {
+	if err == nil {
+		return false
+	}
+
+	if !IsNotFoundError(err) {
+		resp.Diagnostics.Append(APIErrorDiagnosticsPluginFramework(fmt.Sprintf("error reading %s with ID `%s`", resourceType, id), err)...)
+		return true
+	}
+
+	WarnMissingPluginFramework(ctx, resourceType, id, resp)
+	return true
+}
+
+// WarnMissingPluginFramework is the plugin framework equivalent of WarnMissing.
+func WarnMissingPluginFramework(ctx context.Context, resourceType, id string, resp *resource.ReadResponse) {
+	log.Printf("[WARN] removing %s with ID %q from state because it no longer exists in grafana", resourceType, id)
+	resp.Diagnostics.AddWarning(
+		fmt.Sprintf("%s with ID %q is in Terraform state, but no longer exists in Grafana", resourceType, id),
+		fmt.Sprintf("%q will be recreated when you apply", id),
+	)
+	resp.State.RemoveResource(ctx)
+}
+
+// IsNotFoundError returns true if the error is a 404 returned by any of the API clients
 func IsNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
 	if err, ok := err.(runtime.ClientResponseStatus); ok {
 		return err.IsCode(404)
 	}
+	if apiErr, ok := AsAPIError(err); ok {
+		return apiErr.IsNotFound()
+	}
 	return strings.Contains(err.Error(), NotFoundError) // TODO: Remove when the old client is removed
 }
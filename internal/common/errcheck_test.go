@@ -0,0 +1,69 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	onCallAPI "github.com/grafana/amixr-api-go-client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckReadError(t *testing.T) {
+	apiErr := &onCallAPI.ErrorResponse{
+		Message: "Permission denied.",
+		Response: &http.Response{
+			StatusCode: 403,
+			Header:     http.Header{"X-Request-Id": []string{"abc"}},
+			Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "oncall", Path: "/api/v1/routes/1"}},
+		},
+	}
+	notFoundErr := errors.New(`status: 404, body: {"message":"not found"}`)
+	newData := func() *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+		d.SetId("1")
+		return d
+	}
+
+	t.Run("no error", func(t *testing.T) {
+		diags, shouldReturn := CheckReadError("route", newData(), nil)
+		assert.False(t, shouldReturn)
+		assert.Empty(t, diags)
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		d := newData()
+		diags, shouldReturn := CheckReadError("route", d, apiErr)
+		assert.True(t, shouldReturn)
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Error, diags[0].Severity)
+		assert.Equal(t, "error reading route with ID `1`", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "Permission denied.")
+		assert.Contains(t, diags[0].Detail, "Request ID: abc")
+		assert.Equal(t, "1", d.Id())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		d := newData()
+		diags, shouldReturn := CheckReadError("route", d, notFoundErr)
+		assert.True(t, shouldReturn)
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Empty(t, d.Id())
+	})
+
+	t.Run("plugin framework API error", func(t *testing.T) {
+		resp := &resource.ReadResponse{}
+		assert.True(t, CheckReadErrorPluginFramework(context.Background(), "route", "1", apiErr, resp))
+		require.Len(t, resp.Diagnostics, 1)
+		assert.Equal(t, "error reading route with ID `1`", resp.Diagnostics[0].Summary())
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "Permission denied.")
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "Request ID: abc")
+	})
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	goapitransport "github.com/grafana/grafana-openapi-client-go/pkg/transport"
+)
+
+// SetGrafanaAPITransport makes an OpenAPI client send its requests through the given round tripper (ex: the transport shared by the service clients),
+// instead of the default transport that the client always wraps. The retries of the client are still applied.
+func SetGrafanaAPITransport(client *goapi.GrafanaHTTPAPI, transport http.RoundTripper) error {
+	retryableTransport, err := grafanaAPIRetryableTransport(client)
+	if err != nil {
+		return err
+	}
+	retryableTransport.Transport = transport
+	return nil
+}
+
+// GrafanaAPIWithOrgID returns a copy of an OpenAPI client scoped to the given org (0 for the global scope).
+// WithOrgID rebuilds the transport of the client on top of the default transport,
+// so the round tripper of the original client (ex: the shared transport with the proxy and TLS settings) is set again.
+func GrafanaAPIWithOrgID(client *goapi.GrafanaHTTPAPI, orgID int64) *goapi.GrafanaHTTPAPI {
+	retryableTransport, err := grafanaAPIRetryableTransport(client)
+	client = client.Clone().WithOrgID(orgID)
+	if err == nil {
+		// Can't fail, WithOrgID always creates a runtime with a retryable transport
+		_ = SetGrafanaAPITransport(client, retryableTransport.Transport)
+	}
+	return client
+}
+
+// grafanaAPIRetryableTransport returns the retrying transport of an OpenAPI client, which wraps the round tripper that requests are sent through
+func grafanaAPIRetryableTransport(client *goapi.GrafanaHTTPAPI) (*goapitransport.RetryableTransport, error) {
+	runtime, ok := client.Transport.(*httptransport.Runtime)
+	if !ok {
+		return nil, fmt.Errorf("unexpected Grafana API transport type: %T", client.Transport)
+	}
+	retryableTransport, ok := runtime.Transport.(*goapitransport.RetryableTransport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected Grafana API round tripper type: %T", runtime.Transport)
+	}
+	return retryableTransport, nil
+}
+
+// SubmitGrafanaJSON sends a request to the Grafana API (relative to /api) through the transport of an OpenAPI client.
+// This reuses the auth, org, headers, TLS and retry settings of the client for endpoints that it doesn't cover or doesn't model correctly.
+// The body is sent as JSON if it isn't nil, and the response is decoded into out if it isn't nil.
+func SubmitGrafanaJSON(ctx context.Context, client *goapi.GrafanaHTTPAPI, schemes []string, method, path string, body, out any) error {
+	operation := fmt.Sprintf("[%s %s]", method, path)
+	_, err := client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 operation,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            schemes,
+		Params: runtime.ClientRequestWriterFunc(func(req runtime.ClientRequest, _ strfmt.Registry) error {
+			if body == nil {
+				return nil
+			}
+			return req.SetBodyParam(body)
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (any, error) {
+			if response.Code() != http.StatusOK {
+				message, _ := io.ReadAll(response.Body())
+				return nil, runtime.NewAPIError(operation, messageFromBody(message), response.Code())
+			}
+			if out == nil {
+				return nil, nil
+			}
+			return nil, consumer.Consume(response.Body(), out)
+		}),
+		Context: ctx,
+	})
+	return err
+}
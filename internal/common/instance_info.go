@@ -0,0 +1,150 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+type GrafanaEdition string
+
+const (
+	GrafanaEditionOSS        = GrafanaEdition("oss")
+	GrafanaEditionEnterprise = GrafanaEdition("enterprise")
+	GrafanaEditionCloud      = GrafanaEdition("cloud")
+)
+
+var grafanaCloudDomains = []string{".grafana.net", ".grafana-dev.net", ".grafana-ops.net"}
+
+// GrafanaInstanceInfo describes the Grafana instance that the provider is configured for.
+type GrafanaInstanceInfo struct {
+	// Version is the Grafana version, without pre-release information (ex: 11.0.0-68102 is reported as 11.0.0)
+	Version        *semver.Version
+	VersionString  string
+	Edition        GrafanaEdition
+	FeatureToggles map[string]bool
+}
+
+// HasEnterpriseFeatures returns true if the instance runs Grafana Enterprise or Grafana Cloud
+func (i *GrafanaInstanceInfo) HasEnterpriseFeatures() bool {
+	return i.Edition == GrafanaEditionEnterprise || i.Edition == GrafanaEditionCloud
+}
+
+// HasNestedFolders returns true if folders can be nested and moved on the instance.
+// Before Grafana 11, this requires the nestedFolders feature toggle. It is enabled by default from Grafana 11.
+// Instances of unknown versions are assumed to support nested folders, their API calls will report the actual error.
+func (i *GrafanaInstanceInfo) HasNestedFolders() bool {
+	if enabled, ok := i.FeatureToggles["nestedFolders"]; ok {
+		return enabled
+	}
+	return i.Version == nil || i.Version.Major() >= 11
+}
+
+const grafanaRequirementsErrorDetail = "The resource cannot be created on this Grafana instance. Upgrade the instance or remove the resource from the configuration."
+
+// GrafanaRequirements describes the features of the Grafana instance that a resource needs.
+type GrafanaRequirements struct {
+	// MinVersion is the minimum Grafana version (ex: 10.3.0)
+	MinVersion string
+	// Enterprise is true if the resource requires Grafana Enterprise or Grafana Cloud
+	Enterprise bool
+}
+
+// CheckGrafanaRequirements returns an error describing the first requirement that the Grafana instance doesn't meet.
+// Nothing is checked if the instance information can't be fetched, the resource's API calls will report the actual error.
+func (c *Client) CheckGrafanaRequirements(ctx context.Context, resourceName string, requirements GrafanaRequirements) error {
+	if c.GrafanaAPI == nil {
+		return nil
+	}
+	info, err := c.GrafanaInstanceInfo(ctx)
+	if err != nil {
+		log.Printf("[WARN] not checking the Grafana requirements of %s: %v", resourceName, err)
+		return nil
+	}
+
+	if requirements.Enterprise && !info.HasEnterpriseFeatures() {
+		return fmt.Errorf("%s requires Grafana Enterprise or Grafana Cloud, but the Grafana instance runs the %s edition", resourceName, info.Edition)
+	}
+	if requirements.MinVersion != "" && info.Version != nil {
+		minVersion, err := semver.NewVersion(requirements.MinVersion)
+		if err != nil {
+			return fmt.Errorf("invalid minimum version %q for %s: %w", requirements.MinVersion, resourceName, err) // Coding error
+		}
+		if info.Version.LessThan(minVersion) {
+			return fmt.Errorf("%s requires Grafana >= %s, but the Grafana instance runs version %s", resourceName, minVersion, info.VersionString)
+		}
+	}
+	return nil
+}
+
+type grafanaHealthResponse struct {
+	Version string `json:"version"`
+}
+
+type grafanaFrontendSettingsResponse struct {
+	BuildInfo struct {
+		Version string `json:"version"`
+		Edition string `json:"edition"`
+	} `json:"buildInfo"`
+	FeatureToggles map[string]bool `json:"featureToggles"`
+}
+
+// GrafanaInstanceInfo returns information about the Grafana instance.
+// It is fetched from the API on first use and cached for the lifetime of the provider.
+func (c *Client) GrafanaInstanceInfo(ctx context.Context) (*GrafanaInstanceInfo, error) {
+	if c.GrafanaAPI == nil {
+		return nil, fmt.Errorf("the Grafana client is required to get the instance information. Set the auth and url provider attributes")
+	}
+
+	c.instanceInfoMutex.Lock()
+	defer c.instanceInfoMutex.Unlock()
+	if c.instanceInfo != nil {
+		return c.instanceInfo, nil
+	}
+
+	var health grafanaHealthResponse
+	if err := c.getGrafanaJSON(ctx, "/health", &health); err != nil {
+		return nil, fmt.Errorf("failed to get the Grafana health: %w", err)
+	}
+	var settings grafanaFrontendSettingsResponse
+	if err := c.getGrafanaJSON(ctx, "/frontend/settings", &settings); err != nil {
+		return nil, fmt.Errorf("failed to get the Grafana frontend settings: %w", err)
+	}
+
+	info := &GrafanaInstanceInfo{
+		VersionString:  health.Version,
+		Edition:        GrafanaEditionOSS,
+		FeatureToggles: settings.FeatureToggles,
+	}
+	if info.VersionString == "" {
+		info.VersionString = settings.BuildInfo.Version
+	}
+	if info.FeatureToggles == nil {
+		info.FeatureToggles = map[string]bool{}
+	}
+	if strings.EqualFold(settings.BuildInfo.Edition, "enterprise") {
+		info.Edition = GrafanaEditionEnterprise
+	}
+	for _, domain := range grafanaCloudDomains {
+		if strings.HasSuffix(c.GrafanaAPIURLParsed.Hostname(), domain) {
+			info.Edition = GrafanaEditionCloud
+		}
+	}
+	if version, err := semver.NewVersion(info.VersionString); err != nil {
+		log.Printf("[WARN] could not parse the Grafana version %q: %v", info.VersionString, err)
+	} else {
+		info.Version = semver.New(version.Major(), version.Minor(), version.Patch(), "", "")
+	}
+
+	c.instanceInfo = info
+	return info, nil
+}
+
+// getGrafanaJSON sends a GET request to the Grafana API (relative to /api) with the provider's client
+func (c *Client) getGrafanaJSON(ctx context.Context, path string, out any) error {
+	return SubmitGrafanaJSON(ctx, c.GrafanaAPI, c.GrafanaAPIConfig.Schemes, http.MethodGet, path, nil, out)
+}
@@ -0,0 +1,247 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-openapi/strfmt"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrafanaInstanceInfoHasNestedFolders(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		version        string
+		featureToggles map[string]bool
+		expected       bool
+	}{
+		{name: "toggle disabled by default", version: "10.3.1", expected: false},
+		{name: "toggle enabled", version: "10.3.1", featureToggles: map[string]bool{"nestedFolders": true}, expected: true},
+		{name: "enabled by default", version: "11.0.0", expected: true},
+		{name: "toggle disabled", version: "11.0.0", featureToggles: map[string]bool{"nestedFolders": false}, expected: false},
+		{name: "unknown version", expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			info := &GrafanaInstanceInfo{FeatureToggles: tc.featureToggles}
+			if tc.version != "" {
+				info.Version = semver.MustParse(tc.version)
+			}
+			if got := info.HasNestedFolders(); got != tc.expected {
+				t.Errorf("expected HasNestedFolders to be %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGrafanaInstanceInfo(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		url             string
+		health          string
+		settings        string
+		expectedVersion string
+		expectedString  string
+		expectedEdition GrafanaEdition
+		expectedToggles map[string]bool
+	}{
+		{
+			name:            "OSS",
+			url:             "https://grafana.example.com",
+			health:          `{"version": "10.4.2"}`,
+			settings:        `{"buildInfo": {"version": "10.4.2", "edition": "Open Source"}, "featureToggles": {"nestedFolders": true}}`,
+			expectedVersion: "10.4.2",
+			expectedString:  "10.4.2",
+			expectedEdition: GrafanaEditionOSS,
+			expectedToggles: map[string]bool{"nestedFolders": true},
+		},
+		{
+			name:            "Enterprise",
+			url:             "https://grafana.example.com",
+			health:          `{"version": "11.0.0"}`,
+			settings:        `{"buildInfo": {"version": "11.0.0", "edition": "Enterprise"}}`,
+			expectedVersion: "11.0.0",
+			expectedString:  "11.0.0",
+			expectedEdition: GrafanaEditionEnterprise,
+			expectedToggles: map[string]bool{},
+		},
+		{
+			name:            "Cloud",
+			url:             "https://mystack.grafana.net",
+			health:          `{"version": "11.1.0-70005"}`,
+			settings:        `{"buildInfo": {"version": "11.1.0-70005", "edition": "Enterprise"}}`,
+			expectedVersion: "11.1.0",
+			expectedString:  "11.1.0-70005",
+			expectedEdition: GrafanaEditionCloud,
+			expectedToggles: map[string]bool{},
+		},
+		{
+			name:            "version from the build info",
+			url:             "https://grafana.example.com",
+			health:          `{}`,
+			settings:        `{"buildInfo": {"version": "10.0.0", "edition": "Open Source"}}`,
+			expectedVersion: "10.0.0",
+			expectedString:  "10.0.0",
+			expectedEdition: GrafanaEditionOSS,
+			expectedToggles: map[string]bool{},
+		},
+		{
+			name:            "unparseable version",
+			url:             "https://grafana.example.com",
+			health:          `{"version": "main"}`,
+			settings:        `{"buildInfo": {"version": "main", "edition": "Open Source"}}`,
+			expectedString:  "main",
+			expectedEdition: GrafanaEditionOSS,
+			expectedToggles: map[string]bool{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client, requests := newInstanceInfoTestClient(t, tc.url, tc.health, tc.settings)
+
+			info, err := client.GrafanaInstanceInfo(context.Background())
+			require.NoError(t, err)
+			if tc.expectedVersion == "" {
+				assert.Nil(t, info.Version)
+			} else {
+				require.NotNil(t, info.Version)
+				assert.Equal(t, tc.expectedVersion, info.Version.String())
+			}
+			assert.Equal(t, tc.expectedString, info.VersionString)
+			assert.Equal(t, tc.expectedEdition, info.Edition)
+			assert.Equal(t, tc.expectedToggles, info.FeatureToggles)
+
+			// The information is cached
+			cached, err := client.GrafanaInstanceInfo(context.Background())
+			require.NoError(t, err)
+			assert.Same(t, info, cached)
+			assert.Equal(t, 2, *requests)
+		})
+	}
+}
+
+func TestCheckGrafanaRequirements(t *testing.T) {
+	ossSettings := `{"buildInfo": {"version": "10.4.2", "edition": "Open Source"}}`
+	for _, tc := range []struct {
+		name          string
+		url           string
+		health        string
+		settings      string
+		requirements  GrafanaRequirements
+		expectedError string
+	}{
+		{
+			name:         "no requirements",
+			url:          "https://grafana.example.com",
+			health:       `{"version": "10.4.2"}`,
+			settings:     ossSettings,
+			requirements: GrafanaRequirements{},
+		},
+		{
+			name:         "minimum version met",
+			url:          "https://grafana.example.com",
+			health:       `{"version": "10.4.2"}`,
+			settings:     ossSettings,
+			requirements: GrafanaRequirements{MinVersion: "10.4.0"},
+		},
+		{
+			name:          "minimum version not met",
+			url:           "https://grafana.example.com",
+			health:        `{"version": "10.4.2"}`,
+			settings:      ossSettings,
+			requirements:  GrafanaRequirements{MinVersion: "11.0.0"},
+			expectedError: "grafana_test requires Grafana >= 11.0.0, but the Grafana instance runs version 10.4.2",
+		},
+		{
+			name:         "pre-release of the minimum version",
+			url:          "https://mystack.grafana.net",
+			health:       `{"version": "11.0.0-68102"}`,
+			settings:     ossSettings,
+			requirements: GrafanaRequirements{MinVersion: "11.0.0", Enterprise: true},
+		},
+		{
+			name:          "Enterprise required on OSS",
+			url:           "https://grafana.example.com",
+			health:        `{"version": "10.4.2"}`,
+			settings:      ossSettings,
+			requirements:  GrafanaRequirements{Enterprise: true},
+			expectedError: "grafana_test requires Grafana Enterprise or Grafana Cloud, but the Grafana instance runs the oss edition",
+		},
+		{
+			name:         "Enterprise required on Enterprise",
+			url:          "https://grafana.example.com",
+			health:       `{"version": "10.4.2"}`,
+			settings:     `{"buildInfo": {"version": "10.4.2", "edition": "Enterprise"}}`,
+			requirements: GrafanaRequirements{Enterprise: true},
+		},
+		{
+			name:         "Enterprise required on Cloud",
+			url:          "https://mystack.grafana.net",
+			health:       `{"version": "10.4.2"}`,
+			settings:     ossSettings,
+			requirements: GrafanaRequirements{Enterprise: true},
+		},
+		{
+			name:         "unparseable version isn't checked",
+			url:          "https://grafana.example.com",
+			health:       `{"version": "main"}`,
+			settings:     ossSettings,
+			requirements: GrafanaRequirements{MinVersion: "11.0.0"},
+		},
+		{
+			name:         "unavailable instance information isn't checked",
+			url:          "https://grafana.example.com",
+			requirements: GrafanaRequirements{MinVersion: "11.0.0", Enterprise: true},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client, _ := newInstanceInfoTestClient(t, tc.url, tc.health, tc.settings)
+
+			err := client.CheckGrafanaRequirements(context.Background(), "grafana_test", tc.requirements)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+
+	// Without a Grafana client, nothing is checked
+	assert.NoError(t, (&Client{}).CheckGrafanaRequirements(context.Background(), "grafana_test", GrafanaRequirements{Enterprise: true}))
+}
+
+// newInstanceInfoTestClient returns a client for a Grafana instance at the given URL, whose API returns the given health and frontend settings.
+// The endpoints return a 500 error if their response is empty. The number of requests is returned along with the client.
+func newInstanceInfoTestClient(t *testing.T, instanceURL, health, settings string) (*Client, *int) {
+	t.Helper()
+
+	requests := 0
+	responses := map[string]string{"/api/health": health, "/api/frontend/settings": settings}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		response := responses[r.URL.Path]
+		if response == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			response = `{"message": "error"}`
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	instanceURLParsed, err := url.Parse(instanceURL)
+	require.NoError(t, err)
+	cfg := &goapi.TransportConfig{Host: serverURL.Host, BasePath: "/api", Schemes: []string{"http"}}
+	return &Client{
+		GrafanaAPIURL:       instanceURL,
+		GrafanaAPIURLParsed: instanceURLParsed,
+		GrafanaAPI:          goapi.NewHTTPClientWithConfig(strfmt.Default, cfg),
+		GrafanaAPIConfig:    cfg,
+	}, &requests
+}
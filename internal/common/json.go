@@ -1,37 +1,79 @@
 package common
 
 import (
-	"bytes"
 	"encoding/json"
-	"reflect"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func SuppressEquivalentJSONDiffs(k, old, new string, d *schema.ResourceData) bool {
-	ob := bytes.NewBufferString("")
-	if err := json.Compact(ob, []byte(old)); err != nil {
-		return false
+// NormalizedJSON describes a JSON string attribute that is compared semantically.
+// Key order and whitespace are ignored, and the fields that the API adds or manages (ex: IDs, versions, default values)
+// are normalized by NormalizeFields, so that users don't get perpetual diffs when they don't set them in their configuration.
+//
+// It provides the StateFunc and DiffSuppressFunc of SDKv2 attributes, and a plugin framework type with semantic equality (see FrameworkType).
+type NormalizedJSON struct {
+	// NormalizeFields modifies a decoded JSON object in place to normalize the fields managed by the API
+	// (ex: removing IDs or default values, setting fields that the API always returns). It is optional.
+	NormalizeFields func(map[string]interface{})
+}
+
+// Normalize returns the compact JSON encoding of the given value, with sorted keys and the fields managed by the API normalized.
+// The value is either a JSON string or an already decoded JSON object. An empty string is returned as is.
+func (j NormalizedJSON) Normalize(value interface{}) (string, error) {
+	var decoded interface{}
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		if v == "" {
+			return "", nil
+		}
+		if err := json.Unmarshal([]byte(v), &decoded); err != nil {
+			return v, err
+		}
+	case map[string]interface{}:
+		decoded = v
+	default:
+		return "", fmt.Errorf("unsupported JSON value type %T", value)
 	}
 
-	nb := bytes.NewBufferString("")
-	if err := json.Compact(nb, []byte(new)); err != nil {
-		return false
+	if object, ok := decoded.(map[string]interface{}); ok && j.NormalizeFields != nil {
+		j.NormalizeFields(object)
 	}
 
-	return JSONBytesEqual(ob.Bytes(), nb.Bytes())
+	normalized, err := json.Marshal(decoded)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
 }
 
-func JSONBytesEqual(b1, b2 []byte) bool {
-	var o1 interface{}
-	if err := json.Unmarshal(b1, &o1); err != nil {
+// Equal returns true if both JSON strings are the same once normalized. Invalid JSON strings are never equal.
+func (j NormalizedJSON) Equal(a, b string) bool {
+	normalizedA, err := j.Normalize(a)
+	if err != nil {
 		return false
 	}
-
-	var o2 interface{}
-	if err := json.Unmarshal(b2, &o2); err != nil {
+	normalizedB, err := j.Normalize(b)
+	if err != nil {
 		return false
 	}
+	return normalizedA == normalizedB
+}
+
+// StateFunc is the SDKv2 StateFunc of the attribute. Invalid JSON is stored as is, it should be caught by validation.
+func (j NormalizedJSON) StateFunc(value interface{}) string {
+	normalized, err := j.Normalize(value)
+	if err != nil {
+		if s, ok := value.(string); ok {
+			return s
+		}
+	}
+	return normalized
+}
 
-	return reflect.DeepEqual(o1, o2)
+// DiffSuppressFunc is the SDKv2 DiffSuppressFunc of the attribute.
+func (j NormalizedJSON) DiffSuppressFunc(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return j.Equal(oldValue, newValue)
 }
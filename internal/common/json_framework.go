@@ -0,0 +1,123 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = NormalizedJSONType{}
+	_ xattr.TypeWithValidate                     = NormalizedJSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = NormalizedJSONValue{}
+)
+
+// FrameworkType returns the plugin framework type of the attribute.
+// Values of this type are semantically equal when they are the same once normalized, so the framework keeps the prior value instead of showing a diff.
+func (j NormalizedJSON) FrameworkType() NormalizedJSONType {
+	return NormalizedJSONType{normalizedJSON: j}
+}
+
+// FrameworkValue returns a plugin framework value of the attribute.
+func (j NormalizedJSON) FrameworkValue(value string) NormalizedJSONValue {
+	return NormalizedJSONValue{StringValue: basetypes.NewStringValue(value), normalizedJSON: j}
+}
+
+// NormalizedJSONType is the plugin framework type of a NormalizedJSON attribute.
+type NormalizedJSONType struct {
+	basetypes.StringType
+	normalizedJSON NormalizedJSON
+}
+
+func (t NormalizedJSONType) String() string {
+	return "common.NormalizedJSONType"
+}
+
+func (t NormalizedJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(NormalizedJSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t NormalizedJSONType) ValueType(ctx context.Context) attr.Value {
+	return NormalizedJSONValue{normalizedJSON: t.normalizedJSON}
+}
+
+func (t NormalizedJSONType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return NormalizedJSONValue{StringValue: in, normalizedJSON: t.normalizedJSON}, nil
+}
+
+func (t NormalizedJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return NormalizedJSONValue{StringValue: stringValue, normalizedJSON: t.normalizedJSON}, nil
+}
+
+// Validate checks that known values are valid JSON.
+func (t NormalizedJSONType) Validate(ctx context.Context, in tftypes.Value, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var value string
+	if err := in.As(&value); err != nil {
+		diags.AddAttributeError(p, "Invalid JSON String Value", err.Error())
+		return diags
+	}
+	if _, err := t.normalizedJSON.Normalize(value); err != nil {
+		diags.AddAttributeError(p, "Invalid JSON String Value", fmt.Sprintf("A string value was provided that is not valid JSON: %s", err))
+	}
+	return diags
+}
+
+// NormalizedJSONValue is the plugin framework value of a NormalizedJSON attribute.
+type NormalizedJSONValue struct {
+	basetypes.StringValue
+	normalizedJSON NormalizedJSON
+}
+
+func (v NormalizedJSONValue) Type(ctx context.Context) attr.Type {
+	return NormalizedJSONType{normalizedJSON: v.normalizedJSON}
+}
+
+func (v NormalizedJSONValue) Equal(o attr.Value) bool {
+	other, ok := o.(NormalizedJSONValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if both values are the same JSON once normalized.
+func (v NormalizedJSONValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(NormalizedJSONValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+	return v.normalizedJSON.Equal(v.ValueString(), newValue.ValueString()), diags
+}
+
+// Normalized returns the normalized JSON of the value.
+func (v NormalizedJSONValue) Normalized() (string, error) {
+	return v.normalizedJSON.Normalize(v.ValueString())
+}
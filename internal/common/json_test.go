@@ -0,0 +1,147 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var testNormalizedJSON = NormalizedJSON{
+	NormalizeFields: func(object map[string]interface{}) {
+		delete(object, "id")
+	},
+}
+
+func TestNormalizedJSONNormalize(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		value         interface{}
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "key order and whitespace",
+			value:    "{\n  \"b\": 1,\n  \"a\": {\"d\": true, \"c\": null}\n}",
+			expected: `{"a":{"c":null,"d":true},"b":1}`,
+		},
+		{
+			name:     "normalized fields",
+			value:    `{"id": 12, "title": "test"}`,
+			expected: `{"title":"test"}`,
+		},
+		{
+			name:     "decoded object",
+			value:    map[string]interface{}{"id": 12, "title": "test"},
+			expected: `{"title":"test"}`,
+		},
+		{
+			name:     "not an object",
+			value:    `[ {"id": 12} ]`,
+			expected: `[{"id":12}]`,
+		},
+		{
+			name:     "empty",
+			value:    "",
+			expected: "",
+		},
+		{
+			name:          "invalid",
+			value:         "{",
+			expected:      "{",
+			expectedError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			normalized, err := testNormalizedJSON.Normalize(tc.value)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if normalized != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, normalized)
+			}
+			if stateValue := testNormalizedJSON.StateFunc(tc.value); stateValue != tc.expected {
+				t.Errorf("expected state value %q, got %q", tc.expected, stateValue)
+			}
+		})
+	}
+}
+
+func TestNormalizedJSONEqual(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{
+			name:     "same",
+			a:        `{"a":1,"b":2}`,
+			b:        `{ "b": 2, "a": 1 }`,
+			expected: true,
+		},
+		{
+			name:     "normalized fields",
+			a:        `{"a":1}`,
+			b:        `{"a":1,"id":3}`,
+			expected: true,
+		},
+		{
+			name:     "different",
+			a:        `{"a":1}`,
+			b:        `{"a":2}`,
+			expected: false,
+		},
+		{
+			name:     "invalid",
+			a:        `{`,
+			b:        `{`,
+			expected: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if equal := testNormalizedJSON.DiffSuppressFunc("attr", tc.a, tc.b, nil); equal != tc.expected {
+				t.Errorf("expected DiffSuppressFunc to return %t, got %t", tc.expected, equal)
+			}
+
+			ctx := context.Background()
+			equal, diags := testNormalizedJSON.FrameworkValue(tc.a).StringSemanticEquals(ctx, testNormalizedJSON.FrameworkValue(tc.b))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != tc.expected {
+				t.Errorf("expected StringSemanticEquals to return %t, got %t", tc.expected, equal)
+			}
+		})
+	}
+}
+
+func TestNormalizedJSONFrameworkType(t *testing.T) {
+	ctx := context.Background()
+	jsonType := testNormalizedJSON.FrameworkType()
+
+	value, err := jsonType.ValueFromTerraform(ctx, tftypes.NewValue(tftypes.String, `{"id":1,"a":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonValue, ok := value.(NormalizedJSONValue)
+	if !ok {
+		t.Fatalf("expected a NormalizedJSONValue, got %T", value)
+	}
+	if normalized, err := jsonValue.Normalized(); err != nil || normalized != `{"a":2}` {
+		t.Errorf("unexpected normalized value %q (error: %v)", normalized, err)
+	}
+	if !jsonValue.Type(ctx).Equal(jsonType) {
+		t.Errorf("expected the value type to be %s", jsonType)
+	}
+
+	if diags := jsonType.Validate(ctx, tftypes.NewValue(tftypes.String, `{"a":1}`), path.Root("attr")); diags.HasError() {
+		t.Errorf("unexpected diagnostics for valid JSON: %v", diags)
+	}
+	if diags := jsonType.Validate(ctx, tftypes.NewValue(tftypes.String, `{`), path.Root("attr")); !diags.HasError() {
+		t.Error("expected diagnostics for invalid JSON")
+	}
+	if diags := jsonType.Validate(ctx, tftypes.NewValue(tftypes.String, tftypes.UnknownValue), path.Root("attr")); diags.HasError() {
+		t.Errorf("unexpected diagnostics for unknown value: %v", diags)
+	}
+}
@@ -0,0 +1,46 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const readOnlyErrorDetail = "The provider is configured with `read_only = true` (or the `GRAFANA_READ_ONLY` environment variable), so resources can be read but not created, updated or deleted."
+
+func readOnlyErrorSummary(resourceName, operation string) string {
+	return fmt.Sprintf("cannot %s %s: the provider is in read-only mode", operation, resourceName)
+}
+
+// addReadOnlyCheckToSchema wraps the Create, Update and Delete functions of an SDKv2 resource so that they fail when the provider is in read-only mode.
+func addReadOnlyCheckToSchema(resourceName string, r *schema.Resource) {
+	if r == nil {
+		return
+	}
+	if r.CreateContext != nil {
+		r.CreateContext = withReadOnlyCheck(resourceName, "create", r.CreateContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = withReadOnlyCheck(resourceName, "update", r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = withReadOnlyCheck(resourceName, "delete", r.DeleteContext)
+	}
+}
+
+func withReadOnlyCheck[T schema.CreateContextFunc | schema.UpdateContextFunc | schema.DeleteContextFunc](resourceName, operation string, f T) T {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if client, ok := meta.(*Client); ok && client.ReadOnly {
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  readOnlyErrorSummary(resourceName, operation),
+					Detail:   readOnlyErrorDetail,
+				},
+			}
+		}
+		return f(ctx, d, meta)
+	}
+}
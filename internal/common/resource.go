@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -14,6 +17,11 @@ import (
 // The data arg can be used to pass information between different listers. For example, the list of stacks will be used when listing stack plugins.
 type ResourceListIDsFunc func(ctx context.Context, client *Client, data any) ([]string, error)
 
+// PlanWarningsFunc returns warnings to show when an SDKv2 resource is created or updated, from its configuration.
+// It is called after the plan succeeded. CustomizeDiff functions can only fail the plan, so they can't report warnings.
+// Values of the configuration that depend on other resources may not be known yet.
+type PlanWarningsFunc func(ctx context.Context, config cty.Value, meta interface{}) diag.Diagnostics
+
 // Resource represents a Terraform resource, implemented either with the SDKv2 or Terraform Plugin Framework.
 type Resource struct {
 	Name                  string
@@ -21,9 +29,12 @@ type Resource struct {
 	ListIDsFunc           ResourceListIDsFunc
 	Schema                *schema.Resource
 	PluginFrameworkSchema resource.ResourceWithConfigure
+	PlanWarningsFunc      PlanWarningsFunc
 }
 
 func NewLegacySDKResource(name string, idType *ResourceID, schema *schema.Resource) *Resource {
+	addReadOnlyCheckToSchema(name, schema)
+	addDefaultTimeoutsToSchema(schema)
 	r := &Resource{
 		Name:   name,
 		IDType: idType,
@@ -36,7 +47,7 @@ func NewResource(name string, idType *ResourceID, schema resource.ResourceWithCo
 	r := &Resource{
 		Name:                  name,
 		IDType:                idType,
-		PluginFrameworkSchema: schema,
+		PluginFrameworkSchema: &frameworkResourceWrapper{ResourceWithConfigure: schema, name: name},
 	}
 	return r
 }
@@ -46,6 +57,36 @@ func (r *Resource) WithLister(lister ResourceListIDsFunc) *Resource {
 	return r
 }
 
+// WithPlanWarnings sets the function that returns the plan warnings of an SDKv2 resource.
+// Plugin Framework resources can return warnings from ModifyPlan instead.
+func (r *Resource) WithPlanWarnings(f PlanWarningsFunc) *Resource {
+	r.PlanWarningsFunc = f
+	return r
+}
+
+// WithGrafanaRequirements sets the features that the Grafana instance must have for the resource to be created.
+// They are checked at plan time so that users get a clear error instead of an API error (often a 404) on apply.
+func (r *Resource) WithGrafanaRequirements(requirements GrafanaRequirements) *Resource {
+	if r.Schema != nil {
+		check := func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			client, ok := meta.(*Client)
+			if !ok || d.Id() != "" {
+				return nil
+			}
+			return client.CheckGrafanaRequirements(ctx, r.Name, requirements)
+		}
+		if r.Schema.CustomizeDiff != nil {
+			r.Schema.CustomizeDiff = customdiff.All(r.Schema.CustomizeDiff, check)
+		} else {
+			r.Schema.CustomizeDiff = check
+		}
+	}
+	if wrapper, ok := r.PluginFrameworkSchema.(*frameworkResourceWrapper); ok {
+		wrapper.requirements = &requirements
+	}
+	return r
+}
+
 func (r *Resource) ImportExample() string {
 	exampleFromFields := func(fields []ResourceIDField) string {
 		fieldTemplates := make([]string, len(fields))
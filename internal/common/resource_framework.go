@@ -0,0 +1,126 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+var (
+	_ resource.ResourceWithImportState      = (*frameworkResourceWrapper)(nil)
+	_ resource.ResourceWithModifyPlan       = (*frameworkResourceWrapper)(nil)
+	_ resource.ResourceWithUpgradeState     = (*frameworkResourceWrapper)(nil)
+	_ resource.ResourceWithMoveState        = (*frameworkResourceWrapper)(nil)
+	_ resource.ResourceWithValidateConfig   = (*frameworkResourceWrapper)(nil)
+	_ resource.ResourceWithConfigValidators = (*frameworkResourceWrapper)(nil)
+)
+
+// frameworkResourceWrapper wraps a plugin framework resource to add the checks that apply to all resources:
+// - Create, Update and Delete fail when the provider is in read-only mode
+// - New resources are checked against the Grafana requirements of the resource at plan time
+// - Create, Read, Update and Delete are cancelled once the timeout set in the `timeouts` block of the resource (see TimeoutsBlock) is reached
+//
+// The optional interfaces of the wrapped resource (import, plan modification, state upgrade and move, config validation) are forwarded to it.
+type frameworkResourceWrapper struct {
+	resource.ResourceWithConfigure
+	name         string
+	requirements *GrafanaRequirements
+	client       *Client
+}
+
+func (r *frameworkResourceWrapper) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if client, ok := req.ProviderData.(*Client); ok {
+		r.client = client
+	}
+	r.ResourceWithConfigure.Configure(ctx, req, resp)
+}
+
+func (r *frameworkResourceWrapper) readOnly() bool {
+	return r.client != nil && r.client.ReadOnly
+}
+
+func (r *frameworkResourceWrapper) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly() {
+		resp.Diagnostics.AddError(readOnlyErrorSummary(r.name, "create"), readOnlyErrorDetail)
+		return
+	}
+	ctx, cancel := contextWithFrameworkTimeout(ctx, tfsdk.State(req.Plan), "create")
+	defer cancel()
+	r.ResourceWithConfigure.Create(ctx, req, resp)
+}
+
+func (r *frameworkResourceWrapper) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, cancel := contextWithFrameworkTimeout(ctx, req.State, "read")
+	defer cancel()
+	r.ResourceWithConfigure.Read(ctx, req, resp)
+}
+
+func (r *frameworkResourceWrapper) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly() {
+		resp.Diagnostics.AddError(readOnlyErrorSummary(r.name, "update"), readOnlyErrorDetail)
+		return
+	}
+	ctx, cancel := contextWithFrameworkTimeout(ctx, tfsdk.State(req.Plan), "update")
+	defer cancel()
+	r.ResourceWithConfigure.Update(ctx, req, resp)
+}
+
+func (r *frameworkResourceWrapper) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly() {
+		resp.Diagnostics.AddError(readOnlyErrorSummary(r.name, "delete"), readOnlyErrorDetail)
+		return
+	}
+	ctx, cancel := contextWithFrameworkTimeout(ctx, req.State, "delete")
+	defer cancel()
+	r.ResourceWithConfigure.Delete(ctx, req, resp)
+}
+
+func (r *frameworkResourceWrapper) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importer, ok := r.ResourceWithConfigure.(resource.ResourceWithImportState); ok {
+		importer.ImportState(ctx, req, resp)
+		return
+	}
+	resp.Diagnostics.AddError("Resource Import Not Implemented", fmt.Sprintf("%s does not support import", r.name))
+}
+
+func (r *frameworkResourceWrapper) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only new resources are checked. If the resource already exists, the instance obviously supports it
+	if r.requirements != nil && r.client != nil && req.State.Raw.IsNull() && !req.Plan.Raw.IsNull() {
+		if err := r.client.CheckGrafanaRequirements(ctx, r.name, *r.requirements); err != nil {
+			resp.Diagnostics.AddError(err.Error(), grafanaRequirementsErrorDetail)
+			return
+		}
+	}
+	if modifier, ok := r.ResourceWithConfigure.(resource.ResourceWithModifyPlan); ok {
+		modifier.ModifyPlan(ctx, req, resp)
+	}
+}
+
+func (r *frameworkResourceWrapper) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	if upgrader, ok := r.ResourceWithConfigure.(resource.ResourceWithUpgradeState); ok {
+		return upgrader.UpgradeState(ctx)
+	}
+	return nil
+}
+
+func (r *frameworkResourceWrapper) MoveState(ctx context.Context) []resource.StateMover {
+	if mover, ok := r.ResourceWithConfigure.(resource.ResourceWithMoveState); ok {
+		return mover.MoveState(ctx)
+	}
+	return nil
+}
+
+func (r *frameworkResourceWrapper) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if validator, ok := r.ResourceWithConfigure.(resource.ResourceWithValidateConfig); ok {
+		validator.ValidateConfig(ctx, req, resp)
+	}
+}
+
+func (r *frameworkResourceWrapper) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	if validator, ok := r.ResourceWithConfigure.(resource.ResourceWithConfigValidators); ok {
+		return validator.ConfigValidators(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFrameworkResourceWrapperForwardsInterfaces(t *testing.T) {
+	calls := map[string]bool{}
+	server, err := providerserver.NewProtocol5WithError(&testFrameworkProvider{resources: []func() resource.Resource{
+		func() resource.Resource {
+			return NewResource("test_full", nil, &testFrameworkFullResource{testFrameworkResource{name: "test_full"}, calls}).PluginFrameworkSchema
+		},
+		func() resource.Resource {
+			return NewResource("test_minimal", nil, &testFrameworkResource{name: "test_minimal"}).PluginFrameworkSchema
+		},
+	}})()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	config, err := tfprotov5.NewDynamicValue(testFrameworkResourceType, tftypes.NewValue(testFrameworkResourceType, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, nil),
+		"name": tftypes.NewValue(tftypes.String, "name"),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, typeName := range []string{"test_full", "test_minimal"} {
+		validateResp, err := server.ValidateResourceTypeConfig(ctx, &tfprotov5.ValidateResourceTypeConfigRequest{TypeName: typeName, Config: &config})
+		checkFrameworkCall(t, typeName+" validate", err, validateResp.Diagnostics)
+
+		// The state of the current schema version isn't upgraded
+		upgradeResp, err := server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+			TypeName: typeName,
+			Version:  1,
+			RawState: &tfprotov5.RawState{JSON: []byte(`{"id": "1", "name": "name"}`)},
+		})
+		checkFrameworkCall(t, typeName+" upgrade from the current version", err, upgradeResp.Diagnostics)
+	}
+
+	upgradeResp, err := server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: "test_full",
+		Version:  0,
+		RawState: &tfprotov5.RawState{JSON: []byte(`{"id": "1", "title": "name"}`)},
+	})
+	checkFrameworkCall(t, "upgrade", err, upgradeResp.Diagnostics)
+
+	moveResp, err := server.MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.terraform.io/grafana/grafana",
+		SourceTypeName:        "test_old",
+		SourceState:           &tfprotov5.RawState{JSON: []byte(`{"id": "1", "name": "name"}`)},
+		TargetTypeName:        "test_full",
+	})
+	checkFrameworkCall(t, "move", err, moveResp.Diagnostics)
+
+	for _, call := range []string{"ValidateConfig", "ConfigValidators", "UpgradeState", "MoveState"} {
+		if !calls[call] {
+			t.Errorf("%s of the wrapped resource wasn't called", call)
+		}
+	}
+
+	// Resources without state upgraders can't upgrade older states
+	upgradeResp, err = server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: "test_minimal",
+		Version:  0,
+		RawState: &tfprotov5.RawState{JSON: []byte(`{"id": "1", "title": "name"}`)},
+	})
+	if err != nil || len(upgradeResp.Diagnostics) == 0 {
+		t.Errorf("expected an error when upgrading the state of a resource without state upgraders, got %v, %v", err, upgradeResp.Diagnostics)
+	}
+}
+
+func checkFrameworkCall(t *testing.T, name string, err error, diags []*tfprotov5.Diagnostic) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	for _, d := range diags {
+		t.Errorf("%s: %s: %s", name, d.Summary, d.Detail)
+	}
+}
+
+var testFrameworkResourceType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String, "name": tftypes.String}}
+
+type testFrameworkProvider struct {
+	resources []func() resource.Resource
+}
+
+func (p *testFrameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "test"
+}
+
+func (p *testFrameworkProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {
+}
+
+func (p *testFrameworkProvider) Configure(context.Context, provider.ConfigureRequest, *provider.ConfigureResponse) {
+}
+
+func (p *testFrameworkProvider) Resources(context.Context) []func() resource.Resource {
+	return p.resources
+}
+
+func (p *testFrameworkProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+type testFrameworkResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+// testFrameworkResource is a resource that only implements the required interfaces
+type testFrameworkResource struct {
+	name string
+}
+
+func (r *testFrameworkResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.name
+}
+
+func (r *testFrameworkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id":   schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{Optional: true},
+		},
+	}
+}
+
+func (r *testFrameworkResource) Configure(context.Context, resource.ConfigureRequest, *resource.ConfigureResponse) {
+}
+
+func (r *testFrameworkResource) Create(context.Context, resource.CreateRequest, *resource.CreateResponse) {
+}
+
+func (r *testFrameworkResource) Read(context.Context, resource.ReadRequest, *resource.ReadResponse) {}
+
+func (r *testFrameworkResource) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {
+}
+
+func (r *testFrameworkResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {
+}
+
+// testFrameworkFullResource implements all the optional interfaces, and records their calls
+type testFrameworkFullResource struct {
+	testFrameworkResource
+	calls map[string]bool
+}
+
+func (r *testFrameworkFullResource) ValidateConfig(context.Context, resource.ValidateConfigRequest, *resource.ValidateConfigResponse) {
+	r.calls["ValidateConfig"] = true
+}
+
+func (r *testFrameworkFullResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{testFrameworkConfigValidator{r.calls}}
+}
+
+func (r *testFrameworkFullResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				r.calls["UpgradeState"] = true
+				resp.Diagnostics.Append(resp.State.Set(ctx, testFrameworkResourceModel{ID: types.StringValue("1"), Name: types.StringValue("name")})...)
+			},
+		},
+	}
+}
+
+func (r *testFrameworkFullResource) MoveState(context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if req.SourceTypeName != "test_old" {
+					return
+				}
+				r.calls["MoveState"] = true
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, testFrameworkResourceModel{ID: types.StringValue("1"), Name: types.StringValue("name")})...)
+			},
+		},
+	}
+}
+
+type testFrameworkConfigValidator struct {
+	calls map[string]bool
+}
+
+func (v testFrameworkConfigValidator) Description(context.Context) string {
+	return "records its calls"
+}
+
+func (v testFrameworkConfigValidator) MarkdownDescription(context.Context) string {
+	return "records its calls"
+}
+
+func (v testFrameworkConfigValidator) ValidateResource(context.Context, resource.ValidateConfigRequest, *resource.ValidateConfigResponse) {
+	v.calls["ConfigValidators"] = true
+}
@@ -1,16 +1,20 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type ResourceIDFieldType string
 
 const (
 	defaultSeparator          = ":"
+	escapeCharacter           = "\\"
 	ResourceIDFieldTypeInt    = ResourceIDFieldType("int")
 	ResourceIDFieldTypeString = ResourceIDFieldType("string")
 )
@@ -100,7 +104,7 @@ func (id *ResourceID) Make(parts ...any) string {
 			if !ok {
 				panic(fmt.Sprintf("expected string for field %q, got %T", expectedField.Name, part)) // This is a coding error, so panic is appropriate
 			}
-			stringParts[i] = asString
+			stringParts[i] = escapeIDPart(asString)
 		}
 	}
 
@@ -118,30 +122,79 @@ func (id *ResourceID) Single(resourceID string) (any, error) {
 
 // Split parses a resource ID into its parts
 // The parts will be cast to the expected types
+// Separators within string fields are escaped with a backslash. For backwards compatibility with IDs that were created before escaping,
+// unescaped separators are also accepted, in which case any extra separator is considered part of the last string field.
 func (id *ResourceID) Split(resourceID string) ([]any, error) {
-	requiredFields := id.RequiredFields()
+	parts, _, err := id.split(resourceID)
+	return parts, err
+}
 
-	// Try with optional fields
-	parts, err := split(resourceID, id.expectedFields)
-	if err == nil {
-		return parts, nil
+// Canonical re-creates a resource ID in the current format (with escaping) from an ID that can be parsed by Split
+func (id *ResourceID) Canonical(resourceID string) (string, error) {
+	parts, fields, err := id.split(resourceID)
+	if err != nil {
+		return "", err
 	}
-	if len(requiredFields) == len(id.expectedFields) {
-		return nil, err
+	if len(fields) == len(id.expectedFields) {
+		return id.Make(parts...), nil
 	}
+	return NewResourceID(fields...).Make(parts...), nil
+}
 
-	// Try without optional fields
-	parts, err = split(resourceID, requiredFields)
-	if err != nil {
-		return nil, err
+// StateUpgrader returns a state upgrader that rewrites the resource ID in the current format.
+// The given schema must be the schema of the resource at the given version.
+func (id *ResourceID) StateUpgrader(version int, resourceSchema *schema.Resource) schema.StateUpgrader {
+	return schema.StateUpgrader{
+		Version: version,
+		Type:    resourceSchema.CoreConfigSchema().ImpliedType(),
+		Upgrade: func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+			resourceID, ok := rawState["id"].(string)
+			if !ok || resourceID == "" {
+				return rawState, nil
+			}
+			canonicalID, err := id.Canonical(resourceID)
+			if err != nil {
+				return nil, err
+			}
+			rawState["id"] = canonicalID
+			return rawState, nil
+		},
 	}
-	return parts, nil
 }
 
-// Split parses a resource ID into its parts
+func (id *ResourceID) split(resourceID string) ([]any, []ResourceIDField, error) {
+	requiredFields := id.RequiredFields()
+	fieldSets := [][]ResourceIDField{id.expectedFields}
+	if len(requiredFields) != len(id.expectedFields) {
+		// Try without optional fields
+		fieldSets = append(fieldSets, requiredFields)
+	}
+
+	var splitErr error
+	for _, legacy := range []bool{false, true} {
+		for _, fields := range fieldSets {
+			parts, err := split(resourceID, fields, legacy)
+			if err == nil {
+				return parts, fields, nil
+			}
+			if !legacy {
+				splitErr = err
+			}
+		}
+	}
+	return nil, nil, splitErr
+}
+
+// split parses a resource ID into its parts
 // The parts will be cast to the expected types
-func split(resourceID string, expectedFields []ResourceIDField) ([]any, error) {
-	parts := strings.Split(resourceID, defaultSeparator)
+// In legacy mode, separators are not unescaped and extra parts are joined into the last string field
+func split(resourceID string, expectedFields []ResourceIDField, legacy bool) ([]any, error) {
+	var parts []string
+	if legacy {
+		parts = joinExtraParts(strings.Split(resourceID, defaultSeparator), expectedFields)
+	} else {
+		parts = splitEscaped(resourceID)
+	}
 	if len(parts) == len(expectedFields) {
 		partsAsAny := make([]any, len(parts))
 		for i, part := range parts {
@@ -167,3 +220,41 @@ func split(resourceID string, expectedFields []ResourceIDField) ([]any, error) {
 	}
 	return nil, fmt.Errorf("id %q does not match expected format. Should be in the format: %s", resourceID, strings.Join(expectedFieldNames, defaultSeparator))
 }
+
+// escapeIDPart escapes the separator (and the escape character itself) in a string field
+func escapeIDPart(part string) string {
+	part = strings.ReplaceAll(part, escapeCharacter, escapeCharacter+escapeCharacter)
+	return strings.ReplaceAll(part, defaultSeparator, escapeCharacter+defaultSeparator)
+}
+
+// splitEscaped splits a resource ID on unescaped separators and unescapes the parts
+// A backslash that doesn't escape a separator or another backslash is kept as-is
+func splitEscaped(resourceID string) []string {
+	var parts []string
+	var current strings.Builder
+	for i := 0; i < len(resourceID); i++ {
+		switch {
+		case strings.HasPrefix(resourceID[i:], escapeCharacter+escapeCharacter):
+			current.WriteString(escapeCharacter)
+			i++
+		case strings.HasPrefix(resourceID[i:], escapeCharacter+defaultSeparator):
+			current.WriteString(defaultSeparator)
+			i++
+		case strings.HasPrefix(resourceID[i:], defaultSeparator):
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(resourceID[i])
+		}
+	}
+	return append(parts, current.String())
+}
+
+// joinExtraParts joins the parts that exceed the expected number of fields into the last field, if it's a string field
+func joinExtraParts(parts []string, expectedFields []ResourceIDField) []string {
+	if len(expectedFields) == 0 || len(parts) <= len(expectedFields) || expectedFields[len(expectedFields)-1].Type != ResourceIDFieldTypeString {
+		return parts
+	}
+	last := len(expectedFields) - 1
+	return append(parts[:last:last], strings.Join(parts[last:], defaultSeparator))
+}
@@ -0,0 +1,151 @@
+package common
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceIDSplit(t *testing.T) {
+	ruleGroupID := NewResourceID(OptionalIntIDField("orgID"), StringIDField("folderUID"), StringIDField("title"))
+
+	for _, tc := range []struct {
+		name          string
+		id            *ResourceID
+		resourceID    string
+		expected      []any
+		expectedError string
+	}{
+		{
+			name:       "simple",
+			id:         ruleGroupID,
+			resourceID: "1:folder:title",
+			expected:   []any{int64(1), "folder", "title"},
+		},
+		{
+			name:       "without optional fields",
+			id:         ruleGroupID,
+			resourceID: "folder:title",
+			expected:   []any{"folder", "title"},
+		},
+		{
+			name:       "escaped separator",
+			id:         ruleGroupID,
+			resourceID: `1:folder:team\: alerts`,
+			expected:   []any{int64(1), "folder", "team: alerts"},
+		},
+		{
+			name:       "escaped separator without optional fields",
+			id:         ruleGroupID,
+			resourceID: `folder:team\: alerts`,
+			expected:   []any{"folder", "team: alerts"},
+		},
+		{
+			name:       "escaped backslash",
+			id:         ruleGroupID,
+			resourceID: `1:folder:a\\b`,
+			expected:   []any{int64(1), "folder", `a\b`},
+		},
+		{
+			name:       "unknown escape is kept",
+			id:         ruleGroupID,
+			resourceID: `1:folder:a\b`,
+			expected:   []any{int64(1), "folder", `a\b`},
+		},
+		{
+			name:       "legacy unescaped separator",
+			id:         ruleGroupID,
+			resourceID: "1:folder:team: alerts",
+			expected:   []any{int64(1), "folder", "team: alerts"},
+		},
+		{
+			name:       "legacy unescaped separator without optional fields",
+			id:         ruleGroupID,
+			resourceID: "folder:team: alerts: critical",
+			expected:   []any{"folder", "team: alerts: critical"},
+		},
+		{
+			name:       "legacy unescaped separator in single field",
+			id:         NewResourceID(StringIDField("url")),
+			resourceID: "https://grafana.com",
+			expected:   []any{"https://grafana.com"},
+		},
+		{
+			name:          "invalid int",
+			id:            NewResourceID(IntIDField("id")),
+			resourceID:    "abc",
+			expectedError: `expected int for field "id", got "abc"`,
+		},
+		{
+			name:          "not enough parts",
+			id:            NewResourceID(StringIDField("stackSlug"), StringIDField("pluginSlug")),
+			resourceID:    "noseparator",
+			expectedError: `id "noseparator" does not match expected format. Should be in the format: stackSlug:pluginSlug`,
+		},
+		{
+			name:          "extra parts after an int field",
+			id:            NewResourceID(StringIDField("stackSlug"), IntIDField("serviceAccountID")),
+			resourceID:    "stack:1:2",
+			expectedError: `id "stack:1:2" does not match expected format. Should be in the format: stackSlug:serviceAccountID`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			parts, err := tc.id.Split(tc.resourceID)
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("expected error %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(parts, tc.expected) {
+				t.Fatalf("expected %#v, got %#v", tc.expected, parts)
+			}
+		})
+	}
+}
+
+func TestResourceIDMakeRoundTrip(t *testing.T) {
+	id := NewResourceID(OptionalIntIDField("orgID"), StringIDField("folderUID"), StringIDField("title"))
+
+	for _, title := range []string{"title", "team: alerts", `a\b`, `a\:b`, `a\\:b\`, ":", ""} {
+		resourceID := id.Make(int64(2), "folder", title)
+		parts, err := id.Split(resourceID)
+		if err != nil {
+			t.Fatalf("failed to split %q: %v", resourceID, err)
+		}
+		if expected := []any{int64(2), "folder", title}; !reflect.DeepEqual(parts, expected) {
+			t.Fatalf("expected %#v, got %#v (ID: %q)", expected, parts, resourceID)
+		}
+	}
+
+	// IDs without special characters are unchanged
+	if resourceID := id.Make(int64(1), "folder", "title"); resourceID != "1:folder:title" {
+		t.Fatalf("unexpected ID %q", resourceID)
+	}
+}
+
+func TestResourceIDStateUpgrader(t *testing.T) {
+	id := NewResourceID(OptionalIntIDField("orgID"), StringIDField("folderUID"), StringIDField("title"))
+	upgrader := id.StateUpgrader(0, &schema.Resource{Schema: map[string]*schema.Schema{
+		"title": {Type: schema.TypeString, Required: true},
+	}})
+
+	for legacyID, expectedID := range map[string]string{
+		"1:folder:team: alerts": `1:folder:team\: alerts`,
+		"folder:team: alerts":   `folder:team\: alerts`,
+		"1:folder:title":        "1:folder:title",
+	} {
+		state, err := upgrader.Upgrade(context.Background(), map[string]interface{}{"id": legacyID, "title": "title"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state["id"] != expectedID {
+			t.Fatalf("expected ID %q, got %q", expectedID, state["id"])
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	frameworkSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DefaultResourceTimeout is how long a create, read, update or delete operation can take when the `timeouts` block doesn't set it.
+const DefaultResourceTimeout = 20 * time.Minute
+
+const timeoutsBlockName = "timeouts"
+
+// addDefaultTimeoutsToSchema sets the default timeouts of the operations implemented by an SDKv2 resource.
+// This adds the `timeouts` block to the resource. The SDK cancels the context of each operation once its timeout is reached.
+// Timeouts already set by the resource are kept.
+func addDefaultTimeoutsToSchema(r *schema.Resource) {
+	if r == nil {
+		return
+	}
+	if r.Timeouts == nil {
+		r.Timeouts = &schema.ResourceTimeout{}
+	}
+	setDefault := func(timeout **time.Duration, implemented bool) {
+		if *timeout == nil && implemented {
+			*timeout = Ref(DefaultResourceTimeout)
+		}
+	}
+	setDefault(&r.Timeouts.Create, r.CreateContext != nil)
+	setDefault(&r.Timeouts.Read, r.ReadContext != nil)
+	setDefault(&r.Timeouts.Update, r.UpdateContext != nil)
+	setDefault(&r.Timeouts.Delete, r.DeleteContext != nil)
+}
+
+// ResourceTimeouts is the model of the `timeouts` block of plugin framework resources.
+// Resources add it to their model as a pointer, it is nil when the block isn't set.
+type ResourceTimeouts struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+func (t *ResourceTimeouts) get(operation string) string {
+	if t == nil {
+		return ""
+	}
+	switch operation {
+	case "create":
+		return t.Create.ValueString()
+	case "read":
+		return t.Read.ValueString()
+	case "update":
+		return t.Update.ValueString()
+	case "delete":
+		return t.Delete.ValueString()
+	}
+	return ""
+}
+
+// TimeoutsBlock returns the `timeouts` block of plugin framework resources.
+// The operations are cancelled by the common resource wrapper once their timeout is reached.
+func TimeoutsBlock() frameworkSchema.Block {
+	attribute := func(operation string) frameworkSchema.StringAttribute {
+		return frameworkSchema.StringAttribute{
+			Optional:    true,
+			Description: fmt.Sprintf("How long to wait for the resource to be %s, as a duration string (e.g. `30s` or `5m`). Defaults to `%s`.", operation, DefaultResourceTimeout),
+			Validators:  []validator.String{durationValidator{}},
+		}
+	}
+	return frameworkSchema.SingleNestedBlock{
+		Attributes: map[string]frameworkSchema.Attribute{
+			"create": attribute("created"),
+			"read":   attribute("read"),
+			"update": attribute("updated"),
+			"delete": attribute("deleted"),
+		},
+	}
+}
+
+// contextWithFrameworkTimeout returns a context that is cancelled once the timeout of the operation is reached.
+// The timeout is read from the `timeouts` block of the given plan or state, if the resource has one.
+func contextWithFrameworkTimeout(ctx context.Context, data tfsdk.State, operation string) (context.Context, context.CancelFunc) {
+	timeout := DefaultResourceTimeout
+	if _, ok := data.Schema.GetBlocks()[timeoutsBlockName]; ok && !data.Raw.IsNull() {
+		var timeouts *ResourceTimeouts
+		// Invalid durations are rejected at validation, the default is used if the block can't be read
+		if diags := data.GetAttribute(ctx, path.Root(timeoutsBlockName), &timeouts); !diags.HasError() {
+			if parsed, err := time.ParseDuration(timeouts.get(operation)); err == nil {
+				timeout = parsed
+			}
+		}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a valid duration"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", fmt.Sprintf("%q is not a valid duration: %s", req.ConfigValue.ValueString(), err))
+	}
+}
@@ -0,0 +1,111 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	frameworkSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAddDefaultTimeoutsToSchema(t *testing.T) {
+	noop := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics { return nil }
+	r := &schema.Resource{
+		CreateContext: noop,
+		ReadContext:   noop,
+		DeleteContext: noop,
+		Timeouts: &schema.ResourceTimeout{
+			Create: Ref(time.Hour),
+		},
+	}
+	addDefaultTimeoutsToSchema(r)
+
+	if *r.Timeouts.Create != time.Hour {
+		t.Errorf("expected the timeout set by the resource to be kept, got %s", *r.Timeouts.Create)
+	}
+	for name, timeout := range map[string]*time.Duration{"read": r.Timeouts.Read, "delete": r.Timeouts.Delete} {
+		if timeout == nil || *timeout != DefaultResourceTimeout {
+			t.Errorf("expected the %s timeout to be %s, got %v", name, DefaultResourceTimeout, timeout)
+		}
+	}
+	if r.Timeouts.Update != nil {
+		t.Errorf("expected no update timeout for a resource that can't be updated, got %s", *r.Timeouts.Update)
+	}
+}
+
+func TestContextWithFrameworkTimeout(t *testing.T) {
+	ctx := context.Background()
+	resourceSchema := frameworkSchema.Schema{
+		Attributes: map[string]frameworkSchema.Attribute{
+			"id": frameworkSchema.StringAttribute{Computed: true},
+		},
+		Blocks: map[string]frameworkSchema.Block{
+			"timeouts": TimeoutsBlock(),
+		},
+	}
+	schemaType := resourceSchema.Type().TerraformType(ctx).(tftypes.Object)
+	timeoutsType := schemaType.AttributeTypes["timeouts"]
+	newState := func(timeouts tftypes.Value) tfsdk.State {
+		return tfsdk.State{
+			Schema: resourceSchema,
+			Raw: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"id":       tftypes.NewValue(tftypes.String, "1"),
+				"timeouts": timeouts,
+			}),
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		state    tfsdk.State
+		expected time.Duration
+	}{
+		{
+			name: "configured",
+			state: newState(tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+				"create": tftypes.NewValue(tftypes.String, "1m"),
+				"read":   tftypes.NewValue(tftypes.String, nil),
+				"update": tftypes.NewValue(tftypes.String, nil),
+				"delete": tftypes.NewValue(tftypes.String, nil),
+			})),
+			expected: time.Minute,
+		},
+		{
+			name: "other operation configured",
+			state: newState(tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+				"create": tftypes.NewValue(tftypes.String, nil),
+				"read":   tftypes.NewValue(tftypes.String, "1m"),
+				"update": tftypes.NewValue(tftypes.String, nil),
+				"delete": tftypes.NewValue(tftypes.String, nil),
+			})),
+			expected: DefaultResourceTimeout,
+		},
+		{
+			name:     "block not set",
+			state:    newState(tftypes.NewValue(timeoutsType, nil)),
+			expected: DefaultResourceTimeout,
+		},
+		{
+			name:     "no timeouts block in schema",
+			state:    tfsdk.State{Schema: frameworkSchema.Schema{}, Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})},
+			expected: DefaultResourceTimeout,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			timeoutCtx, cancel := contextWithFrameworkTimeout(ctx, tc.state, "create")
+			defer cancel()
+
+			deadline, ok := timeoutCtx.Deadline()
+			if !ok {
+				t.Fatal("expected the context to have a deadline")
+			}
+			if remaining := time.Until(deadline); remaining > tc.expected || remaining < tc.expected-time.Minute/2 {
+				t.Errorf("expected the deadline to be in %s, got %s", tc.expected, remaining)
+			}
+		})
+	}
+}
@@ -32,23 +32,6 @@ func withClient[T schema.CreateContextFunc | schema.UpdateContextFunc | schema.R
 	}
 }
 
-func apiError(err error) diag.Diagnostics {
-	if err == nil {
-		return nil
-	}
-	detail := err.Error()
-	if err, ok := err.(*gcom.GenericOpenAPIError); ok {
-		detail += "\n" + string(err.Body())
-	}
-	return diag.Diagnostics{
-		diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  err.Error(),
-			Detail:   detail,
-		},
-	}
-}
-
 type basePluginFrameworkResource struct {
 	client *gcom.APIClient
 }
@@ -13,10 +13,9 @@ import (
 )
 
 func TestAccDataSourceIPsRead(t *testing.T) {
-	testutils.CheckCloudAPITestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExample(t, "data-sources/grafana_cloud_ips/data-source.tf"),
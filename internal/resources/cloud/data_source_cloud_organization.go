@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/grafana/grafana-com-public-clients/go/gcom"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -50,7 +51,7 @@ func datasourceOrganizationRead(ctx context.Context, d *schema.ResourceData, cli
 	}
 	org, _, err := client.OrgsAPI.GetOrg(ctx, id).Execute()
 	if err != nil {
-		return apiError(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	id = strconv.FormatInt(int64(org.Id), 10)
@@ -11,7 +11,7 @@ import (
 )
 
 func TestAccDataSourceOrganization_Basic(t *testing.T) {
-	testutils.CheckCloudAPITestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
 
 	config := fmt.Sprintf(`
 	data "grafana_cloud_organization" "test" {
@@ -19,8 +19,7 @@ func TestAccDataSourceOrganization_Basic(t *testing.T) {
 	}
 	`, os.Getenv("GRAFANA_CLOUD_ORG"))
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: config,
@@ -10,23 +10,23 @@ import (
 )
 
 func TestAccDataSourceStack_Basic(t *testing.T) {
-	testutils.CheckCloudAPITestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
+	client := cassette.Client()
 
 	prefix := "tfdatatest"
 
-	resourceName := GetRandomStackName(prefix)
+	resourceName := GetRandomStackName(cassette, prefix)
 	var stack gcom.FormattedApiInstance
-	resource.ParallelTest(t, resource.TestCase{
+	cassette.Test(t, resource.TestCase{
 		PreCheck: func() {
-			testAccDeleteExistingStacks(t, prefix)
+			testAccDeleteExistingStacks(t, client, prefix)
 		},
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccStackCheckDestroy(&stack),
+		CheckDestroy: testAccStackCheckDestroy(client, &stack),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceStackConfig(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccStackCheckExists("grafana_cloud_stack.test", &stack),
+					testAccStackCheckExists(client, "grafana_cloud_stack.test", &stack),
 					resource.TestCheckResourceAttrSet("data.grafana_cloud_stack.test", "id"),
 					resource.TestCheckResourceAttr("data.grafana_cloud_stack.test", "name", resourceName),
 					resource.TestCheckResourceAttr("data.grafana_cloud_stack.test", "slug", resourceName),
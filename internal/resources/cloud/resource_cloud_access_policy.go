@@ -183,7 +183,7 @@ func createCloudAccessPolicy(ctx context.Context, d *schema.ResourceData, client
 		})
 	result, _, err := req.Execute()
 	if err != nil {
-		return apiError(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	d.SetId(resourceAccessPolicyID.Make(region, result.Id))
@@ -210,7 +210,7 @@ func updateCloudAccessPolicy(ctx context.Context, d *schema.ResourceData, client
 			Realms:      expandCloudAccessPolicyRealm(d.Get("realm").(*schema.Set).List()),
 		})
 	if _, _, err = req.Execute(); err != nil {
-		return apiError(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	return readCloudAccessPolicy(ctx, d, client)
@@ -251,7 +251,7 @@ func deleteCloudAccessPolicy(ctx context.Context, d *schema.ResourceData, client
 	region, id := split[0], split[1]
 
 	_, _, err = client.AccesspoliciesAPI.DeleteAccessPolicy(ctx, id.(string)).Region(region.(string)).XRequestId(ClientRequestID()).Execute()
-	return apiError(err)
+	return common.APIErrorDiagnostics("", err)
 }
 
 func validateCloudAccessPolicyScope(v interface{}, path cty.Path) diag.Diagnostics {
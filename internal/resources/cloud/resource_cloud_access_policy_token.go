@@ -125,7 +125,7 @@ func createCloudAccessPolicyToken(ctx context.Context, d *schema.ResourceData, c
 	req := client.TokensAPI.PostTokens(ctx).Region(region).XRequestId(ClientRequestID()).PostTokensRequest(tokenInput)
 	result, _, err := req.Execute()
 	if err != nil {
-		return apiError(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	d.SetId(resourceAccessPolicyTokenID.Make(region, result.Id))
@@ -150,7 +150,7 @@ func updateCloudAccessPolicyToken(ctx context.Context, d *schema.ResourceData, c
 		DisplayName: &displayName,
 	})
 	if _, _, err := req.Execute(); err != nil {
-		return apiError(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	return readCloudAccessPolicyToken(ctx, d, client)
@@ -192,5 +192,5 @@ func deleteCloudAccessPolicyToken(ctx context.Context, d *schema.ResourceData, c
 	region, id := split[0], split[1]
 
 	_, _, err = client.TokensAPI.DeleteToken(ctx, id.(string)).Region(region.(string)).XRequestId(ClientRequestID()).Execute()
-	return apiError(err)
+	return common.APIErrorDiagnostics("", err)
 }
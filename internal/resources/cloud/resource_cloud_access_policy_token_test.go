@@ -19,13 +19,13 @@ import (
 
 // This test covers both the cloud_access_policy and cloud_access_policy_token resources.
 func TestResourceAccessPolicyToken_Basic(t *testing.T) {
-	t.Parallel()
-	testutils.CheckCloudAPITestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
+	client := cassette.Client()
 
 	var policy gcom.AuthAccessPolicy
 	var policyToken gcom.AuthToken
 
-	expiresAt := time.Now().Add(time.Hour * 24).UTC().Format(time.RFC3339)
+	expiresAt := cassette.Now().Add(time.Hour * 24).UTC().Format(time.RFC3339)
 	initialScopes := []string{
 		"metrics:read",
 		"logs:write",
@@ -38,18 +38,17 @@ func TestResourceAccessPolicyToken_Basic(t *testing.T) {
 		"metrics:write",
 	}
 
-	resource.Test(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		CheckDestroy: resource.ComposeTestCheckFunc(
-			testAccCloudAccessPolicyCheckDestroy("us", &policy),
-			testAccCloudAccessPolicyTokenCheckDestroy("us", &policyToken),
+			testAccCloudAccessPolicyCheckDestroy(client, "us", &policy),
+			testAccCloudAccessPolicyTokenCheckDestroy(client, "us", &policyToken),
 		),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCloudAccessPolicyTokenConfigBasic("initial", "", "us", initialScopes, expiresAt),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCloudAccessPolicyCheckExists("grafana_cloud_access_policy.test", &policy),
-					testAccCloudAccessPolicyTokenCheckExists("grafana_cloud_access_policy_token.test", &policyToken),
+					testAccCloudAccessPolicyCheckExists(client, "grafana_cloud_access_policy.test", &policy),
+					testAccCloudAccessPolicyTokenCheckExists(client, "grafana_cloud_access_policy_token.test", &policyToken),
 
 					resource.TestCheckResourceAttr("grafana_cloud_access_policy.test", "name", "initial"),
 					resource.TestCheckResourceAttr("grafana_cloud_access_policy.test", "display_name", "initial"),
@@ -71,8 +70,8 @@ func TestResourceAccessPolicyToken_Basic(t *testing.T) {
 			{
 				Config: testAccCloudAccessPolicyTokenConfigBasic("initial", "updated", "us", updatedScopes, expiresAt),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCloudAccessPolicyCheckExists("grafana_cloud_access_policy.test", &policy),
-					testAccCloudAccessPolicyTokenCheckExists("grafana_cloud_access_policy_token.test", &policyToken),
+					testAccCloudAccessPolicyCheckExists(client, "grafana_cloud_access_policy.test", &policy),
+					testAccCloudAccessPolicyTokenCheckExists(client, "grafana_cloud_access_policy_token.test", &policyToken),
 
 					resource.TestCheckResourceAttr("grafana_cloud_access_policy.test", "name", "initial"),
 					resource.TestCheckResourceAttr("grafana_cloud_access_policy.test", "display_name", "updated"),
@@ -90,8 +89,8 @@ func TestResourceAccessPolicyToken_Basic(t *testing.T) {
 			{
 				Config: testAccCloudAccessPolicyTokenConfigBasic("updated", "updated", "us", updatedScopes, expiresAt),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCloudAccessPolicyCheckExists("grafana_cloud_access_policy.test", &policy),
-					testAccCloudAccessPolicyTokenCheckExists("grafana_cloud_access_policy_token.test", &policyToken),
+					testAccCloudAccessPolicyCheckExists(client, "grafana_cloud_access_policy.test", &policy),
+					testAccCloudAccessPolicyTokenCheckExists(client, "grafana_cloud_access_policy_token.test", &policyToken),
 
 					resource.TestCheckResourceAttr("grafana_cloud_access_policy.test", "name", "updated"),
 					resource.TestCheckResourceAttr("grafana_cloud_access_policy.test", "display_name", "updated"),
@@ -117,20 +116,19 @@ func TestResourceAccessPolicyToken_Basic(t *testing.T) {
 }
 
 func TestResourceAccessPolicyToken_NoExpiration(t *testing.T) {
-	t.Parallel()
-	testutils.CheckCloudAPITestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
+	client := cassette.Client()
 
 	var policy gcom.AuthAccessPolicy
 	var policyToken gcom.AuthToken
 
-	resource.Test(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCloudAccessPolicyTokenConfigBasic("initial-no-expiration", "", "us", []string{"metrics:read"}, ""),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCloudAccessPolicyCheckExists("grafana_cloud_access_policy.test", &policy),
-					testAccCloudAccessPolicyTokenCheckExists("grafana_cloud_access_policy_token.test", &policyToken),
+					testAccCloudAccessPolicyCheckExists(client, "grafana_cloud_access_policy.test", &policy),
+					testAccCloudAccessPolicyTokenCheckExists(client, "grafana_cloud_access_policy_token.test", &policyToken),
 					resource.TestCheckNoResourceAttr("grafana_cloud_access_policy_token.test", "expires_at"),
 				),
 			},
@@ -144,7 +142,7 @@ func TestResourceAccessPolicyToken_NoExpiration(t *testing.T) {
 	})
 }
 
-func testAccCloudAccessPolicyCheckExists(rn string, a *gcom.AuthAccessPolicy) resource.TestCheckFunc {
+func testAccCloudAccessPolicyCheckExists(client *common.Client, rn string, a *gcom.AuthAccessPolicy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
 		if !ok {
@@ -157,8 +155,7 @@ func testAccCloudAccessPolicyCheckExists(rn string, a *gcom.AuthAccessPolicy) re
 
 		region, id, _ := strings.Cut(rs.Primary.ID, ":")
 
-		client := testutils.Provider.Meta().(*common.Client).GrafanaCloudAPI
-		policy, _, err := client.AccesspoliciesAPI.GetAccessPolicy(context.Background(), id).Region(region).Execute()
+		policy, _, err := client.GrafanaCloudAPI.AccesspoliciesAPI.GetAccessPolicy(context.Background(), id).Region(region).Execute()
 		if err != nil {
 			return fmt.Errorf("error getting cloud access policy: %s", err)
 		}
@@ -169,7 +166,7 @@ func testAccCloudAccessPolicyCheckExists(rn string, a *gcom.AuthAccessPolicy) re
 	}
 }
 
-func testAccCloudAccessPolicyTokenCheckExists(rn string, a *gcom.AuthToken) resource.TestCheckFunc {
+func testAccCloudAccessPolicyTokenCheckExists(client *common.Client, rn string, a *gcom.AuthToken) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
 		if !ok {
@@ -182,8 +179,7 @@ func testAccCloudAccessPolicyTokenCheckExists(rn string, a *gcom.AuthToken) reso
 
 		region, id, _ := strings.Cut(rs.Primary.ID, ":")
 
-		client := testutils.Provider.Meta().(*common.Client).GrafanaCloudAPI
-		token, _, err := client.TokensAPI.GetToken(context.Background(), id).Region(region).Execute()
+		token, _, err := client.GrafanaCloudAPI.TokensAPI.GetToken(context.Background(), id).Region(region).Execute()
 		if err != nil {
 			return fmt.Errorf("error getting cloud access policy token: %s", err)
 		}
@@ -194,13 +190,12 @@ func testAccCloudAccessPolicyTokenCheckExists(rn string, a *gcom.AuthToken) reso
 	}
 }
 
-func testAccCloudAccessPolicyCheckDestroy(region string, a *gcom.AuthAccessPolicy) resource.TestCheckFunc {
+func testAccCloudAccessPolicyCheckDestroy(client *common.Client, region string, a *gcom.AuthAccessPolicy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if a == nil {
 			return nil
 		}
-		client := testutils.Provider.Meta().(*common.Client).GrafanaCloudAPI
-		policy, _, err := client.AccesspoliciesAPI.GetAccessPolicy(context.Background(), *a.Id).Region(region).Execute()
+		policy, _, err := client.GrafanaCloudAPI.AccesspoliciesAPI.GetAccessPolicy(context.Background(), *a.Id).Region(region).Execute()
 		if err == nil && policy.Name != "" {
 			return fmt.Errorf("cloud access policy `%s` with ID `%s` still exists after destroy", policy.Name, *policy.Id)
 		}
@@ -209,13 +204,12 @@ func testAccCloudAccessPolicyCheckDestroy(region string, a *gcom.AuthAccessPolic
 	}
 }
 
-func testAccCloudAccessPolicyTokenCheckDestroy(region string, a *gcom.AuthToken) resource.TestCheckFunc {
+func testAccCloudAccessPolicyTokenCheckDestroy(client *common.Client, region string, a *gcom.AuthToken) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if a == nil {
 			return nil
 		}
-		client := testutils.Provider.Meta().(*common.Client).GrafanaCloudAPI
-		token, _, err := client.TokensAPI.GetToken(context.Background(), *a.Id).Region(region).Execute()
+		token, _, err := client.GrafanaCloudAPI.TokensAPI.GetToken(context.Background(), *a.Id).Region(region).Execute()
 		if err == nil && token.Name != "" {
 			return fmt.Errorf("cloud access policy token `%s` with ID `%s` still exists after destroy", token.Name, *token.Id)
 		}
@@ -224,16 +218,15 @@ func testAccCloudAccessPolicyTokenCheckDestroy(region string, a *gcom.AuthToken)
 	}
 }
 
-func testAccDeleteExistingAccessPolicies(t *testing.T, prefix string) {
-	client := testutils.Provider.Meta().(*common.Client).GrafanaCloudAPI
-	resp, _, err := client.AccesspoliciesAPI.GetAccessPolicies(context.Background()).Execute()
+func testAccDeleteExistingAccessPolicies(t *testing.T, client *common.Client, prefix string) {
+	resp, _, err := client.GrafanaCloudAPI.AccesspoliciesAPI.GetAccessPolicies(context.Background()).Execute()
 	if err != nil {
 		t.Error(err)
 	}
 
 	for _, ap := range resp.Items {
 		if strings.HasPrefix(ap.Name, prefix) {
-			_, _, err := client.AccesspoliciesAPI.DeleteAccessPolicy(context.Background(), *ap.Id).XRequestId(cloud.ClientRequestID()).Execute()
+			_, _, err := client.GrafanaCloudAPI.AccesspoliciesAPI.DeleteAccessPolicy(context.Background(), *ap.Id).XRequestId(cloud.ClientRequestID()).Execute()
 			if err != nil {
 				t.Error(err)
 			}
@@ -2,7 +2,6 @@ package cloud
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/grafana/grafana-com-public-clients/go/gcom"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
@@ -30,11 +29,12 @@ func resourceOrgMember() *common.Resource {
 }
 
 type resourceOrgMemberModel struct {
-	ID                   types.String `tfsdk:"id"`
-	Org                  types.String `tfsdk:"org"`
-	User                 types.String `tfsdk:"user"`
-	Role                 types.String `tfsdk:"role"`
-	ReceiveBillingEmails types.Bool   `tfsdk:"receive_billing_emails"`
+	ID                   types.String             `tfsdk:"id"`
+	Org                  types.String             `tfsdk:"org"`
+	User                 types.String             `tfsdk:"user"`
+	Role                 types.String             `tfsdk:"role"`
+	ReceiveBillingEmails types.Bool               `tfsdk:"receive_billing_emails"`
+	Timeouts             *common.ResourceTimeouts `tfsdk:"timeouts"`
 }
 
 type orgMemberResource struct {
@@ -79,6 +79,9 @@ func (r *orgMemberResource) Schema(ctx context.Context, req resource.SchemaReque
 				Default:             booldefault.StaticBool(false),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(),
+		},
 		MarkdownDescription: "Manages the membership of a user in an organization.",
 	}
 }
@@ -126,7 +129,7 @@ func (r *orgMemberResource) Create(ctx context.Context, req resource.CreateReque
 	postReq.SetRole(data.Role.ValueString())
 	_, _, err := r.client.OrgsAPI.PostOrgMembers(ctx, data.Org.ValueString()).PostOrgMembersRequest(*postReq).XRequestId(ClientRequestID()).Execute()
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Create Resource", err.Error())
+		resp.Diagnostics.Append(common.APIErrorDiagnosticsPluginFramework("Unable to Create Resource", err)...)
 		return
 	}
 
@@ -140,6 +143,7 @@ func (r *orgMemberResource) Create(ctx context.Context, req resource.CreateReque
 		resp.Diagnostics.AddError("Unable to read created resource", "Resource not found")
 		return
 	}
+	readData.Timeouts = data.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, readData)...)
 }
 
@@ -155,11 +159,12 @@ func (r *orgMemberResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 	if readData == nil {
-		resp.State.RemoveResource(ctx)
+		common.WarnMissingPluginFramework(ctx, "org member", data.ID.ValueString(), resp)
 		return
 	}
 
 	// Save data into Terraform state
+	readData.Timeouts = data.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, readData)...)
 }
 
@@ -186,7 +191,7 @@ func (r *orgMemberResource) Update(ctx context.Context, req resource.UpdateReque
 	postReq.SetBilling(billing)
 	postReq.SetRole(data.Role.ValueString())
 	if _, _, err := r.client.OrgsAPI.PostOrgMember(ctx, data.Org.ValueString(), data.User.ValueString()).XRequestId(ClientRequestID()).PostOrgMemberRequest(*postReq).Execute(); err != nil {
-		resp.Diagnostics.AddError("Unable to Update Resource", err.Error())
+		resp.Diagnostics.Append(common.APIErrorDiagnosticsPluginFramework("Unable to Update Resource", err)...)
 		return
 	}
 
@@ -200,6 +205,7 @@ func (r *orgMemberResource) Update(ctx context.Context, req resource.UpdateReque
 		resp.Diagnostics.AddError("Unable to read updated resource", "Resource not found")
 		return
 	}
+	readData.Timeouts = data.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, readData)...)
 }
 
@@ -222,7 +228,7 @@ func (r *orgMemberResource) Delete(ctx context.Context, req resource.DeleteReque
 
 	// DELETE
 	if _, err := r.client.OrgsAPI.DeleteOrgMember(ctx, org, user).XRequestId(ClientRequestID()).Execute(); err != nil {
-		resp.Diagnostics.AddError("Unable to Delete Resource", err.Error())
+		resp.Diagnostics.Append(common.APIErrorDiagnosticsPluginFramework("Unable to Delete Resource", err)...)
 	}
 }
 
@@ -238,13 +244,12 @@ func (r *orgMemberResource) readFromID(ctx context.Context, id string) (*resourc
 	org, user := split[0].(string), split[1].(string)
 
 	// GET
-	memberResp, httpResp, err := r.client.OrgsAPI.GetOrgMember(ctx, org, user).Execute()
-	if httpResp.StatusCode == http.StatusNotFound {
+	memberResp, _, err := r.client.OrgsAPI.GetOrgMember(ctx, org, user).Execute()
+	if common.IsNotFoundError(err) {
 		return nil, nil
 	}
-
 	if err != nil {
-		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Unable to read resource", err.Error())}
+		return nil, common.APIErrorDiagnosticsPluginFramework("Unable to read resource", err)
 	}
 
 	data := &resourceOrgMemberModel{}
@@ -17,19 +17,19 @@ import (
 const testOrgMemberUser = "julienduchesne1"
 
 func TestAccResourceOrgMember(t *testing.T) {
-	testutils.CheckCloudAPITestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
+	client := cassette.Client()
 
 	org := os.Getenv("GRAFANA_CLOUD_ORG")
 
-	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:                 func() { testAccDeleteExistingOrgMember(t, org, testOrgMemberUser) },
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
+		PreCheck: func() { testAccDeleteExistingOrgMember(t, client, org, testOrgMemberUser) },
 
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCloudOrgMember(org, testOrgMemberUser, "Admin", true),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOrgMember(org, testOrgMemberUser, true),
+					testAccCheckOrgMember(client, org, testOrgMemberUser, true),
 					resource.TestCheckResourceAttr("grafana_cloud_org_member.test", "org", org),
 					resource.TestCheckResourceAttr("grafana_cloud_org_member.test", "user", testOrgMemberUser),
 					resource.TestCheckResourceAttr("grafana_cloud_org_member.test", "role", "Admin"),
@@ -39,7 +39,7 @@ func TestAccResourceOrgMember(t *testing.T) {
 			{
 				Config: testAccCloudOrgMember(org, testOrgMemberUser, "Editor", false),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOrgMember(org, testOrgMemberUser, true),
+					testAccCheckOrgMember(client, org, testOrgMemberUser, true),
 					resource.TestCheckResourceAttr("grafana_cloud_org_member.test", "org", org),
 					resource.TestCheckResourceAttr("grafana_cloud_org_member.test", "user", testOrgMemberUser),
 					resource.TestCheckResourceAttr("grafana_cloud_org_member.test", "role", "Editor"),
@@ -52,21 +52,16 @@ func TestAccResourceOrgMember(t *testing.T) {
 				ImportStateVerify: true,
 			},
 		},
-		CheckDestroy: testAccCheckOrgMember(org, testOrgMemberUser, false),
+		CheckDestroy: testAccCheckOrgMember(client, org, testOrgMemberUser, false),
 	})
 }
 
-func testAccCheckOrgMember(org, user string, shouldExist bool) resource.TestCheckFunc {
+func testAccCheckOrgMember(client *common.Client, org, user string, shouldExist bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		commonClient, ok := testutils.Provider.Meta().(*common.Client)
-		if !ok {
-			return fmt.Errorf("failed to get common client")
-		}
-		if commonClient.GrafanaCloudAPI == nil {
+		if client.GrafanaCloudAPI == nil {
 			return fmt.Errorf("GrafanaCloudAPI is nil")
 		}
-		client := commonClient.GrafanaCloudAPI
-		resp, _, err := client.OrgsAPI.GetOrgMembers(context.Background(), org).Execute()
+		resp, _, err := client.GrafanaCloudAPI.OrgsAPI.GetOrgMembers(context.Background(), org).Execute()
 		if err != nil {
 			return err
 		}
@@ -87,18 +82,17 @@ func testAccCheckOrgMember(org, user string, shouldExist bool) resource.TestChec
 	}
 }
 
-func testAccDeleteExistingOrgMember(t *testing.T, org, name string) {
+func testAccDeleteExistingOrgMember(t *testing.T, client *common.Client, org, name string) {
 	t.Helper()
 
-	client := testutils.Provider.Meta().(*common.Client).GrafanaCloudAPI
-	resp, _, err := client.OrgsAPI.GetOrgMembers(context.Background(), org).Execute()
+	resp, _, err := client.GrafanaCloudAPI.OrgsAPI.GetOrgMembers(context.Background(), org).Execute()
 	if err != nil {
 		t.Error(err)
 	}
 
 	for _, member := range resp.Items {
 		if member.UserName == name {
-			_, err := client.OrgsAPI.DeleteOrgMember(context.Background(), org, member.UserName).Execute()
+			_, err := client.GrafanaCloudAPI.OrgsAPI.DeleteOrgMember(context.Background(), org, member.UserName).Execute()
 			if err != nil {
 				t.Error(err)
 			}
@@ -97,7 +97,7 @@ func resourcePluginInstallationCreate(ctx context.Context, d *schema.ResourceDat
 		PostInstancePluginsRequest(req).
 		XRequestId(ClientRequestID()).Execute()
 	if err != nil {
-		return apiError(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	d.SetId(resourcePluginInstallationID.Make(stackSlug, pluginSlug))
@@ -133,5 +133,5 @@ func resourcePluginInstallationDelete(ctx context.Context, d *schema.ResourceDat
 	stackSlug, pluginSlug := split[0], split[1]
 
 	_, _, err = client.InstancesAPI.DeleteInstancePlugin(ctx, stackSlug.(string), pluginSlug.(string)).XRequestId(ClientRequestID()).Execute()
-	return apiError(err)
+	return common.APIErrorDiagnostics("", err)
 }
@@ -14,23 +14,23 @@ import (
 )
 
 func TestAccResourcePluginInstallation(t *testing.T) {
-	testutils.CheckCloudAPITestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
+	client := cassette.Client()
 
 	var stack gcom.FormattedApiInstance
 	stackPrefix := "tfplugin"
-	stackSlug := GetRandomStackName(stackPrefix)
+	stackSlug := GetRandomStackName(cassette, stackPrefix)
 	pluginSlug := "grafana-googlesheets-datasource" // TODO: Add datasource to find a plugin and use that
 	pluginVersion := "1.2.5"
 
-	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:                 func() { testAccDeleteExistingStacks(t, stackPrefix) },
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
+		PreCheck: func() { testAccDeleteExistingStacks(t, client, stackPrefix) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccGrafanaCloudPluginInstallation(stackSlug, pluginSlug, pluginVersion),
 				Check: resource.ComposeTestCheckFunc(
-					testAccStackCheckExists("grafana_cloud_stack.test", &stack),
-					testAccCloudPluginInstallationCheckExists(stackSlug, pluginSlug),
+					testAccStackCheckExists(client, "grafana_cloud_stack.test", &stack),
+					testAccCloudPluginInstallationCheckExists(client, stackSlug, pluginSlug),
 					resource.TestCheckResourceAttrSet("grafana_cloud_plugin_installation.test-installation", "id"),
 					resource.TestCheckResourceAttr("grafana_cloud_plugin_installation.test-installation", "stack_slug", stackSlug),
 					resource.TestCheckResourceAttr("grafana_cloud_plugin_installation.test-installation", "slug", "grafana-googlesheets-datasource"),
@@ -66,19 +66,18 @@ func TestAccResourcePluginInstallation(t *testing.T) {
 			{
 				Config: testutils.WithoutResource(t, testAccGrafanaCloudPluginInstallation(stackSlug, pluginSlug, pluginVersion), "grafana_cloud_plugin_installation.test-installation"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccStackCheckExists("grafana_cloud_stack.test", &stack),
-					testAccCloudPluginInstallationDestroy(stackSlug, pluginSlug),
+					testAccStackCheckExists(client, "grafana_cloud_stack.test", &stack),
+					testAccCloudPluginInstallationDestroy(client, stackSlug, pluginSlug),
 				),
 			},
 		},
-		CheckDestroy: testAccStackCheckDestroy(&stack),
+		CheckDestroy: testAccStackCheckDestroy(client, &stack),
 	})
 }
 
-func testAccCloudPluginInstallationCheckExists(stackSlug string, pluginSlug string) resource.TestCheckFunc {
+func testAccCloudPluginInstallationCheckExists(client *common.Client, stackSlug string, pluginSlug string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testutils.Provider.Meta().(*common.Client).GrafanaCloudAPI
-		_, _, err := client.InstancesAPI.GetInstancePlugin(context.Background(), stackSlug, pluginSlug).Execute()
+		_, _, err := client.GrafanaCloudAPI.InstancesAPI.GetInstancePlugin(context.Background(), stackSlug, pluginSlug).Execute()
 		if err != nil {
 			return fmt.Errorf("error getting installation: %s", err)
 		}
@@ -87,9 +86,9 @@ func testAccCloudPluginInstallationCheckExists(stackSlug string, pluginSlug stri
 	}
 }
 
-func testAccCloudPluginInstallationDestroy(stackSlug string, pluginSlug string) resource.TestCheckFunc {
+func testAccCloudPluginInstallationDestroy(client *common.Client, stackSlug string, pluginSlug string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		existsErr := testAccCloudPluginInstallationCheckExists(stackSlug, pluginSlug)(s)
+		existsErr := testAccCloudPluginInstallationCheckExists(client, stackSlug, pluginSlug)(s)
 		if existsErr == nil {
 			return fmt.Errorf("installation still exists")
 		}
@@ -256,7 +256,7 @@ func createStack(ctx context.Context, d *schema.ResourceData, client *gcom.APICl
 		return nil
 	})
 	if err != nil {
-		return apiError(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	if diag := readStack(ctx, d, client); diag != nil {
@@ -268,7 +268,7 @@ func createStack(ctx context.Context, d *schema.ResourceData, client *gcom.APICl
 		if timeoutVal := d.Get("wait_for_readiness_timeout").(string); timeoutVal != "" {
 			timeout, _ = time.ParseDuration(timeoutVal)
 		}
-		return waitForStackReadiness(ctx, timeout, d.Get("url").(string))
+		return waitForStackReadiness(ctx, timeout, d.Get("url").(string), client)
 	}
 	return nil
 }
@@ -295,7 +295,7 @@ func updateStack(ctx context.Context, d *schema.ResourceData, client *gcom.APICl
 	req := client.InstancesAPI.PostInstance(ctx, id.(string)).PostInstanceRequest(stack).XRequestId(ClientRequestID())
 	_, _, err = req.Execute()
 	if err != nil {
-		return apiError(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	if diag := readStack(ctx, d, client); diag != nil {
@@ -307,7 +307,7 @@ func updateStack(ctx context.Context, d *schema.ResourceData, client *gcom.APICl
 		if timeoutVal := d.Get("wait_for_readiness_timeout").(string); timeoutVal != "" {
 			timeout, _ = time.ParseDuration(timeoutVal)
 		}
-		return waitForStackReadiness(ctx, timeout, d.Get("url").(string))
+		return waitForStackReadiness(ctx, timeout, d.Get("url").(string), client)
 	}
 	return nil
 }
@@ -320,7 +320,7 @@ func deleteStack(ctx context.Context, d *schema.ResourceData, client *gcom.APICl
 
 	req := client.InstancesAPI.DeleteInstance(ctx, id.(string)).XRequestId(ClientRequestID())
 	_, _, err = req.Execute()
-	return apiError(err)
+	return common.APIErrorDiagnostics("", err)
 }
 
 func readStack(ctx context.Context, d *schema.ResourceData, client *gcom.APIClient) diag.Diagnostics {
@@ -344,7 +344,7 @@ func readStack(ctx context.Context, d *schema.ResourceData, client *gcom.APIClie
 	connectionsReq := client.InstancesAPI.GetConnections(ctx, id.(string))
 	connections, _, err := connectionsReq.Execute()
 	if err != nil {
-		return apiError(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	if err := flattenStack(d, stack, connections); err != nil {
@@ -436,7 +436,8 @@ func appendPath(baseURL, path string) (string, error) {
 }
 
 // waitForStackReadiness retries until the stack is ready, verified by querying the Grafana URL
-func waitForStackReadiness(ctx context.Context, timeout time.Duration, stackURL string) diag.Diagnostics {
+// The query is sent with the HTTP client of the Cloud API client, which carries the proxy, TLS and retry settings of the provider.
+func waitForStackReadiness(ctx context.Context, timeout time.Duration, stackURL string, client *gcom.APIClient) diag.Diagnostics {
 	healthURL, joinErr := url.JoinPath(stackURL, "api", "health")
 	if joinErr != nil {
 		return diag.FromErr(joinErr)
@@ -446,7 +447,7 @@ func waitForStackReadiness(ctx context.Context, timeout time.Duration, stackURL
 		if err != nil {
 			return retry.NonRetryableError(err)
 		}
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.GetConfig().HTTPClient.Do(req)
 		if err != nil {
 			return retry.RetryableError(err)
 		}
@@ -475,10 +476,10 @@ func waitForStackReadiness(ctx context.Context, timeout time.Duration, stackURL
 func waitForStackReadinessFromSlug(ctx context.Context, timeout time.Duration, slug string, client *gcom.APIClient) diag.Diagnostics {
 	stack, _, err := client.InstancesAPI.GetInstance(ctx, slug).Execute()
 	if err != nil {
-		return apiError(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
-	return waitForStackReadiness(ctx, timeout, stack.Url)
+	return waitForStackReadiness(ctx, timeout, stack.Url, client)
 }
 
 func defaultStackURL(slug string) string {
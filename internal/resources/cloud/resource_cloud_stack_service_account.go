@@ -9,6 +9,7 @@ import (
 
 	"github.com/grafana/grafana-com-public-clients/go/gcom"
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -194,17 +195,29 @@ func CreateTemporaryStackGrafanaClient(ctx context.Context, cloudClient *gcom.AP
 		return nil, nil, err
 	}
 
-	client := goapi.NewHTTPClientWithConfig(nil, &goapi.TransportConfig{
+	cfg := &goapi.TransportConfig{
 		Host:         stackURLParsed.Host,
 		Schemes:      []string{stackURLParsed.Scheme},
 		BasePath:     "api",
 		APIKey:       *token.Key,
 		NumRetries:   5,
 		RetryTimeout: 10 * time.Second,
-	})
+	}
+	// Send the requests through the HTTP client of the Cloud API client, which carries the proxy, TLS and retry settings of the provider
+	httpClient := cloudClient.GetConfig().HTTPClient
+	useCloudTransport := httpClient != nil && httpClient.Transport != nil
+	if useCloudTransport {
+		cfg.NumRetries = 0 // Retried by the HTTP client
+	}
+	client := goapi.NewHTTPClientWithConfig(nil, cfg)
+	if useCloudTransport {
+		if err := common.SetGrafanaAPITransport(client, httpClient.Transport); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	cleanup := func() error {
-		_, err = client.ServiceAccounts.DeleteServiceAccount(*sa.Id)
+		_, err := client.ServiceAccounts.DeleteServiceAccountWithParams(service_accounts.NewDeleteServiceAccountParams().WithServiceAccountID(*sa.Id).WithContext(ctx))
 		return err
 	}
 
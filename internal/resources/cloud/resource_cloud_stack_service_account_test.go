@@ -15,24 +15,24 @@ import (
 )
 
 func TestAccGrafanaServiceAccountFromCloud(t *testing.T) {
-	testutils.CheckCloudAPITestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
+	client := cassette.Client()
 
 	var stack gcom.FormattedApiInstance
 	prefix := "tfsatest"
-	slug := GetRandomStackName(prefix)
+	slug := GetRandomStackName(cassette, prefix)
 
-	resource.ParallelTest(t, resource.TestCase{
+	cassette.Test(t, resource.TestCase{
 		PreCheck: func() {
-			testAccDeleteExistingStacks(t, prefix)
+			testAccDeleteExistingStacks(t, client, prefix)
 		},
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccStackCheckDestroy(&stack),
+		CheckDestroy: testAccStackCheckDestroy(client, &stack),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccGrafanaServiceAccountFromCloud(slug, slug, true, "Admin"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccStackCheckExists("grafana_cloud_stack.test", &stack),
-					testAccGrafanaAuthCheckServiceAccounts(&stack, []string{"management-sa"}),
+					testAccStackCheckExists(client, "grafana_cloud_stack.test", &stack),
+					testAccGrafanaAuthCheckServiceAccounts(client, &stack, []string{"management-sa"}),
 					resource.TestCheckResourceAttr("grafana_cloud_stack_service_account.management", "name", "management-sa"),
 					resource.TestCheckResourceAttr("grafana_cloud_stack_service_account.management", "role", "Admin"),
 					resource.TestCheckResourceAttr("grafana_cloud_stack_service_account.management", "is_disabled", "true"),
@@ -55,25 +55,25 @@ func TestAccGrafanaServiceAccountFromCloud(t *testing.T) {
 			},
 			{
 				Config: testAccStackConfigBasic(slug, slug, "description"),
-				Check:  testAccGrafanaAuthCheckServiceAccounts(&stack, []string{}),
+				Check:  testAccGrafanaAuthCheckServiceAccounts(client, &stack, []string{}),
 			},
 		},
 	})
 }
 
 func TestAccGrafanaServiceAccountFromCloud_AssignRoleOrPermissions(t *testing.T) {
-	testutils.CheckCloudAPITestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
+	client := cassette.Client()
 
 	var stack gcom.FormattedApiInstance
 	prefix := "tfsatest"
-	slug := GetRandomStackName(prefix)
+	slug := GetRandomStackName(cassette, prefix)
 
-	resource.Test(t, resource.TestCase{
+	cassette.Test(t, resource.TestCase{
 		PreCheck: func() {
-			testAccDeleteExistingStacks(t, prefix)
+			testAccDeleteExistingStacks(t, client, prefix)
 		},
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccStackCheckDestroy(&stack),
+		CheckDestroy: testAccStackCheckDestroy(client, &stack),
 		Steps: []resource.TestStep{
 			// SA permission item
 			{
@@ -153,24 +153,24 @@ func TestAccGrafanaServiceAccountFromCloud_AssignRoleOrPermissions(t *testing.T)
 }
 
 func TestAccGrafanaServiceAccountFromCloudNoneRole(t *testing.T) {
-	testutils.CheckCloudAPITestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
+	client := cassette.Client()
 
 	var stack gcom.FormattedApiInstance
 	prefix := "tfsanone"
-	slug := GetRandomStackName(prefix)
+	slug := GetRandomStackName(cassette, prefix)
 
-	resource.ParallelTest(t, resource.TestCase{
+	cassette.Test(t, resource.TestCase{
 		PreCheck: func() {
-			testAccDeleteExistingStacks(t, prefix)
+			testAccDeleteExistingStacks(t, client, prefix)
 		},
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccStackCheckDestroy(&stack),
+		CheckDestroy: testAccStackCheckDestroy(client, &stack),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccGrafanaServiceAccountFromCloud(slug, slug, true, "None"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccStackCheckExists("grafana_cloud_stack.test", &stack),
-					testAccGrafanaAuthCheckServiceAccounts(&stack, []string{"management-sa"}),
+					testAccStackCheckExists(client, "grafana_cloud_stack.test", &stack),
+					testAccGrafanaAuthCheckServiceAccounts(client, &stack, []string{"management-sa"}),
 					resource.TestCheckResourceAttr("grafana_cloud_stack_service_account.management", "name", "management-sa"),
 					resource.TestCheckResourceAttr("grafana_cloud_stack_service_account.management", "role", "None"),
 					resource.TestCheckResourceAttr("grafana_cloud_stack_service_account.management", "is_disabled", "true"),
@@ -200,10 +200,9 @@ func testAccGrafanaServiceAccountFromCloud(name, slug string, disabled bool, rol
 	`, role, disabled)
 }
 
-func testAccGrafanaAuthCheckServiceAccounts(stack *gcom.FormattedApiInstance, expectedSAs []string) resource.TestCheckFunc {
+func testAccGrafanaAuthCheckServiceAccounts(client *common.Client, stack *gcom.FormattedApiInstance, expectedSAs []string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		cloudClient := testutils.Provider.Meta().(*common.Client).GrafanaCloudAPI
-		c, cleanup, err := cloud.CreateTemporaryStackGrafanaClient(context.Background(), cloudClient, stack.Slug, "test-api-key-")
+		c, cleanup, err := cloud.CreateTemporaryStackGrafanaClient(context.Background(), client.GrafanaCloudAPI, stack.Slug, "test-api-key-")
 		if err != nil {
 			return err
 		}
@@ -19,16 +19,17 @@ import (
 )
 
 func TestResourceStack_Basic(t *testing.T) {
-	testutils.CheckCloudAPITestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
+	client := cassette.Client()
 
 	prefix := "tfresourcetest"
 
 	var stack gcom.FormattedApiInstance
-	resourceName := GetRandomStackName(prefix)
+	resourceName := GetRandomStackName(cassette, prefix)
 	stackDescription := "This is a test stack"
 
 	firstStepChecks := resource.ComposeTestCheckFunc(
-		testAccStackCheckExists("grafana_cloud_stack.test", &stack),
+		testAccStackCheckExists(client, "grafana_cloud_stack.test", &stack),
 		resource.TestMatchResourceAttr("grafana_cloud_stack.test", "id", common.IDRegexp),
 		resource.TestCheckResourceAttr("grafana_cloud_stack.test", "name", resourceName),
 		resource.TestCheckResourceAttr("grafana_cloud_stack.test", "slug", resourceName),
@@ -53,12 +54,11 @@ func TestResourceStack_Basic(t *testing.T) {
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "otlp_url"),
 	)
 
-	resource.ParallelTest(t, resource.TestCase{
+	cassette.Test(t, resource.TestCase{
 		PreCheck: func() {
-			testAccDeleteExistingStacks(t, prefix)
+			testAccDeleteExistingStacks(t, client, prefix)
 		},
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccStackCheckDestroy(&stack),
+		CheckDestroy: testAccStackCheckDestroy(client, &stack),
 		Steps: []resource.TestStep{
 			// Create a basic stack
 			{
@@ -84,12 +84,12 @@ func TestResourceStack_Basic(t *testing.T) {
 				// Delete the stack outside of the test and make sure it is recreated
 				// Terraform should detect that it's gone and recreate it (status should be active at all times)
 				PreConfig: func() {
-					testAccDeleteExistingStacks(t, prefix)
+					testAccDeleteExistingStacks(t, client, prefix)
 					time.Sleep(10 * time.Second)
 				},
 				Config: testAccStackConfigBasic(resourceName, resourceName, stackDescription),
 				Check: resource.ComposeTestCheckFunc(
-					testAccStackCheckExists("grafana_cloud_stack.test", &stack),
+					testAccStackCheckExists(client, "grafana_cloud_stack.test", &stack),
 					resource.TestMatchResourceAttr("grafana_cloud_stack.test", "id", common.IDRegexp),
 					resource.TestCheckResourceAttr("grafana_cloud_stack.test", "name", resourceName),
 					resource.TestCheckResourceAttr("grafana_cloud_stack.test", "slug", resourceName),
@@ -100,7 +100,7 @@ func TestResourceStack_Basic(t *testing.T) {
 			{
 				Config: testAccStackConfigUpdate(resourceName+"new", resourceName, stackDescription),
 				Check: resource.ComposeTestCheckFunc(
-					testAccStackCheckExists("grafana_cloud_stack.test", &stack),
+					testAccStackCheckExists(client, "grafana_cloud_stack.test", &stack),
 					resource.TestMatchResourceAttr("grafana_cloud_stack.test", "id", common.IDRegexp),
 					resource.TestCheckResourceAttr("grafana_cloud_stack.test", "name", resourceName+"new"),
 					resource.TestCheckResourceAttr("grafana_cloud_stack.test", "slug", resourceName),
@@ -183,16 +183,15 @@ func TestResourceStack_Invalid(t *testing.T) {
 	})
 }
 
-func testAccDeleteExistingStacks(t *testing.T, prefix string) {
-	client := testutils.Provider.Meta().(*common.Client).GrafanaCloudAPI
-	resp, _, err := client.InstancesAPI.GetInstances(context.Background()).Execute()
+func testAccDeleteExistingStacks(t *testing.T, client *common.Client, prefix string) {
+	resp, _, err := client.GrafanaCloudAPI.InstancesAPI.GetInstances(context.Background()).Execute()
 	if err != nil {
 		t.Error(err)
 	}
 
 	for _, stack := range resp.Items {
 		if strings.HasPrefix(stack.Name, prefix) {
-			_, _, err := client.InstancesAPI.DeleteInstance(context.Background(), stack.Slug).XRequestId(cloud.ClientRequestID()).Execute()
+			_, _, err := client.GrafanaCloudAPI.InstancesAPI.DeleteInstance(context.Background(), stack.Slug).XRequestId(cloud.ClientRequestID()).Execute()
 			if err != nil {
 				t.Error(err)
 			}
@@ -200,7 +199,7 @@ func testAccDeleteExistingStacks(t *testing.T, prefix string) {
 	}
 }
 
-func testAccStackCheckExists(rn string, a *gcom.FormattedApiInstance) resource.TestCheckFunc {
+func testAccStackCheckExists(client *common.Client, rn string, a *gcom.FormattedApiInstance) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
 		if !ok {
@@ -211,15 +210,14 @@ func testAccStackCheckExists(rn string, a *gcom.FormattedApiInstance) resource.T
 			return fmt.Errorf("resource id not set")
 		}
 
-		client := testutils.Provider.Meta().(*common.Client).GrafanaCloudAPI
-		stack, _, err := client.InstancesAPI.GetInstance(context.Background(), rs.Primary.ID).Execute()
+		stack, _, err := client.GrafanaCloudAPI.InstancesAPI.GetInstance(context.Background(), rs.Primary.ID).Execute()
 		if err != nil {
 			return fmt.Errorf("error getting data source: %s", err)
 		}
 
 		*a = *stack
 
-		if destroyErr := testAccStackCheckDestroy(a)(s); destroyErr == nil {
+		if destroyErr := testAccStackCheckDestroy(client, a)(s); destroyErr == nil {
 			return fmt.Errorf("expected the stack's destroy check to fail, but it didn't")
 		}
 
@@ -227,10 +225,9 @@ func testAccStackCheckExists(rn string, a *gcom.FormattedApiInstance) resource.T
 	}
 }
 
-func testAccStackCheckDestroy(a *gcom.FormattedApiInstance) resource.TestCheckFunc {
+func testAccStackCheckDestroy(client *common.Client, a *gcom.FormattedApiInstance) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testutils.Provider.Meta().(*common.Client).GrafanaCloudAPI
-		stack, _, err := client.InstancesAPI.GetInstance(context.Background(), a.Slug).Execute()
+		stack, _, err := client.GrafanaCloudAPI.InstancesAPI.GetInstance(context.Background(), a.Slug).Execute()
 		if err == nil && stack.Name != "" && stack.Status != "deleting" {
 			return fmt.Errorf("stack `%s` with ID `%d` still exists after destroy. Status: %s", stack.Name, int(stack.Id), stack.Status)
 		}
@@ -275,6 +272,6 @@ func testAccStackConfigUpdate(name string, slug string, description string) stri
 }
 
 // Prefix a character as stack name can't start with a number
-func GetRandomStackName(prefix string) string {
-	return prefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+func GetRandomStackName(cassette *testutils.Cassette, prefix string) string {
+	return prefix + cassette.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 }
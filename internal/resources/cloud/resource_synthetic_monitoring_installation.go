@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 
 	"github.com/grafana/grafana-com-public-clients/go/gcom"
@@ -21,6 +22,16 @@ var smAPIURLsExceptions = map[string]string{
 	"us-azure":        "https://synthetic-monitoring-api-us-central2.grafana.net",
 }
 
+// SMAPIURLForRegion returns the Synthetic Monitoring API URL for a Grafana Cloud region slug.
+// TODO: Get this URL programatically
+// Perhaps it should be exposed to users through /api/stack-regions?
+func SMAPIURLForRegion(regionSlug string) string {
+	if apiURL, ok := smAPIURLsExceptions[regionSlug]; ok {
+		return apiURL
+	}
+	return fmt.Sprintf("https://synthetic-monitoring-api-%s.grafana.net", strings.TrimPrefix(regionSlug, "prod-"))
+}
+
 func resourceSyntheticMonitoringInstallation() *common.Resource {
 	schema := &schema.Resource{
 
@@ -82,20 +93,15 @@ func resourceInstallationCreate(ctx context.Context, d *schema.ResourceData, clo
 	req := cloudClient.InstancesAPI.GetInstance(ctx, d.Get("stack_id").(string))
 	stack, _, err := req.Execute()
 	if err != nil {
-		return apiError(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
-	// TODO: Get this URL programatically
-	// Perhaps it should be exposed to users through /api/stack-regions?
 	apiURL := d.Get("stack_sm_api_url").(string)
 	if apiURL == "" {
-		apiURL = smAPIURLsExceptions[stack.RegionSlug]
-	}
-	if apiURL == "" {
-		apiURL = fmt.Sprintf("https://synthetic-monitoring-api-%s.grafana.net", strings.TrimPrefix(stack.RegionSlug, "prod-"))
+		apiURL = SMAPIURLForRegion(stack.RegionSlug)
 	}
 
-	smClient := SMAPI.NewClient(apiURL, "", nil)
+	smClient := SMAPI.NewClient(apiURL, "", cloudClient.GetConfig().HTTPClient)
 	stackID, metricsID, logsID := int64(stack.Id), int64(stack.HmInstancePromId), int64(stack.HlInstanceId)
 	resp, err := smClient.Install(ctx, stackID, metricsID, logsID, d.Get("metrics_publisher_key").(string))
 	if err != nil {
@@ -111,7 +117,7 @@ func resourceInstallationCreate(ctx context.Context, d *schema.ResourceData, clo
 // This read function will only invalidate the state (forcing recreation) if the installation has been deleted.
 func resourceInstallationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiURL := strings.Split(d.Id(), ";")[0]
-	tempClient := SMAPI.NewClient(apiURL, d.Get("sm_access_token").(string), nil)
+	tempClient := SMAPI.NewClient(apiURL, d.Get("sm_access_token").(string), installationHTTPClient(meta))
 	if err := tempClient.ValidateToken(ctx); err != nil {
 		log.Printf("[WARN] removing SM installation from state because it is no longer valid")
 		d.SetId("")
@@ -122,10 +128,19 @@ func resourceInstallationRead(ctx context.Context, d *schema.ResourceData, meta
 
 func resourceInstallationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiURL := strings.Split(d.Id(), ";")[0]
-	tempClient := SMAPI.NewClient(apiURL, d.Get("sm_access_token").(string), nil)
+	tempClient := SMAPI.NewClient(apiURL, d.Get("sm_access_token").(string), installationHTTPClient(meta))
 	if err := tempClient.DeleteToken(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
 }
+
+// installationHTTPClient returns the HTTP client of the Cloud API client, which carries the proxy, TLS and retry settings of the provider.
+// The Cloud API client isn't required to read or delete an installation, the default HTTP client is used without it.
+func installationHTTPClient(meta interface{}) *http.Client {
+	if client, ok := meta.(*common.Client); ok && client.GrafanaCloudAPI != nil {
+		return client.GrafanaCloudAPI.GetConfig().HTTPClient
+	}
+	return nil
+}
@@ -10,30 +10,30 @@ import (
 )
 
 func TestAccSyntheticMonitoringInstallation(t *testing.T) {
-	testutils.CheckCloudAPITestsEnabled(t)
-
 	for region, expectedURL := range map[string]string{
 		"prod-ca-east-0": "https://synthetic-monitoring-api-ca-east-0.grafana.net",
 		"eu":             "https://synthetic-monitoring-api-eu-west.grafana.net",
 	} {
 		t.Run(region, func(t *testing.T) {
+			cassette := testutils.NewCassette(t, testutils.CheckCloudAPITestsEnabled)
+			client := cassette.Client()
+
 			var stack gcom.FormattedApiInstance
 			stackPrefix := "tfsminstalltest"
-			testAccDeleteExistingStacks(t, stackPrefix)
-			stackSlug := GetRandomStackName(stackPrefix)
+			testAccDeleteExistingStacks(t, client, stackPrefix)
+			stackSlug := GetRandomStackName(cassette, stackPrefix)
 
 			accessPolicyPrefix := "testsminstall-"
-			testAccDeleteExistingAccessPolicies(t, accessPolicyPrefix)
-			accessPolicyName := accessPolicyPrefix + acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+			testAccDeleteExistingAccessPolicies(t, client, accessPolicyPrefix)
+			accessPolicyName := accessPolicyPrefix + cassette.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
 
-			resource.ParallelTest(t, resource.TestCase{
-				ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-				CheckDestroy:             testAccStackCheckDestroy(&stack),
+			cassette.Test(t, resource.TestCase{
+				CheckDestroy: testAccStackCheckDestroy(client, &stack),
 				Steps: []resource.TestStep{
 					{
 						Config: testAccSyntheticMonitoringInstallation(stackSlug, accessPolicyName, region),
 						Check: resource.ComposeTestCheckFunc(
-							testAccStackCheckExists("grafana_cloud_stack.test", &stack),
+							testAccStackCheckExists(client, "grafana_cloud_stack.test", &stack),
 							resource.TestCheckResourceAttrSet("grafana_synthetic_monitoring_installation.test", "sm_access_token"),
 							resource.TestCheckResourceAttr("grafana_synthetic_monitoring_installation.test", "stack_sm_api_url", expectedURL),
 						),
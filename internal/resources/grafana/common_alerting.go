@@ -0,0 +1,76 @@
+package grafana
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// alertingOrgLockKeys locks alerting resources by org.
+// Contact points, notification policies, mute timings and message templates are all stored in the Alertmanager configuration of their org.
+func alertingOrgLockKeys(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	orgID, err := alertingResourceOrgID(d, meta)
+	if err != nil {
+		return nil, err
+	}
+	return []string{alertingOrgLockKey(orgID)}, nil
+}
+
+func alertingOrgLockKey(orgID int64) string {
+	return fmt.Sprintf("org:%d", orgID)
+}
+
+// alertingRuleGroupLockKeys locks rule groups by org and folder.
+// Rule groups are stored independently from the Alertmanager configuration, so they don't conflict with other alerting resources.
+// When the folder changes, both the previous and the new folder are locked.
+// Rules with notification settings route their notifications through the Alertmanager configuration of the org,
+// which Grafana updates when they're saved, so the org is also locked when a rule of the group sets them (before or after the change).
+func alertingRuleGroupLockKeys(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	orgID, err := alertingResourceOrgID(d, meta)
+	if err != nil {
+		return nil, err
+	}
+	oldFolderUID, newFolderUID := d.GetChange("folder_uid")
+	keys := []string{fmt.Sprintf("%s:folder:%s", alertingOrgLockKey(orgID), newFolderUID.(string))}
+	if oldFolderUID.(string) != "" && oldFolderUID != newFolderUID {
+		keys = append(keys, fmt.Sprintf("%s:folder:%s", alertingOrgLockKey(orgID), oldFolderUID.(string)))
+	}
+	if oldRules, newRules := d.GetChange("rule"); ruleGroupHasNotificationSettings(oldRules) || ruleGroupHasNotificationSettings(newRules) {
+		keys = append(keys, alertingOrgLockKey(orgID))
+	}
+	return keys, nil
+}
+
+func ruleGroupHasNotificationSettings(rules interface{}) bool {
+	list, _ := rules.([]interface{})
+	for _, rule := range list {
+		if rule, ok := rule.(map[string]interface{}); ok {
+			if settings, ok := rule["notification_settings"].([]interface{}); ok && len(settings) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// alertingResourceOrgID returns the org of an alerting resource, from its ID if it exists or from its `org_id` attribute otherwise
+func alertingResourceOrgID(d *schema.ResourceData, meta interface{}) (int64, error) {
+	client, ok := meta.(*common.Client)
+	if !ok || client.GrafanaAPI == nil {
+		return 0, errors.New("the Grafana client is required for this resource. Set the auth and url provider attributes")
+	}
+	if d.Id() != "" {
+		_, orgID, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
+		return orgID, nil
+	}
+	if orgID, ok := d.Get("org_id").(string); ok && orgID != "" {
+		if _, err := strconv.ParseInt(orgID, 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid org_id %q: %w", orgID, err)
+		}
+	}
+	_, orgID := OAPIClientFromNewOrgResource(meta, d)
+	return orgID, nil
+}
@@ -0,0 +1,63 @@
+package grafana
+
+import (
+	"testing"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertingRuleGroupLockKeys(t *testing.T) {
+	client := &common.Client{GrafanaAPI: goapi.NewHTTPClientWithConfig(nil, &goapi.TransportConfig{Host: "localhost:3000", OrgID: 1})}
+	rule := map[string]interface{}{"name": "rule"}
+	ruleWithNotificationSettings := map[string]interface{}{
+		"name":                  "rule",
+		"notification_settings": []interface{}{map[string]interface{}{"contact_point": "contact point"}},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		id       string
+		rules    []interface{}
+		expected []string
+	}{
+		{
+			name:     "new group",
+			rules:    []interface{}{rule},
+			expected: []string{"org:1:folder:folder"},
+		},
+		{
+			name:     "new group with notification settings",
+			rules:    []interface{}{rule, ruleWithNotificationSettings},
+			expected: []string{"org:1:folder:folder", "org:1"},
+		},
+		{
+			name:     "existing group in another org",
+			id:       "2:folder:group",
+			rules:    []interface{}{rule},
+			expected: []string{"org:2:folder:folder"},
+		},
+		{
+			name:     "existing group in another org with notification settings",
+			id:       "2:folder:group",
+			rules:    []interface{}{ruleWithNotificationSettings},
+			expected: []string{"org:2:folder:folder", "org:2"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceRuleGroup().Schema.Schema, map[string]interface{}{
+				"name":             "group",
+				"folder_uid":       "folder",
+				"interval_seconds": 60,
+				"rule":             tc.rules,
+			})
+			d.SetId(tc.id)
+			keys, err := alertingRuleGroupLockKeys(d, client)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, keys)
+		})
+	}
+}
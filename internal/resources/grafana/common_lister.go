@@ -23,19 +23,19 @@ func NewListerData(singleOrg bool) *ListerData {
 	}
 }
 
-func (ld *ListerData) OrgIDs(client *goapi.GrafanaHTTPAPI) ([]int64, error) {
+func (ld *ListerData) OrgIDs(ctx context.Context, client *goapi.GrafanaHTTPAPI) ([]int64, error) {
 	if ld.singleOrg {
 		return []int64{0}, nil
 	}
 
 	var err error
 	ld.orgsInit.Do(func() {
-		client = client.Clone().WithOrgID(0)
+		client = common.GrafanaAPIWithOrgID(client, 0)
 
 		var page int64 = 0
 		for {
 			var resp *orgs.SearchOrgsOK
-			if resp, err = client.Orgs.SearchOrgs(orgs.NewSearchOrgsParams().WithPage(&page)); err != nil {
+			if resp, err = client.Orgs.SearchOrgs(orgs.NewSearchOrgsParams().WithPage(&page).WithContext(ctx)); err != nil {
 				return
 			}
 			for _, org := range resp.Payload {
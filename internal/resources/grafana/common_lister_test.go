@@ -0,0 +1,44 @@
+package grafana_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/resources/grafana"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
+)
+
+func TestListerContext(t *testing.T) {
+	mock := testutils.NewMockGrafana(t)
+	client := &common.Client{GrafanaAPI: mock.Client()}
+	if _, err := client.GrafanaAPI.Folders.CreateFolder(&models.CreateFolderCommand{UID: "lister-folder", Title: "Lister Folder"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var lister common.ResourceListIDsFunc
+	for _, r := range grafana.Resources {
+		if r.Name == "grafana_folder" {
+			lister = r.ListIDsFunc
+		}
+	}
+	if lister == nil {
+		t.Fatal("grafana_folder has no lister")
+	}
+
+	ids, err := lister(context.Background(), client, grafana.NewListerData(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != "1:lister-folder" {
+		t.Errorf("expected the folder to be listed, got %v", ids)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := lister(ctx, client, grafana.NewListerData(false)); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the listing to be cancelled, got %v", err)
+	}
+}
@@ -59,7 +59,7 @@ func (r *basePluginFrameworkResource) clientFromExistingOrgResource(idFormat *co
 	} else {
 		orgID = split[0].(int64)
 		split = split[1:]
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 	return client, orgID, split, nil
 }
@@ -76,7 +76,7 @@ func (r *basePluginFrameworkResource) clientFromNewOrgResource(orgIDStr string)
 	if orgID == 0 {
 		orgID = client.OrgID()
 	} else if orgID > 0 {
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 	return client, orgID, nil
 }
@@ -87,7 +87,7 @@ func (r *basePluginFrameworkResource) clientFromNewOrgResource(orgIDStr string)
 // 	return nil, 0, nil, fmt.Errorf("client not configured")
 // }
 
-// 	client := r.client.Clone().WithOrgID(0)
+// 	client := common.GrafanaAPIWithOrgID(r.client, 0)
 // 	if r.config.APIKey != "" {
 // 		return client, fmt.Errorf("global scope resources cannot be managed with an API key. Use basic auth instead")
 // 	}
@@ -150,3 +150,11 @@ func (d *orgScopedAttributePlanModifier) PlanModifyString(ctx context.Context, r
 		resp.PlanValue = req.StateValue
 	}
 }
+
+// stringValue returns a null value for empty strings, unless the current value is an empty string
+func stringValue(value string, current types.String) types.String {
+	if value == "" && (current.IsNull() || current.IsUnknown() || current.ValueString() != "") {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
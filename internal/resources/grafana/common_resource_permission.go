@@ -4,6 +4,7 @@
 package grafana
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/grafana/grafana-openapi-client-go/client"
@@ -101,7 +102,7 @@ func (r *resourcePermissionBase) addInSchemaAttributes(attributes map[string]sch
 	return attributes
 }
 
-func (r *resourcePermissionBase) readItem(id string, checkExistsFunc func(client *client.GrafanaHTTPAPI, itemID string) error) (*resourcePermissionItemBaseModel, diag.Diagnostics) {
+func (r *resourcePermissionBase) readItem(ctx context.Context, id string, checkExistsFunc func(ctx context.Context, client *client.GrafanaHTTPAPI, itemID string) error) (*resourcePermissionItemBaseModel, diag.Diagnostics) {
 	client, orgID, splitID, err := r.clientFromExistingOrgResource(resourceFolderPermissionItemID, id)
 	if err != nil {
 		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Unable to parse resource ID", err.Error())}
@@ -111,20 +112,20 @@ func (r *resourcePermissionBase) readItem(id string, checkExistsFunc func(client
 	permissionTargetID := splitID[2].(string)
 
 	// Check that the resource exists. This depends on the resource type, so a generic function is passed in.
-	if err := checkExistsFunc(client, itemID); err != nil {
+	if err := checkExistsFunc(ctx, client, itemID); err != nil {
 		if common.IsNotFoundError(err) {
 			return nil, nil
 		}
-		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Resource does not exist", err.Error())}
+		return nil, common.APIErrorDiagnosticsPluginFramework("Resource does not exist", err)
 	}
 
 	// GET
-	permissionsResp, err := client.AccessControl.GetResourcePermissions(itemID, r.resourceType)
+	permissionsResp, err := client.AccessControl.GetResourcePermissionsWithParams(access_control.NewGetResourcePermissionsParams().WithResourceID(itemID).WithResource(r.resourceType).WithContext(ctx))
 	if err != nil {
 		if common.IsNotFoundError(err) {
 			return nil, nil
 		}
-		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Failed to read permissions", err.Error())}
+		return nil, common.APIErrorDiagnosticsPluginFramework("Failed to read permissions", err)
 	}
 
 	for _, permission := range permissionsResp.Payload {
@@ -163,7 +164,7 @@ func (r *resourcePermissionBase) readItem(id string, checkExistsFunc func(client
 	return nil, nil
 }
 
-func (r *resourcePermissionBase) writeItem(itemID string, data *resourcePermissionItemBaseModel) diag.Diagnostics {
+func (r *resourcePermissionBase) writeItem(ctx context.Context, itemID string, data *resourcePermissionItemBaseModel) diag.Diagnostics {
 	client, orgID, err := r.clientFromNewOrgResource(data.OrgID.ValueString())
 	if err != nil {
 		return diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get client", err.Error())}
@@ -191,7 +192,8 @@ func (r *resourcePermissionBase) writeItem(itemID string, data *resourcePermissi
 					Permission: data.Permission.ValueString(),
 				}).
 				WithResource(r.resourceType).
-				WithResourceID(itemID),
+				WithResourceID(itemID).
+				WithContext(ctx),
 		)
 		data.ID = types.StringValue(
 			resourceFolderPermissionItemID.Make(orgID, itemID, permissionTargetUser, userIDStr),
@@ -209,7 +211,8 @@ func (r *resourcePermissionBase) writeItem(itemID string, data *resourcePermissi
 					Permission: data.Permission.ValueString(),
 				}).
 				WithResource(r.resourceType).
-				WithResourceID(itemID),
+				WithResourceID(itemID).
+				WithContext(ctx),
 		)
 		data.ID = types.StringValue(
 			resourceFolderPermissionItemID.Make(orgID, itemID, permissionTargetTeam, teamIDStr),
@@ -222,14 +225,15 @@ func (r *resourcePermissionBase) writeItem(itemID string, data *resourcePermissi
 					Permission: data.Permission.ValueString(),
 				}).
 				WithResource(r.resourceType).
-				WithResourceID(itemID),
+				WithResourceID(itemID).
+				WithContext(ctx),
 		)
 		data.ID = types.StringValue(
 			resourceFolderPermissionItemID.Make(orgID, itemID, permissionTargetRole, data.Role.ValueString()),
 		)
 	}
 	if err != nil {
-		return diag.Diagnostics{diag.NewErrorDiagnostic("Failed to write permissions", err.Error())}
+		return common.APIErrorDiagnosticsPluginFramework("Failed to write permissions", err)
 	}
 	return nil
 }
@@ -21,7 +21,7 @@ type resourcePermissionsHelper struct {
 
 	// Given the resource data, check the resource exists and return the correct ID for permissions.
 	// Ex: We support ID and UID for dashboards but the permissions are managed by UID.
-	getResource func(d *schema.ResourceData, meta interface{}) (string, error)
+	getResource func(ctx context.Context, d *schema.ResourceData, meta interface{}) (string, error)
 }
 
 func (h *resourcePermissionsHelper) addCommonSchemaAttributes(s map[string]*schema.Schema) {
@@ -96,7 +96,7 @@ func (h *resourcePermissionsHelper) addCommonSchemaAttributes(s map[string]*sche
 func (h *resourcePermissionsHelper) updatePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 
-	resourceID, err := h.getResource(d, meta)
+	resourceID, err := h.getResource(ctx, d, meta)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -126,7 +126,7 @@ func (h *resourcePermissionsHelper) updatePermissions(ctx context.Context, d *sc
 		permissionList = append(permissionList, &permissionItem)
 	}
 
-	if err := h.updateResourcePermissions(client, resourceID, permissionList); err != nil {
+	if err := h.updateResourcePermissions(ctx, client, resourceID, permissionList); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -139,12 +139,12 @@ func (h *resourcePermissionsHelper) readPermissions(ctx context.Context, d *sche
 	client, orgID, resourceID := OAPIClientFromExistingOrgResource(meta, d.Id())
 
 	// Check if the resource still exists
-	_, err := h.getResource(d, meta)
+	_, err := h.getResource(ctx, d, meta)
 	if err, shouldReturn := common.CheckReadError("resource", d, err); shouldReturn {
 		return err
 	}
 
-	resp, err := client.AccessControl.GetResourcePermissions(resourceID, h.resourceType)
+	resp, err := client.AccessControl.GetResourcePermissionsWithParams(access_control.NewGetResourcePermissionsParams().WithResourceID(resourceID).WithResource(h.resourceType).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("permissions", d, err); shouldReturn {
 		return err
 	}
@@ -179,17 +179,17 @@ func (h *resourcePermissionsHelper) deletePermissions(ctx context.Context, d *sc
 	// we will simply remove all permissions, leaving a resource that only an admin can access.
 	// if for some reason the resource doesn't exist, we'll just ignore the error
 	client, _, resourceID := OAPIClientFromExistingOrgResource(meta, d.Id())
-	err := h.updateResourcePermissions(client, resourceID, []*models.SetResourcePermissionCommand{})
+	err := h.updateResourcePermissions(ctx, client, resourceID, []*models.SetResourcePermissionCommand{})
 	diags, _ := common.CheckReadError("permissions", d, err)
 	return diags
 }
 
-func (h *resourcePermissionsHelper) updateResourcePermissions(client *goapi.GrafanaHTTPAPI, uid string, permissions []*models.SetResourcePermissionCommand) error {
+func (h *resourcePermissionsHelper) updateResourcePermissions(ctx context.Context, client *goapi.GrafanaHTTPAPI, uid string, permissions []*models.SetResourcePermissionCommand) error {
 	areEqual := func(a *models.ResourcePermissionDTO, b *models.SetResourcePermissionCommand) bool {
 		return a.Permission == b.Permission && a.TeamID == b.TeamID && a.UserID == b.UserID && a.BuiltInRole == b.BuiltInRole
 	}
 
-	listResp, err := client.AccessControl.GetResourcePermissions(uid, h.resourceType)
+	listResp, err := client.AccessControl.GetResourcePermissionsWithParams(access_control.NewGetResourcePermissionsParams().WithResourceID(uid).WithResource(h.resourceType).WithContext(ctx))
 	if err != nil {
 		return err
 	}
@@ -232,7 +232,8 @@ addLoop:
 	params := access_control.NewSetResourcePermissionsParams().
 		WithResource(h.resourceType).
 		WithResourceID(uid).
-		WithBody(&body)
+		WithBody(&body).
+		WithContext(ctx)
 	_, err = client.AccessControl.SetResourcePermissions(params)
 
 	return err
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
 	"github.com/grafana/grafana-openapi-client-go/client/search"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
@@ -87,14 +88,14 @@ func dataSourceDashboardRead(ctx context.Context, d *schema.ResourceData, meta i
 		if id < 1 {
 			return diag.FromErr(fmt.Errorf("must specify either `dashboard_id` or `uid`"))
 		}
-		dashboard, err := getDashboardByID(client, int64(id))
+		dashboard, err := getDashboardByID(ctx, client, int64(id))
 		if err != nil {
 			return diag.FromErr(err)
 		}
 		uid = dashboard.UID
 	}
 
-	resp, err := client.Dashboards.GetDashboardByUID(uid)
+	resp, err := client.Dashboards.GetDashboardByUIDWithParams(dashboards.NewGetDashboardByUIDParams().WithUID(uid).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -119,9 +120,9 @@ func dataSourceDashboardRead(ctx context.Context, d *schema.ResourceData, meta i
 	return nil
 }
 
-func getDashboardByID(client *goapi.GrafanaHTTPAPI, id int64) (*models.Hit, error) {
+func getDashboardByID(ctx context.Context, client *goapi.GrafanaHTTPAPI, id int64) (*models.Hit, error) {
 	searchType := "dash-db"
-	params := search.NewSearchParams().WithType(&searchType).WithDashboardIds([]int64{id})
+	params := search.NewSearchParams().WithType(&searchType).WithDashboardIds([]int64{id}).WithContext(ctx)
 	resp, err := client.Search.Search(params)
 	if err != nil {
 		return nil, err
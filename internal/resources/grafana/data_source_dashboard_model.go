@@ -0,0 +1,804 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dashboardModelSchemaVersion is the schema version of the dashboards built by the `grafana_dashboard_model` data source.
+// Grafana runs its migrations on dashboards with an older schema version, which would change the JSON that was built.
+const dashboardModelSchemaVersion = 39
+
+var (
+	dashboardModelGraphTooltips = []string{"default", "shared_crosshair", "shared_tooltip"}
+	dashboardModelVariableTypes = []string{"query", "custom", "constant", "datasource", "interval", "textbox", "adhoc"}
+	dashboardModelVariableHides = []string{"", "label", "variable"}
+	dashboardModelRefreshes     = []string{"never", "on_dashboard_load", "on_time_range_change"}
+	dashboardModelLinkTypes     = []string{"link", "dashboards"}
+)
+
+func datasourceDashboardModel() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Builds the JSON model of a dashboard from typed blocks. The result can be used as the ` + "`config_json`" + ` of a ` + "`grafana_dashboard`" + ` resource.
+
+This data source doesn't call the Grafana API. Attributes that aren't covered by the blocks, such as panel options, are given as JSON.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/view-dashboard-json-model/)
+`,
+		ReadContext: dataSourceReadDashboardModel,
+		Schema: map[string]*schema.Schema{
+			"uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The UID of the dashboard. Grafana generates one when the dashboard is created if it isn't set.",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The title of the dashboard.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the dashboard.",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The tags of the dashboard.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"timezone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "browser",
+				Description: "The timezone of the dashboard. Either `browser`, `utc` or a timezone name such as `Europe/Paris`.",
+			},
+			"refresh": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The auto-refresh interval of the dashboard (ex: `1m`). The dashboard isn't refreshed automatically if this isn't set.",
+			},
+			"editable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the dashboard can be edited in the UI.",
+			},
+			"graph_tooltip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "default",
+				Description:  fmt.Sprintf("How the crosshair and tooltips are shared between panels. One of %q.", dashboardModelGraphTooltips),
+				ValidateFunc: validation.StringInSlice(dashboardModelGraphTooltips, false),
+			},
+			"time_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "now-6h",
+				Description: "The start of the default time range of the dashboard.",
+			},
+			"time_to": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "now",
+				Description: "The end of the default time range of the dashboard.",
+			},
+			"panel": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The panels of the dashboard that aren't in a row.",
+				Elem:        dashboardModelPanelSchema(),
+			},
+			"row": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The rows of the dashboard. The rows are added after the panels that aren't in a row.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The title of the row.",
+						},
+						"y": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "The vertical position of the row. The panels of the row are positioned with their own `grid_pos` block.",
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"collapsed": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the row is collapsed.",
+						},
+						"panel": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The panels of the row.",
+							Elem:        dashboardModelPanelSchema(),
+						},
+					},
+				},
+			},
+			"variable": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The template variables of the dashboard.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the variable, used as `$name` in queries.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  fmt.Sprintf("The type of the variable. One of %q.", dashboardModelVariableTypes),
+							ValidateFunc: validation.StringInSlice(dashboardModelVariableTypes, false),
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The label of the variable in the UI.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The description of the variable.",
+						},
+						"query": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: "The query of the variable. Its meaning depends on the type: the query for `query`, " +
+								"the comma-separated values for `custom`, the plugin ID for `datasource`, the value for `constant` and `textbox`.",
+						},
+						"datasource_uid":  dashboardModelDatasourceUIDSchema("variable"),
+						"datasource_type": dashboardModelDatasourceTypeSchema("variable"),
+						"current": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The value selected when the dashboard is loaded.",
+						},
+						"multi": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether several values can be selected.",
+						},
+						"include_all": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether an `All` option is added.",
+						},
+						"all_value": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The value of the `All` option. By default, all values are selected.",
+						},
+						"regex": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A regex to filter or capture the values returned by the query.",
+						},
+						"refresh": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "on_dashboard_load",
+							Description:  fmt.Sprintf("When the values of a `query` variable are refreshed. One of %q.", dashboardModelRefreshes),
+							ValidateFunc: validation.StringInSlice(dashboardModelRefreshes, false),
+						},
+						"hide": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "",
+							Description:  fmt.Sprintf("What to hide in the UI. One of %q.", dashboardModelVariableHides),
+							ValidateFunc: validation.StringInSlice(dashboardModelVariableHides, false),
+						},
+					},
+				},
+			},
+			"annotation": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The annotation queries of the dashboard.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the annotation query.",
+						},
+						"datasource_uid":  dashboardModelDatasourceUIDSchema("annotation query"),
+						"datasource_type": dashboardModelDatasourceTypeSchema("annotation query"),
+						"enable": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the annotations are shown.",
+						},
+						"hide": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the toggle of the annotation query is hidden.",
+						},
+						"icon_color": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "red",
+							Description: "The color of the annotations.",
+						},
+						"query_json": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The JSON of the datasource-specific query, set as the `target` of the annotation query.",
+							ValidateFunc: validation.StringIsJSON,
+						},
+					},
+				},
+			},
+			"link": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The links of the dashboard.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The title of the link.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "link",
+							Description:  fmt.Sprintf("The type of the link. One of %q. `dashboards` links to the dashboards with the given tags.", dashboardModelLinkTypes),
+							ValidateFunc: validation.StringInSlice(dashboardModelLinkTypes, false),
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL of a `link` link.",
+						},
+						"tags": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The tags of the dashboards of a `dashboards` link.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"tooltip": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The tooltip of the link.",
+						},
+						"icon": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "external link",
+							Description: "The icon of the link.",
+						},
+						"as_dropdown": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the dashboards of a `dashboards` link are shown in a dropdown.",
+						},
+						"target_blank": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the link opens in a new tab.",
+						},
+						"include_vars": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the values of the variables are added to the link.",
+						},
+						"keep_time": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the time range is added to the link.",
+						},
+					},
+				},
+			},
+			"config_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The complete dashboard model JSON.",
+			},
+		},
+	}
+}
+
+func dashboardModelPanelSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The ID of the panel, unique in the dashboard. Panels without an ID are numbered after the panels with one.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "timeseries",
+				Description: "The type of the panel (ex: `timeseries`, `stat`, `table`).",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The title of the panel.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the panel.",
+			},
+			"datasource_uid":  dashboardModelDatasourceUIDSchema("panel"),
+			"datasource_type": dashboardModelDatasourceTypeSchema("panel"),
+			"grid_pos": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The position and size of the panel. The grid is 24 columns wide.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"x": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							Description:  "The column of the panel, from 0 to 23.",
+							ValidateFunc: validation.IntBetween(0, 23),
+						},
+						"y": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							Description:  "The vertical position of the panel.",
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"w": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "The width of the panel, in columns.",
+							ValidateFunc: validation.IntBetween(1, 24),
+						},
+						"h": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "The height of the panel.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"target": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The queries of the panel.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ref_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The reference of the query, unique in the panel. Queries without one are named A, B, C... in order.",
+						},
+						"datasource_uid":  dashboardModelDatasourceUIDSchema("query"),
+						"datasource_type": dashboardModelDatasourceTypeSchema("query"),
+						"hide": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the query is disabled.",
+						},
+						"query_json": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The JSON of the datasource-specific fields of the query (ex: `{\"expr\": \"up\"}` for Prometheus).",
+							ValidateFunc: validation.StringIsJSON,
+						},
+					},
+				},
+			},
+			"options_json": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The JSON of the options of the panel, which depend on its type.",
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"field_config_json": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The JSON of the field config of the panel (defaults and overrides).",
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"transparent": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the panel has no background.",
+			},
+			"repeat": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of a variable to repeat the panel for each of its values.",
+			},
+		},
+	}
+}
+
+func dashboardModelDatasourceUIDSchema(of string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: fmt.Sprintf("The UID of the data source of the %s.", of),
+	}
+}
+
+func dashboardModelDatasourceTypeSchema(of string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: fmt.Sprintf("The type of the data source of the %s (ex: `prometheus`).", of),
+	}
+}
+
+func dataSourceReadDashboardModel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	model, err := buildDashboardModel(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	configJSON, err := json.Marshal(model)
+	if err != nil {
+		return diag.Errorf("failed to marshal the dashboard model: %s", err)
+	}
+	if _, errs := validateDashboardConfigJSON(string(configJSON), "config_json"); len(errs) > 0 {
+		return diag.Errorf("the dashboard model is invalid: %s", errs[0])
+	}
+
+	d.SetId(hashDashboardConfigJSON(string(configJSON)))
+	d.Set("config_json", string(configJSON))
+
+	return nil
+}
+
+func buildDashboardModel(d *schema.ResourceData) (map[string]interface{}, error) {
+	model := map[string]interface{}{
+		"title":         d.Get("title").(string),
+		"tags":          d.Get("tags").([]interface{}),
+		"timezone":      d.Get("timezone").(string),
+		"editable":      d.Get("editable").(bool),
+		"graphTooltip":  indexOf(dashboardModelGraphTooltips, d.Get("graph_tooltip").(string)),
+		"schemaVersion": dashboardModelSchemaVersion,
+		"time": map[string]interface{}{
+			"from": d.Get("time_from").(string),
+			"to":   d.Get("time_to").(string),
+		},
+	}
+	setIfNotEmpty(model, "uid", d.Get("uid").(string))
+	setIfNotEmpty(model, "description", d.Get("description").(string))
+	setIfNotEmpty(model, "refresh", d.Get("refresh").(string))
+
+	panels, err := buildDashboardModelPanels(d.Get("panel").([]interface{}), d.Get("row").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	model["panels"] = panels
+
+	variables := []interface{}{}
+	names := map[string]bool{}
+	for _, v := range d.Get("variable").([]interface{}) {
+		variable := v.(map[string]interface{})
+		name := variable["name"].(string)
+		if names[name] {
+			return nil, fmt.Errorf("variable %q is defined more than once", name)
+		}
+		names[name] = true
+		variables = append(variables, buildDashboardModelVariable(variable))
+	}
+	model["templating"] = map[string]interface{}{"list": variables}
+
+	annotations := []interface{}{}
+	for _, a := range d.Get("annotation").([]interface{}) {
+		annotation, err := buildDashboardModelAnnotation(a.(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, annotation)
+	}
+	model["annotations"] = map[string]interface{}{"list": annotations}
+
+	links := []interface{}{}
+	for _, l := range d.Get("link").([]interface{}) {
+		links = append(links, buildDashboardModelLink(l.(map[string]interface{})))
+	}
+	model["links"] = links
+
+	return model, nil
+}
+
+// buildDashboardModelPanels returns the `panels` of the dashboard: the panels that aren't in a row, then each row.
+// The panels of a collapsed row are nested in the row, the panels of an expanded row follow it.
+// Panels and rows without an ID are numbered after the highest ID that was set.
+func buildDashboardModelPanels(panelBlocks, rowBlocks []interface{}) ([]interface{}, error) {
+	panels := []interface{}{}
+	ids := map[int]bool{}
+	addID := func(id int, title string) error {
+		if ids[id] {
+			return fmt.Errorf("panel %q: the ID %d is used by another panel", title, id)
+		}
+		ids[id] = true
+		return nil
+	}
+
+	var panelsWithoutID []map[string]interface{}
+	build := func(block map[string]interface{}) (map[string]interface{}, error) {
+		panel, err := buildDashboardModelPanel(block)
+		if err != nil {
+			return nil, err
+		}
+		if id := block["id"].(int); id != 0 {
+			if err := addID(id, block["title"].(string)); err != nil {
+				return nil, err
+			}
+		} else {
+			panelsWithoutID = append(panelsWithoutID, panel)
+		}
+		return panel, nil
+	}
+
+	for _, p := range panelBlocks {
+		panel, err := build(p.(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		panels = append(panels, panel)
+	}
+
+	for _, r := range rowBlocks {
+		rowBlock := r.(map[string]interface{})
+		collapsed := rowBlock["collapsed"].(bool)
+		row := map[string]interface{}{
+			"type":      "row",
+			"title":     rowBlock["title"].(string),
+			"collapsed": collapsed,
+			"gridPos":   map[string]interface{}{"x": 0, "y": rowBlock["y"].(int), "w": 24, "h": 1},
+		}
+		panelsWithoutID = append(panelsWithoutID, row)
+
+		rowPanels := []interface{}{}
+		for _, p := range rowBlock["panel"].([]interface{}) {
+			panel, err := build(p.(map[string]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			rowPanels = append(rowPanels, panel)
+		}
+		if collapsed {
+			row["panels"] = rowPanels
+			panels = append(panels, row)
+		} else {
+			row["panels"] = []interface{}{}
+			panels = append(panels, row)
+			panels = append(panels, rowPanels...)
+		}
+	}
+
+	nextID := 1
+	for id := range ids {
+		if id >= nextID {
+			nextID = id + 1
+		}
+	}
+	for _, panel := range panelsWithoutID {
+		panel["id"] = nextID
+		nextID++
+	}
+
+	return panels, nil
+}
+
+func buildDashboardModelPanel(block map[string]interface{}) (map[string]interface{}, error) {
+	title := block["title"].(string)
+	gridPos := block["grid_pos"].([]interface{})[0].(map[string]interface{})
+	if x, w := gridPos["x"].(int), gridPos["w"].(int); x+w > 24 {
+		return nil, fmt.Errorf("panel %q: the panel is outside of the grid, x + w must be at most 24, got %d", title, x+w)
+	}
+
+	panel := map[string]interface{}{
+		"type":  block["type"].(string),
+		"title": title,
+		"gridPos": map[string]interface{}{
+			"x": gridPos["x"].(int),
+			"y": gridPos["y"].(int),
+			"w": gridPos["w"].(int),
+			"h": gridPos["h"].(int),
+		},
+	}
+	if id := block["id"].(int); id != 0 {
+		panel["id"] = id
+	}
+	setIfNotEmpty(panel, "description", block["description"].(string))
+	setIfNotEmpty(panel, "repeat", block["repeat"].(string))
+	if block["transparent"].(bool) {
+		panel["transparent"] = true
+	}
+	if datasource := buildDashboardModelDatasource(block); datasource != nil {
+		panel["datasource"] = datasource
+	}
+
+	for _, field := range []struct{ attribute, key string }{
+		{"options_json", "options"},
+		{"field_config_json", "fieldConfig"},
+	} {
+		value, err := unmarshalDashboardModelObject(block[field.attribute].(string))
+		if err != nil {
+			return nil, fmt.Errorf("panel %q: %s: %w", title, field.attribute, err)
+		}
+		if value != nil {
+			panel[field.key] = value
+		}
+	}
+
+	targets := []interface{}{}
+	refIDs := map[string]bool{}
+	for i, t := range block["target"].([]interface{}) {
+		targetBlock := t.(map[string]interface{})
+		target, err := unmarshalDashboardModelObject(targetBlock["query_json"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("panel %q: target %d: query_json: %w", title, i, err)
+		}
+		if target == nil {
+			target = map[string]interface{}{}
+		}
+		for _, key := range []string{"refId", "datasource", "hide"} {
+			if _, ok := target[key]; ok {
+				return nil, fmt.Errorf("panel %q: target %d: query_json can't set %q, use the attribute of the target block instead", title, i, key)
+			}
+		}
+
+		refID := targetBlock["ref_id"].(string)
+		if refID == "" {
+			refID = dashboardModelRefID(i)
+		}
+		if refIDs[refID] {
+			return nil, fmt.Errorf("panel %q: the ref ID %q is used by more than one target", title, refID)
+		}
+		refIDs[refID] = true
+
+		target["refId"] = refID
+		if targetBlock["hide"].(bool) {
+			target["hide"] = true
+		}
+		if datasource := buildDashboardModelDatasource(targetBlock); datasource != nil {
+			target["datasource"] = datasource
+		}
+		targets = append(targets, target)
+	}
+	panel["targets"] = targets
+
+	return panel, nil
+}
+
+func buildDashboardModelVariable(block map[string]interface{}) map[string]interface{} {
+	variableType := block["type"].(string)
+	variable := map[string]interface{}{
+		"name":       block["name"].(string),
+		"type":       variableType,
+		"query":      block["query"].(string),
+		"multi":      block["multi"].(bool),
+		"includeAll": block["include_all"].(bool),
+		"hide":       indexOf(dashboardModelVariableHides, block["hide"].(string)),
+	}
+	setIfNotEmpty(variable, "label", block["label"].(string))
+	setIfNotEmpty(variable, "description", block["description"].(string))
+	setIfNotEmpty(variable, "allValue", block["all_value"].(string))
+	setIfNotEmpty(variable, "regex", block["regex"].(string))
+	if datasource := buildDashboardModelDatasource(block); datasource != nil {
+		variable["datasource"] = datasource
+	}
+	if variableType == "query" {
+		variable["refresh"] = indexOf(dashboardModelRefreshes, block["refresh"].(string))
+	}
+	if current := block["current"].(string); current != "" {
+		variable["current"] = map[string]interface{}{"text": current, "value": current}
+	}
+	return variable
+}
+
+func buildDashboardModelAnnotation(block map[string]interface{}) (map[string]interface{}, error) {
+	name := block["name"].(string)
+	annotation := map[string]interface{}{
+		"name":      name,
+		"enable":    block["enable"].(bool),
+		"hide":      block["hide"].(bool),
+		"iconColor": block["icon_color"].(string),
+	}
+	if datasource := buildDashboardModelDatasource(block); datasource != nil {
+		annotation["datasource"] = datasource
+	}
+	target, err := unmarshalDashboardModelObject(block["query_json"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("annotation %q: query_json: %w", name, err)
+	}
+	if target != nil {
+		annotation["target"] = target
+	}
+	return annotation, nil
+}
+
+func buildDashboardModelLink(block map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"title":       block["title"].(string),
+		"type":        block["type"].(string),
+		"url":         block["url"].(string),
+		"tags":        block["tags"].([]interface{}),
+		"tooltip":     block["tooltip"].(string),
+		"icon":        block["icon"].(string),
+		"asDropdown":  block["as_dropdown"].(bool),
+		"targetBlank": block["target_blank"].(bool),
+		"includeVars": block["include_vars"].(bool),
+		"keepTime":    block["keep_time"].(bool),
+	}
+}
+
+// buildDashboardModelDatasource returns the data source reference of a block, or nil if the block doesn't set one
+func buildDashboardModelDatasource(block map[string]interface{}) map[string]interface{} {
+	uid, datasourceType := block["datasource_uid"].(string), block["datasource_type"].(string)
+	if uid == "" && datasourceType == "" {
+		return nil
+	}
+	datasource := map[string]interface{}{}
+	setIfNotEmpty(datasource, "uid", uid)
+	setIfNotEmpty(datasource, "type", datasourceType)
+	return datasource
+}
+
+// unmarshalDashboardModelObject parses a JSON attribute, which must hold an object. It returns nil if the attribute isn't set.
+func unmarshalDashboardModelObject(value string) (map[string]interface{}, error) {
+	if value == "" {
+		return nil, nil
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(value), &object); err != nil {
+		return nil, fmt.Errorf("expected a JSON object: %w", err)
+	}
+	return object, nil
+}
+
+// dashboardModelRefID returns the ref ID that Grafana gives to the i-th query of a panel: A to Z, then AA, AB...
+func dashboardModelRefID(i int) string {
+	if i < 26 {
+		return string(rune('A' + i))
+	}
+	return dashboardModelRefID(i/26-1) + dashboardModelRefID(i%26)
+}
+
+func setIfNotEmpty(m map[string]interface{}, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return 0
+}
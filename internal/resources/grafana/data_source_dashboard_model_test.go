@@ -0,0 +1,239 @@
+package grafana_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDashboardModel_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dashboard models.DashboardFullWithMeta
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             dashboardCheckExists.destroyed(&dashboard, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_dashboard_model/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					dashboardCheckExists.exists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "uid", "dashboard-model"),
+					resource.TestCheckResourceAttrPair("grafana_dashboard.test", "config_json", "data.grafana_dashboard_model.test", "config_json"),
+				),
+			},
+			// Grafana doesn't change the dashboard, so there is no diff
+			{
+				Config:   testutils.TestAccExample(t, "data-sources/grafana_dashboard_model/data-source.tf"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestMockDataSourceDashboardModel(t *testing.T) {
+	testutils.IsUnitTest(t)
+	testutils.NewMockGrafana(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "grafana_dashboard_model" "test" {
+	uid   = "model"
+	title = "Model"
+
+	panel {
+		title = "Without ID"
+		grid_pos {
+			w = 12
+			h = 8
+		}
+		target {
+			datasource_uid = "prometheus"
+			query_json     = jsonencode({ expr = "up" })
+		}
+		target {
+			ref_id = "Errors"
+		}
+	}
+
+	row {
+		title = "Row"
+		y     = 8
+
+		panel {
+			id    = 5
+			title = "With ID"
+			type  = "stat"
+			grid_pos {
+				y = 9
+				w = 24
+				h = 4
+			}
+		}
+	}
+
+	variable {
+		name    = "env"
+		type    = "custom"
+		query   = "dev,prod"
+		current = "dev"
+	}
+
+	annotation {
+		name           = "Deployments"
+		datasource_uid = "loki"
+		query_json     = jsonencode({ expr = "{app=\"deployer\"}" })
+	}
+
+	link {
+		title = "Docs"
+		url   = "https://example.com"
+	}
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_dashboard_model.test", "config_json", `{"annotations":{"list":[{"datasource":{"uid":"loki"},"enable":true,"hide":false,"iconColor":"red","name":"Deployments","target":{"expr":"{app=\"deployer\"}"}}]},`+
+						`"editable":true,"graphTooltip":0,`+
+						`"links":[{"asDropdown":false,"icon":"external link","includeVars":false,"keepTime":false,"tags":[],"targetBlank":false,"title":"Docs","tooltip":"","type":"link","url":"https://example.com"}],`+
+						`"panels":[`+
+						`{"gridPos":{"h":8,"w":12,"x":0,"y":0},"id":6,"targets":[{"datasource":{"uid":"prometheus"},"expr":"up","refId":"A"},{"refId":"Errors"}],"title":"Without ID","type":"timeseries"},`+
+						`{"collapsed":false,"gridPos":{"h":1,"w":24,"x":0,"y":8},"id":7,"panels":[],"title":"Row","type":"row"},`+
+						`{"gridPos":{"h":4,"w":24,"x":0,"y":9},"id":5,"targets":[],"title":"With ID","type":"stat"}],`+
+						`"schemaVersion":39,"tags":[],`+
+						`"templating":{"list":[{"current":{"text":"dev","value":"dev"},"hide":0,"includeAll":false,"multi":false,"name":"env","query":"dev,prod","type":"custom"}]},`+
+						`"time":{"from":"now-6h","to":"now"},"timezone":"browser","title":"Model","uid":"model"}`),
+				),
+			},
+		},
+	})
+}
+
+func TestMockDataSourceDashboardModel_invalid(t *testing.T) {
+	testutils.IsUnitTest(t)
+	testutils.NewMockGrafana(t)
+
+	for _, tc := range []struct {
+		name          string
+		blocks        string
+		expectedError string
+	}{
+		{
+			name: "outside of the grid",
+			blocks: `
+	panel {
+		title = "Panel"
+		grid_pos {
+			x = 12
+			w = 13
+			h = 8
+		}
+	}`,
+			expectedError: `panel "Panel": the panel is outside of the grid`,
+		},
+		{
+			name: "duplicate panel ID",
+			blocks: `
+	panel {
+		id    = 1
+		title = "First"
+		grid_pos {
+			w = 12
+			h = 8
+		}
+	}
+	row {
+		title = "Row"
+		y     = 8
+		panel {
+			id    = 1
+			title = "Second"
+			grid_pos {
+				w = 12
+				h = 8
+			}
+		}
+	}`,
+			expectedError: `panel "Second": the ID 1 is used by another panel`,
+		},
+		{
+			name: "duplicate ref ID",
+			blocks: `
+	panel {
+		title = "Panel"
+		grid_pos {
+			w = 12
+			h = 8
+		}
+		target {
+			ref_id = "B"
+		}
+		target {}
+	}`,
+			expectedError: `panel "Panel": the ref ID "B" is used by more than one target`,
+		},
+		{
+			name: "reserved query field",
+			blocks: `
+	panel {
+		title = "Panel"
+		grid_pos {
+			w = 12
+			h = 8
+		}
+		target {
+			query_json = jsonencode({ refId = "A" })
+		}
+	}`,
+			expectedError: `query_json can't set "refId"`,
+		},
+		{
+			name: "query that isn't an object",
+			blocks: `
+	panel {
+		title = "Panel"
+		grid_pos {
+			w = 12
+			h = 8
+		}
+		target {
+			query_json = jsonencode(["up"])
+		}
+	}`,
+			expectedError: `expected a JSON object`,
+		},
+		{
+			name: "duplicate variable",
+			blocks: `
+	variable {
+		name = "env"
+		type = "constant"
+	}
+	variable {
+		name = "env"
+		type = "textbox"
+	}`,
+			expectedError: `variable "env" is defined more than once`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: `
+data "grafana_dashboard_model" "test" {
+	title = "Invalid"
+` + tc.blocks + `
+}`,
+						ExpectError: regexp.MustCompile(regexp.QuoteMeta(tc.expectedError)),
+					},
+				},
+			})
+		})
+	}
+}
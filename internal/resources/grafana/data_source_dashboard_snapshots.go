@@ -0,0 +1,114 @@
+package grafana
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana-openapi-client-go/client/snapshots"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceDashboardSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Datasource for retrieving the dashboard snapshots of an organization, sorted by name.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/share-dashboards-panels/#publish-a-snapshot)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/snapshot/#list-snapshots)
+`,
+		ReadContext: dataSourceReadDashboardSnapshots,
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"query": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the snapshots whose name contains this string.",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				Description:  "Maximum number of snapshots to return.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"snapshots": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The snapshots.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the snapshot, used in its URL.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the snapshot.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL of the snapshot.",
+						},
+						"external": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the snapshot is saved on the external snapshot server.",
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the snapshot was created, in RFC3339 format.",
+						},
+						"expires": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the snapshot expires, in RFC3339 format. Snapshots that never expire expire in a distant future.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReadDashboardSnapshots(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+
+	query := d.Get("query").(string)
+	limit := int64(d.Get("limit").(int))
+	params := snapshots.NewSearchDashboardSnapshotsParams().WithQuery(&query).WithLimit(&limit).WithContext(ctx)
+	resp, err := client.Snapshots.SearchDashboardSnapshots(params)
+	if err != nil {
+		return diag.Errorf("failed to search dashboard snapshots: %s", err)
+	}
+
+	grafanaURL := meta.(*common.Client).GrafanaAPIURLParsed
+	list := make([]map[string]interface{}, len(resp.GetPayload()))
+	for i, snapshot := range resp.GetPayload() {
+		url := snapshot.ExternalURL
+		if !snapshot.External {
+			url = grafanaURL.JoinPath("dashboard", "snapshot", snapshot.Key).String()
+		}
+		list[i] = map[string]interface{}{
+			"key":      snapshot.Key,
+			"name":     snapshot.Name,
+			"url":      url,
+			"external": snapshot.External,
+			"created":  time.Time(snapshot.Created).Format(time.RFC3339),
+			"expires":  time.Time(snapshot.Expires).Format(time.RFC3339),
+		}
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, "snapshots"))
+	if err := d.Set("snapshots", list); err != nil {
+		return diag.Errorf("error setting snapshots attribute: %s", err)
+	}
+
+	return nil
+}
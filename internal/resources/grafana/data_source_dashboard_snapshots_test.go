@@ -0,0 +1,31 @@
+package grafana_test
+
+import (
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDashboardSnapshots_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_dashboard_snapshots/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_dashboard_snapshots.postmortems", "id", "1:snapshots"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_snapshots.postmortems", "snapshots.#", "1"),
+					resource.TestCheckResourceAttrPair("data.grafana_dashboard_snapshots.postmortems", "snapshots.0.key", "grafana_dashboard_snapshot.postmortem", "key"),
+					resource.TestCheckResourceAttrPair("data.grafana_dashboard_snapshots.postmortems", "snapshots.0.url", "grafana_dashboard_snapshot.postmortem", "url"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_snapshots.postmortems", "snapshots.0.name", "Postmortem: Checkout Latency"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_snapshots.postmortems", "snapshots.0.external", "false"),
+					resource.TestCheckResourceAttrSet("data.grafana_dashboard_snapshots.postmortems", "snapshots.0.created"),
+					resource.TestCheckResourceAttrSet("data.grafana_dashboard_snapshots.postmortems", "snapshots.0.expires"),
+				),
+			},
+		},
+	})
+}
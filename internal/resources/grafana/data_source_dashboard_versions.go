@@ -0,0 +1,108 @@
+package grafana
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana-openapi-client-go/client/dashboard_versions"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceDashboardVersions() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Datasource for retrieving the version history of a dashboard, from the latest version to the oldest.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/manage-version-history/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard_versions/)
+`,
+		ReadContext: dataSourceReadDashboardVersions,
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"dashboard_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The UID of the dashboard.",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				Description:  "Maximum number of versions to return.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The versions of the dashboard, from the latest to the oldest.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version number.",
+						},
+						"parent_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version that this version was saved from.",
+						},
+						"restored_from": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version that was restored to create this version. 0 if the version wasn't created by a restore.",
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the version was created, in RFC3339 format.",
+						},
+						"created_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The login of the user who created the version.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The commit message of the version.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReadDashboardVersions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+	uid := d.Get("dashboard_uid").(string)
+
+	limit := int64(d.Get("limit").(int))
+	params := dashboard_versions.NewGetDashboardVersionsByUIDParams().WithUID(uid).WithLimit(&limit).WithContext(ctx)
+	resp, err := client.DashboardVersions.GetDashboardVersionsByUID(params)
+	if err != nil {
+		return diag.Errorf("failed to get the versions of dashboard %s: %s", uid, err)
+	}
+
+	versions := make([]map[string]interface{}, len(resp.GetPayload()))
+	for i, version := range resp.GetPayload() {
+		versions[i] = map[string]interface{}{
+			"version":        version.Version,
+			"parent_version": version.ParentVersion,
+			"restored_from":  version.RestoredFrom,
+			"created":        time.Time(version.Created).Format(time.RFC3339),
+			"created_by":     version.CreatedBy,
+			"message":        version.Message,
+		}
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, uid))
+	if err := d.Set("versions", versions); err != nil {
+		return diag.Errorf("error setting versions attribute: %s", err)
+	}
+
+	return nil
+}
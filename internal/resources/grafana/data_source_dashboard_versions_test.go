@@ -0,0 +1,34 @@
+package grafana_test
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDashboardVersions_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dashboard models.DashboardFullWithMeta
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             dashboardCheckExists.destroyed(&dashboard, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_dashboard_versions/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					dashboardCheckExists.exists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "id", "1:dashboard-versions"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.version", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.parent_version", "0"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.restored_from", "0"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.message", "Created by Terraform"),
+					resource.TestCheckResourceAttrSet("data.grafana_dashboard_versions.test", "versions.0.created"),
+				),
+			},
+		},
+	})
+}
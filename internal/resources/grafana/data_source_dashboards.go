@@ -4,8 +4,12 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"sort"
+	"strings"
 
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/search"
+	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -29,6 +33,12 @@ Datasource for retrieving all dashboards. Specify list of folder IDs to search i
 				Description: "UIDs of Grafana folders containing dashboards. Specify to filter for dashboards by folder (eg. `[\"General\"]` for General folder), or leave blank to get all dashboards in all folders.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"recursive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Also return the dashboards of the descendant folders of `folder_uids`, with nested folders.",
+			},
 			"limit": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -70,7 +80,7 @@ func dataSourceReadDashboards(ctx context.Context, d *schema.ResourceData, meta
 
 	limit := int64(d.Get("limit").(int))
 	searchType := "dash-db"
-	params := search.NewSearchParams().WithLimit(&limit).WithType(&searchType)
+	params := search.NewSearchParams().WithLimit(&limit).WithType(&searchType).WithContext(ctx)
 
 	id := sha256.New()
 	id.Write([]byte(fmt.Sprintf("%d", limit)))
@@ -79,6 +89,15 @@ func dataSourceReadDashboards(ctx context.Context, d *schema.ResourceData, meta
 	if list, ok := d.GetOk("folder_uids"); ok {
 		params.FolderUIDs = common.ListToStringSlice(list.([]interface{}))
 		id.Write([]byte(fmt.Sprintf("%v", params.FolderUIDs)))
+
+		if d.Get("recursive").(bool) {
+			folderUIDs, err := listDescendantFolderUIDs(ctx, client, params.FolderUIDs)
+			if err != nil {
+				return diag.Errorf("failed to list the descendant folders: %s", err)
+			}
+			params.FolderUIDs = folderUIDs
+			id.Write([]byte("recursive"))
+		}
 	}
 
 	if list, ok := d.GetOk("tags"); ok {
@@ -88,13 +107,13 @@ func dataSourceReadDashboards(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(MakeOrgResourceID(orgID, id))
 
-	resp, err := client.Search.Search(params)
+	hits, err := searchDashboardsInFolders(client, params)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	dashboards := make([]map[string]interface{}, len(resp.GetPayload()))
-	for i, result := range resp.GetPayload() {
+	dashboards := make([]map[string]interface{}, len(hits))
+	for i, result := range hits {
 		dashboards[i] = map[string]interface{}{
 			"title":        result.Title,
 			"uid":          result.UID,
@@ -108,3 +127,60 @@ func dataSourceReadDashboards(ctx context.Context, d *schema.ResourceData, meta
 
 	return nil
 }
+
+// searchDashboardsInFolders searches the dashboards of each folder separately, since the client sends the folder UIDs as a single comma-separated value.
+// The results are sorted by title, like the results of a single search, and truncated to the limit.
+func searchDashboardsInFolders(client *goapi.GrafanaHTTPAPI, params *search.SearchParams) (models.HitList, error) {
+	if len(params.FolderUIDs) <= 1 {
+		resp, err := client.Search.Search(params)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Payload, nil
+	}
+
+	var hits models.HitList
+	for _, uid := range params.FolderUIDs {
+		folderParams := *params
+		folderParams.FolderUIDs = []string{uid}
+		resp, err := client.Search.Search(&folderParams)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, resp.Payload...)
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Title < hits[j].Title })
+	if params.Limit != nil && int64(len(hits)) > *params.Limit {
+		hits = hits[:*params.Limit]
+	}
+	return hits, nil
+}
+
+// listDescendantFolderUIDs returns the given folder UIDs followed by the UIDs of their descendants.
+// The General folder is the root of the organization, all the folders are its descendants.
+func listDescendantFolderUIDs(ctx context.Context, client *goapi.GrafanaHTTPAPI, folderUIDs []string) ([]string, error) {
+	seen := map[string]bool{}
+	result := make([]string, 0, len(folderUIDs))
+	add := func(uid string) {
+		if !seen[uid] {
+			seen[uid] = true
+			result = append(result, uid)
+		}
+	}
+
+	for _, uid := range folderUIDs {
+		add(uid)
+		root := folderTreeItem{uid: uid}
+		if strings.EqualFold(uid, "general") {
+			root.uid = ""
+		}
+		tree, err := listFolderTree(ctx, client, root)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range tree {
+			add(item.uid)
+		}
+	}
+	return result, nil
+}
@@ -3,6 +3,7 @@ package grafana
 import (
 	"context"
 
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -40,9 +41,9 @@ func datasourceDatasourceRead(ctx context.Context, d *schema.ResourceData, meta
 	var err error
 
 	if name, ok := d.GetOk("name"); ok {
-		resp, err = client.Datasources.GetDataSourceByName(name.(string))
+		resp, err = client.Datasources.GetDataSourceByNameWithParams(datasources.NewGetDataSourceByNameParams().WithName(name.(string)).WithContext(ctx))
 	} else if uid, ok := d.GetOk("uid"); ok {
-		resp, err = client.Datasources.GetDataSourceByUID(uid.(string))
+		resp, err = client.Datasources.GetDataSourceByUIDWithParams(datasources.NewGetDataSourceByUIDParams().WithUID(uid.(string)).WithContext(ctx))
 	}
 
 	if err != nil {
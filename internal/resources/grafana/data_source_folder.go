@@ -30,11 +30,11 @@ func datasourceFolder() *schema.Resource {
 	}
 }
 
-func findFolderWithTitle(client *goapi.GrafanaHTTPAPI, title string) (string, error) {
+func findFolderWithTitle(ctx context.Context, client *goapi.GrafanaHTTPAPI, title string) (string, error) {
 	var page int64 = 1
 
 	for {
-		params := search.NewSearchParams().WithType(common.Ref("dash-folder")).WithPage(&page)
+		params := search.NewSearchParams().WithType(common.Ref("dash-folder")).WithPage(&page).WithContext(ctx)
 		resp, err := client.Search.Search(params)
 		if err != nil {
 			return "", err
@@ -56,7 +56,7 @@ func findFolderWithTitle(client *goapi.GrafanaHTTPAPI, title string) (string, er
 
 func dataSourceFolderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
-	uid, err := findFolderWithTitle(client, d.Get("title").(string))
+	uid, err := findFolderWithTitle(ctx, client, d.Get("title").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,193 @@
+package grafana
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/folders"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceFolderTree() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Datasource for retrieving the nested folders of an organization, with their parent folder, depth and path.
+Folders are listed depth first: each folder is followed by its subfolders, sorted by title.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/manage-dashboards/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/folder/)
+`,
+		ReadContext: dataSourceReadFolderTree,
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"root_folder_uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The UID of the folder to list the descendants of. The root folder itself isn't listed. All the folders are listed if not set.",
+			},
+			"folders": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The folders of the tree.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The folder's unique identifier.",
+						},
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The folder ID.",
+						},
+						"title": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The folder title.",
+						},
+						"parent_folder_uid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The UID of the parent folder. Empty for the folders at the root of the organization.",
+						},
+						"depth": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of ancestors of the folder. 0 for the folders at the root of the organization.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The titles of the ancestors of the folder and of the folder, from the root of the organization, separated by `/` (ex: `Teams/Platform/Databases`).",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReadFolderTree(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+
+	root := folderTreeItem{depth: -1}
+	if rootUID := d.Get("root_folder_uid").(string); rootUID != "" {
+		resp, err := client.Folders.GetFolderByUIDWithParams(folders.NewGetFolderByUIDParams().WithFolderUID(rootUID).WithContext(ctx))
+		if err != nil {
+			return diag.Errorf("failed to get folder %s: %s", rootUID, err)
+		}
+		root = folderTreeItemOf(resp.Payload)
+	}
+
+	tree, err := listFolderTree(ctx, client, root)
+	if err != nil {
+		return diag.Errorf("failed to list folders: %s", err)
+	}
+	list := make([]map[string]interface{}, len(tree))
+	for i, item := range tree {
+		list[i] = map[string]interface{}{
+			"uid":               item.uid,
+			"id":                item.id,
+			"title":             item.title,
+			"parent_folder_uid": item.parentUID,
+			"depth":             item.depth,
+			"path":              item.path,
+		}
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, "folder_tree"))
+	if err := d.Set("folders", list); err != nil {
+		return diag.Errorf("error setting folders attribute: %s", err)
+	}
+
+	return nil
+}
+
+// folderTreeItem is a folder with its position in the folder tree
+type folderTreeItem struct {
+	uid, title, parentUID string
+	id                    int64
+	// depth is the number of ancestors of the folder, -1 for the root of the organization
+	depth int
+	// path is the titles of the ancestors of the folder and of the folder, separated by slashes
+	path string
+}
+
+// folderTreeItemOf returns the position of a folder, from the parents returned by the API
+func folderTreeItemOf(folder *models.Folder) folderTreeItem {
+	titles := make([]string, 0, len(folder.Parents)+1)
+	for _, parent := range folder.Parents {
+		titles = append(titles, parent.Title)
+	}
+	titles = append(titles, folder.Title)
+	return folderTreeItem{
+		uid:       folder.UID,
+		id:        folder.ID,
+		title:     folder.Title,
+		parentUID: folder.ParentUID,
+		depth:     len(folder.Parents),
+		path:      strings.Join(titles, "/"),
+	}
+}
+
+// listFolderTree returns the descendants of a folder, depth first, with the subfolders of each folder sorted by title.
+// The folders returned by the API are only kept if they belong to the listed parent, so that instances without nested folders,
+// which return all the folders whatever the parent, are listed as a flat tree.
+func listFolderTree(ctx context.Context, client *goapi.GrafanaHTTPAPI, root folderTreeItem) ([]folderTreeItem, error) {
+	var tree []folderTreeItem
+	visited := map[string]bool{root.uid: true}
+
+	var walk func(parent folderTreeItem) error
+	walk = func(parent folderTreeItem) error {
+		var children []*models.FolderSearchHit
+		var page int64 = 1
+		limit := int64(1000)
+		for {
+			params := folders.NewGetFoldersParams().WithParentUID(&parent.uid).WithLimit(&limit).WithPage(&page).WithContext(ctx)
+			if parent.uid == "" {
+				params.ParentUID = nil
+			}
+			resp, err := client.Folders.GetFolders(params)
+			if err != nil {
+				return err
+			}
+			for _, hit := range resp.Payload {
+				if hit.ParentUID == parent.uid && !visited[hit.UID] {
+					visited[hit.UID] = true
+					children = append(children, hit)
+				}
+			}
+			if int64(len(resp.Payload)) < limit {
+				break
+			}
+			page++
+		}
+		sort.SliceStable(children, func(i, j int) bool { return children[i].Title < children[j].Title })
+
+		for _, child := range children {
+			item := folderTreeItem{
+				uid:       child.UID,
+				id:        child.ID,
+				title:     child.Title,
+				parentUID: parent.uid,
+				depth:     parent.depth + 1,
+				path:      child.Title,
+			}
+			if parent.path != "" {
+				item.path = parent.path + "/" + child.Title
+			}
+			tree = append(tree, item)
+			if err := walk(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return tree, walk(root)
+}
@@ -0,0 +1,83 @@
+package grafana_test
+
+import (
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceFolderTree_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=10.3.0")
+
+	// Do not use parallel tests here because the folder titles must be unique in the default org
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_folder_tree/data-source.tf"),
+				Check:  testFolderTreeCheck(),
+			},
+		},
+	})
+}
+
+func TestMockDataSourceFolderTree_basic(t *testing.T) {
+	testutils.IsUnitTest(t)
+	testutils.NewMockGrafana(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_folder_tree/data-source.tf") + `
+resource "grafana_folder" "other" {
+  title = "Other"
+}
+
+resource "grafana_folder" "apps" {
+  title             = "Apps"
+  parent_folder_uid = grafana_folder.teams.uid
+}
+
+data "grafana_folder_tree" "all" {
+  depends_on = [grafana_folder.databases, grafana_folder.other, grafana_folder.apps]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testFolderTreeCheck(),
+					// Depth first, the subfolders are sorted by title
+					resource.TestCheckResourceAttr("data.grafana_folder_tree.all", "folders.#", "5"),
+					resource.TestCheckResourceAttr("data.grafana_folder_tree.all", "folders.0.path", "Other"),
+					resource.TestCheckResourceAttr("data.grafana_folder_tree.all", "folders.1.path", "Teams"),
+					resource.TestCheckResourceAttr("data.grafana_folder_tree.all", "folders.1.depth", "0"),
+					resource.TestCheckResourceAttr("data.grafana_folder_tree.all", "folders.1.parent_folder_uid", ""),
+					resource.TestCheckResourceAttr("data.grafana_folder_tree.all", "folders.2.path", "Teams/Apps"),
+					resource.TestCheckResourceAttr("data.grafana_folder_tree.all", "folders.3.path", "Teams/Platform"),
+					resource.TestCheckResourceAttr("data.grafana_folder_tree.all", "folders.4.path", "Teams/Platform/Databases"),
+					resource.TestCheckResourceAttr("data.grafana_folder_tree.all", "folders.4.depth", "2"),
+				),
+			},
+		},
+	})
+}
+
+// testFolderTreeCheck checks the data sources of the grafana_folder_tree example
+func testFolderTreeCheck() resource.TestCheckFunc {
+	return resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttr("data.grafana_folder_tree.teams", "folders.#", "2"),
+		resource.TestCheckResourceAttrPair("data.grafana_folder_tree.teams", "folders.0.uid", "grafana_folder.platform", "uid"),
+		resource.TestCheckResourceAttrPair("data.grafana_folder_tree.teams", "folders.0.parent_folder_uid", "grafana_folder.teams", "uid"),
+		resource.TestCheckResourceAttr("data.grafana_folder_tree.teams", "folders.0.title", "Platform"),
+		resource.TestCheckResourceAttr("data.grafana_folder_tree.teams", "folders.0.depth", "1"),
+		resource.TestCheckResourceAttr("data.grafana_folder_tree.teams", "folders.0.path", "Teams/Platform"),
+		resource.TestCheckResourceAttrPair("data.grafana_folder_tree.teams", "folders.1.uid", "grafana_folder.databases", "uid"),
+		resource.TestCheckResourceAttrPair("data.grafana_folder_tree.teams", "folders.1.parent_folder_uid", "grafana_folder.platform", "uid"),
+		resource.TestCheckResourceAttr("data.grafana_folder_tree.teams", "folders.1.depth", "2"),
+		resource.TestCheckResourceAttr("data.grafana_folder_tree.teams", "folders.1.path", "Teams/Platform/Databases"),
+
+		resource.TestCheckResourceAttr("data.grafana_dashboards.teams", "dashboards.#", "1"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.teams", "dashboards.0.title", "Database Overview"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.teams", "dashboards.0.folder_title", "Databases"),
+	)
+}
@@ -65,7 +65,7 @@ func readFolders(ctx context.Context, d *schema.ResourceData, meta interface{})
 	var page int64 = 1
 	searchType := "dash-folder"
 	for {
-		params := search.NewSearchParams().WithType(&searchType).WithPage(&page)
+		params := search.NewSearchParams().WithType(&searchType).WithPage(&page).WithContext(ctx)
 		resp, err := client.Search.Search(params)
 		if err != nil {
 			return diag.FromErr(err)
@@ -0,0 +1,73 @@
+package grafana
+
+import (
+	"context"
+
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceInstanceInfo() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Information about the Grafana instance that the provider is configured for. This can be used to enable parts of a configuration depending on the version or edition of Grafana.
+
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/other/#health-api)
+`,
+		ReadContext: dataSourceInstanceInfoRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full version of the Grafana instance, as reported by the instance.",
+			},
+			"major_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The major version of the Grafana instance.",
+			},
+			"minor_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The minor version of the Grafana instance.",
+			},
+			"edition": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The edition of the Grafana instance. One of `oss`, `enterprise` or `cloud`.",
+			},
+			"enterprise_features": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether Grafana Enterprise features are available on the instance (Grafana Enterprise or Grafana Cloud).",
+			},
+			"feature_toggles": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeBool},
+				Description: "The feature toggles of the Grafana instance, mapped to whether they are enabled.",
+			},
+		},
+	}
+}
+
+func dataSourceInstanceInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client)
+	info, err := client.GrafanaInstanceInfo(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(client.GrafanaAPIURL)
+	d.Set("version", info.VersionString)
+	if info.Version != nil {
+		d.Set("major_version", info.Version.Major())
+		d.Set("minor_version", info.Version.Minor())
+	}
+	d.Set("edition", string(info.Edition))
+	d.Set("enterprise_features", info.HasEnterpriseFeatures())
+	d.Set("feature_toggles", info.FeatureToggles)
+
+	return nil
+}
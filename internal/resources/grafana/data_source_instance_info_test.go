@@ -0,0 +1,39 @@
+package grafana_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceInstanceInfo_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr("data.grafana_instance_info.current", "edition", "oss"),
+		resource.TestCheckResourceAttr("data.grafana_instance_info.current", "enterprise_features", "false"),
+		resource.TestCheckResourceAttrSet("data.grafana_instance_info.current", "major_version"),
+		resource.TestCheckResourceAttrSet("data.grafana_instance_info.current", "minor_version"),
+		resource.TestCheckResourceAttrWith("data.grafana_instance_info.current", "version", func(value string) error {
+			expected := strings.TrimPrefix(os.Getenv("GRAFANA_VERSION"), "v")
+			if !strings.HasPrefix(value, expected) {
+				return fmt.Errorf("expected version %q, got %q", expected, value)
+			}
+			return nil
+		}),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_instance_info/data-source.tf"),
+				Check:  resource.ComposeTestCheckFunc(checks...),
+			},
+		},
+	})
+}
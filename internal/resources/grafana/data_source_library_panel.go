@@ -2,8 +2,10 @@ package grafana
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
 
-	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/grafana/grafana-openapi-client-go/client/library_elements"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -12,7 +14,7 @@ func datasourceLibraryPanel() *schema.Resource {
 	return &schema.Resource{
 		Description: "Data source for retrieving a single library panel by name or uid.",
 		ReadContext: dataSourceLibraryPanelRead,
-		Schema: common.CloneResourceSchemaForDatasource(resourceLibraryPanel().Schema, map[string]*schema.Schema{
+		Schema: map[string]*schema.Schema{
 			"org_id": orgIDAttribute(),
 			"name": {
 				Type:        schema.TypeString,
@@ -24,7 +26,65 @@ func datasourceLibraryPanel() *schema.Resource {
 				Optional:    true,
 				Description: "The unique identifier (UID) of the library panel.",
 			},
-		}),
+			"panel_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The numeric ID of the library panel computed by Grafana.",
+			},
+			"folder_uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID (UID) of the folder containing the library panel.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the library panel.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the library panel (eg. text).",
+			},
+			"model_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON model for the library panel.",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version of the library panel.",
+			},
+			"folder_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the folder containing the library panel.",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Timestamp when the library panel was created.",
+			},
+			"updated": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Timestamp when the library panel was last modified.",
+			},
+			"dashboard_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Numerical IDs of Grafana dashboards containing the library panel.",
+				Deprecated:  "Use `dashboard_uids` instead. Numerical dashboard IDs are deprecated in Grafana.",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"dashboard_uids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "UIDs of the Grafana dashboards containing the library panel.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
 	}
 }
 
@@ -40,7 +100,7 @@ func dataSourceLibraryPanelRead(ctx context.Context, d *schema.ResourceData, met
 			return diag.Errorf("either name or uid must be specified")
 		}
 
-		resp, err := client.LibraryElements.GetLibraryElementByName(name)
+		resp, err := client.LibraryElements.GetLibraryElementByNameWithParams(library_elements.NewGetLibraryElementByNameParams().WithLibraryElementName(name).WithContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -51,7 +111,37 @@ func dataSourceLibraryPanelRead(ctx context.Context, d *schema.ResourceData, met
 		uid = result[0].UID
 	}
 
+	panel, connections, err := getLibraryPanelWithConnections(ctx, client, uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	modelJSON, err := json.Marshal(panel.Model)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	dashboardIDs := make([]int64, len(connections))
+	dashboardUIDs := make([]string, len(connections))
+	for i, connection := range connections {
+		dashboardIDs[i] = connection.ConnectionID
+		dashboardUIDs[i] = connection.ConnectionUID
+	}
+
 	d.SetId(MakeOrgResourceID(orgID, uid))
+	d.Set("uid", panel.UID)
+	d.Set("panel_id", panel.ID)
+	d.Set("org_id", strconv.FormatInt(panel.OrgID, 10))
+	d.Set("folder_uid", panel.Meta.FolderUID)
+	d.Set("description", panel.Description)
+	d.Set("type", panel.Type)
+	d.Set("name", panel.Name)
+	d.Set("model_json", libraryPanelModelJSON.StateFunc(string(modelJSON)))
+	d.Set("version", panel.Version)
+	d.Set("folder_name", panel.Meta.FolderName)
+	d.Set("created", panel.Meta.Created.String())
+	d.Set("updated", panel.Meta.Updated.String())
+	d.Set("dashboard_ids", dashboardIDs)
+	d.Set("dashboard_uids", dashboardUIDs)
 
-	return readLibraryPanel(ctx, d, meta)
+	return nil
 }
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/grafana/grafana-openapi-client-go/client/orgs"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -58,16 +60,16 @@ func dataSourceOrganizationRead(ctx context.Context, d *schema.ResourceData, met
 	}
 	name := d.Get("name").(string)
 
-	org, err := client.Orgs.GetOrgByName(name)
+	org, err := client.Orgs.GetOrgByNameWithParams(orgs.NewGetOrgByNameParams().WithOrgName(name).WithContext(ctx))
 
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "status: 404") {
+		if common.IsNotFoundError(err) {
 			return diag.Errorf("no organization with name %q", name)
 		}
 		return diag.FromErr(err)
 	}
 
-	orgUsers, err := client.Orgs.GetOrgUsers(org.Payload.ID)
+	orgUsers, err := client.Orgs.GetOrgUsersWithParams(orgs.NewGetOrgUsersParams().WithOrgID(org.Payload.ID).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
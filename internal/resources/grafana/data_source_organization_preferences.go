@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/grafana/grafana-openapi-client-go/client/org_preferences"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -24,7 +25,7 @@ func datasourceOrganizationPreferences() *schema.Resource {
 
 func dataSourceOrganizationPreferencesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
-	resp, err := client.OrgPreferences.GetOrgPreferences()
+	resp, err := client.OrgPreferences.GetOrgPreferencesWithParams(org_preferences.NewGetOrgPreferencesParams().WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -31,7 +31,7 @@ func datasourceRole() *schema.Resource {
 
 func dataSourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
-	resp, err := client.AccessControl.ListRoles(access_control.NewListRolesParams().WithIncludeHidden(common.Ref(true)), nil)
+	resp, err := client.AccessControl.ListRoles(access_control.NewListRolesParams().WithIncludeHidden(common.Ref(true)).WithContext(ctx), nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -40,7 +40,7 @@ func dataSourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interf
 	for _, r := range resp.Payload {
 		if r.Name == name {
 			d.SetId(MakeOrgResourceID(orgID, r.UID))
-			return readRoleFromUID(client, r.UID, d)
+			return readRoleFromUID(ctx, client, r.UID, d)
 		}
 	}
 
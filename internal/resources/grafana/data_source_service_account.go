@@ -33,7 +33,7 @@ func datasourceServiceAccount() *schema.Resource {
 func datasourceServiceAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 	name := d.Get("name").(string)
-	sa, err := findServiceAccountByName(client, name)
+	sa, err := findServiceAccountByName(ctx, client, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -41,10 +41,10 @@ func datasourceServiceAccountRead(ctx context.Context, d *schema.ResourceData, m
 	return ReadServiceAccount(ctx, d, meta)
 }
 
-func findServiceAccountByName(client *client.GrafanaHTTPAPI, name string) (*models.ServiceAccountDTO, error) {
+func findServiceAccountByName(ctx context.Context, client *client.GrafanaHTTPAPI, name string) (*models.ServiceAccountDTO, error) {
 	var page int64 = 0
 	for {
-		params := service_accounts.NewSearchOrgServiceAccountsWithPagingParams().WithPage(&page)
+		params := service_accounts.NewSearchOrgServiceAccountsWithPagingParams().WithPage(&page).WithContext(ctx)
 		resp, err := client.ServiceAccounts.SearchOrgServiceAccountsWithPaging(params)
 		if err != nil {
 			return nil, err
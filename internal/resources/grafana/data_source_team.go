@@ -38,7 +38,7 @@ func dataSourceTeamRead(ctx context.Context, d *schema.ResourceData, meta interf
 	client, _ := OAPIClientFromNewOrgResource(meta, d)
 	name := d.Get("name").(string)
 
-	params := teams.NewSearchTeamsParams().WithName(&name)
+	params := teams.NewSearchTeamsParams().WithName(&name).WithContext(ctx)
 	resp, err := client.Teams.SearchTeams(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -47,7 +47,7 @@ func dataSourceTeamRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 	for _, r := range searchTeam.Teams {
 		if r.Name == name {
-			return readTeamFromID(client, r.ID, d, d.Get("read_team_sync").(bool))
+			return readTeamFromID(ctx, client, r.ID, d, d.Get("read_team_sync").(bool))
 		}
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/grafana/grafana-openapi-client-go/client/users"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -66,9 +67,9 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 
 	if id := d.Get("user_id").(int); id >= 0 {
-		resp, err = client.Users.GetUserByID(int64(id))
+		resp, err = client.Users.GetUserByIDWithParams(users.NewGetUserByIDParams().WithUserID(int64(id)).WithContext(ctx))
 	} else if emailOrLogin != "" {
-		resp, err = client.Users.GetUserByLoginOrEmail(emailOrLogin)
+		resp, err = client.Users.GetUserByLoginOrEmailWithParams(users.NewGetUserByLoginOrEmailParams().WithLoginOrEmail(emailOrLogin).WithContext(ctx))
 	} else {
 		err = fmt.Errorf("must specify one of user_id, email, or login")
 	}
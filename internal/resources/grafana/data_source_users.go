@@ -69,7 +69,7 @@ func readUsers(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	allUsers, err := getAllUsers(client)
+	allUsers, err := getAllUsers(ctx, client)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -93,12 +93,12 @@ func flattenUsers(items []*models.UserSearchHitDTO) []interface{} {
 	return userItems
 }
 
-func getAllUsers(client *goapi.GrafanaHTTPAPI) ([]*models.UserSearchHitDTO, error) {
+func getAllUsers(ctx context.Context, client *goapi.GrafanaHTTPAPI) ([]*models.UserSearchHitDTO, error) {
 	allUsers := []*models.UserSearchHitDTO{}
 	var page int64 = 1
-	params := users.NewSearchUsersParams().WithDefaults()
+	params := users.NewSearchUsersParams().WithDefaults().WithContext(ctx)
 	for {
-		resp, err := client.Users.SearchUsers(params.WithPage(&page), nil)
+		resp, err := client.Users.SearchUsers(params.WithPage(&page).WithContext(ctx), nil)
 		if err != nil {
 			return nil, err
 		}
@@ -32,23 +32,24 @@ func orgIDAttribute() *schema.Schema {
 	}
 }
 
+// orgResourceID is the <orgID>:<resourceID> format of the IDs of org-scoped resources
+var orgResourceID = common.NewResourceID(common.IntIDField("orgID"), common.StringIDField("resourceID"))
+
 // MakeOrgResourceID creates a resource ID for an org-scoped resource
+// Separators in the resource ID are escaped, like in the IDs made from a common.ResourceID
 func MakeOrgResourceID(orgID int64, resourceID interface{}) string {
-	return fmt.Sprintf("%d:%s", orgID, fmt.Sprint(resourceID))
+	return orgResourceID.Make(orgID, fmt.Sprint(resourceID))
 }
 
 // SplitOrgResourceID splits into two parts (org ID and resource ID) the ID of an org-scoped resource
+// The resource ID is unescaped if it's a single field. If it contains unescaped separators (legacy IDs, or IDs with more fields),
+// it's returned as is, to be parsed by the caller.
 func SplitOrgResourceID(id string) (int64, string) {
-	if strings.ContainsRune(id, ':') {
-		parts := strings.SplitN(id, ":", 2)
-		orgID, err := strconv.ParseInt(parts[0], 10, 64)
-		if err != nil {
-			return 0, id
-		}
-		return orgID, parts[1]
+	parts, err := orgResourceID.Split(id)
+	if err != nil {
+		return 0, id
 	}
-
-	return 0, id
+	return parts[0].(int64), parts[1].(string)
 }
 
 // SplitServiceAccountID is like SplitOrgResourceID but for service accounts
@@ -74,7 +75,7 @@ func OAPIClientFromExistingOrgResource(meta interface{}, id string) (*goapi.Graf
 	if orgID == 0 {
 		orgID = client.OrgID()
 	} else if orgID > 0 {
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 	return client, orgID, restOfID
 }
@@ -87,14 +88,14 @@ func OAPIClientFromNewOrgResource(meta interface{}, d *schema.ResourceData) (*go
 	if orgID == 0 {
 		orgID = client.OrgID()
 	} else if orgID > 0 {
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 	return client, orgID
 }
 
 func OAPIGlobalClient(meta interface{}) (*goapi.GrafanaHTTPAPI, error) {
 	metaClient := meta.(*common.Client)
-	client := meta.(*common.Client).GrafanaAPI.Clone().WithOrgID(0)
+	client := common.GrafanaAPIWithOrgID(meta.(*common.Client).GrafanaAPI, 0)
 	if metaClient.GrafanaAPIConfig.APIKey != "" {
 		return client, fmt.Errorf("global scope resources cannot be managed with an API key. Use basic auth instead")
 	}
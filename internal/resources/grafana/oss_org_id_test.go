@@ -11,6 +11,7 @@ import (
 	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/resources/grafana"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -95,3 +96,35 @@ func orgScopedTest(t *testing.T) int64 {
 
 	return *org.Payload.OrgID
 }
+
+func TestOrgResourceID(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	for _, resourceID := range []string{"uid", "", "team: alerts", `back\slash`, `both\:`, `\\`, "a:b:c"} {
+		id := grafana.MakeOrgResourceID(2, resourceID)
+		orgID, gotResourceID := grafana.SplitOrgResourceID(id)
+		if orgID != 2 || gotResourceID != resourceID {
+			t.Errorf("MakeOrgResourceID(2, %q) = %q, split into %d and %q", resourceID, id, orgID, gotResourceID)
+		}
+	}
+
+	for _, tc := range []struct {
+		id         string
+		orgID      int64
+		resourceID string
+	}{
+		// Legacy IDs, made before separators were escaped
+		{id: "1:uid", orgID: 1, resourceID: "uid"},
+		{id: "1:team: alerts", orgID: 1, resourceID: "team: alerts"},
+		// IDs with more fields are returned as is, to be parsed by the caller
+		{id: `1:folder:team\: alerts`, orgID: 1, resourceID: `folder:team\: alerts`},
+		// IDs without org
+		{id: "uid", orgID: 0, resourceID: "uid"},
+		{id: "stack:uid", orgID: 0, resourceID: "stack:uid"},
+	} {
+		orgID, resourceID := grafana.SplitOrgResourceID(tc.id)
+		if orgID != tc.orgID || resourceID != tc.resourceID {
+			t.Errorf("SplitOrgResourceID(%q) = %d, %q, expected %d, %q", tc.id, orgID, resourceID, tc.orgID, tc.resourceID)
+		}
+	}
+}
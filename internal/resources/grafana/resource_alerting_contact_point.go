@@ -54,10 +54,10 @@ Manages Grafana Alerting contact points.
 
 This resource requires Grafana 9.1.0 or later.
 `,
-		CreateContext: common.WithAlertingMutex[schema.CreateContextFunc](updateContactPoint),
+		CreateContext: common.WithAlertingMutex[schema.CreateContextFunc](alertingOrgLockKeys, updateContactPoint),
 		ReadContext:   readContactPoint,
-		UpdateContext: common.WithAlertingMutex[schema.UpdateContextFunc](updateContactPoint),
-		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](deleteContactPoint),
+		UpdateContext: common.WithAlertingMutex[schema.UpdateContextFunc](alertingOrgLockKeys, updateContactPoint),
+		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](alertingOrgLockKeys, deleteContactPoint),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -108,14 +108,14 @@ This resource requires Grafana 9.1.0 or later.
 // TODO: Fix lister
 // .WithLister(listerFunction(listContactPoints))
 // func listContactPoints(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
-// 	orgIDs, err := data.OrgIDs(client)
+// 	orgIDs, err := data.OrgIDs(ctx, client)
 // 	if err != nil {
 // 		return nil, err
 // 	}
 
 // 	idMap := map[string]bool{}
 // 	for _, orgID := range orgIDs {
-// 		client = client.Clone().WithOrgID(orgID)
+// 		client = common.GrafanaAPIWithOrgID(client, orgID)
 
 // 		resp, err := client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParams())
 // 		if err != nil {
@@ -140,7 +140,7 @@ func readContactPoint(ctx context.Context, data *schema.ResourceData, meta inter
 
 	// First, try to fetch the contact point by name.
 	// If that fails, try to fetch it by the UID of its notifiers.
-	resp, err := client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParams().WithName(&name))
+	resp, err := client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParams().WithName(&name).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -174,7 +174,7 @@ func updateContactPoint(ctx context.Context, data *schema.ResourceData, meta int
 		var uid string
 		if uid = p.tfState["uid"].(string); uid != "" {
 			// If the contact point already has a UID, update it.
-			params := provisioning.NewPutContactpointParams().WithUID(uid).WithBody(p.gfState)
+			params := provisioning.NewPutContactpointParams().WithUID(uid).WithBody(p.gfState).WithContext(ctx)
 			if data.Get("disable_provenance").(bool) {
 				params.SetXDisableProvenance(&provenanceDisabled)
 			}
@@ -186,7 +186,7 @@ func updateContactPoint(ctx context.Context, data *schema.ResourceData, meta int
 			// Retry if the API returns 500 because it may be that the alertmanager is not ready in the org yet.
 			// The alertmanager is provisioned asynchronously when the org is created.
 			err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
-				params := provisioning.NewPostContactpointsParams().WithBody(p.gfState)
+				params := provisioning.NewPostContactpointsParams().WithBody(p.gfState).WithContext(ctx)
 				if data.Get("disable_provenance").(bool) {
 					params.SetXDisableProvenance(&provenanceDisabled)
 				}
@@ -216,7 +216,7 @@ func updateContactPoint(ctx context.Context, data *schema.ResourceData, meta int
 		}
 		uid := p.tfState["uid"].(string)
 		// If the contact point is not in the proposed state, delete it.
-		if _, err := client.Provisioning.DeleteContactpoints(uid); err != nil {
+		if _, err := client.Provisioning.DeleteContactpointsWithParams(provisioning.NewDeleteContactpointsParams().WithUID(uid).WithContext(ctx)); err != nil {
 			return diag.Errorf("failed to remove contact point notifier with UID %s from contact point %s: %v", uid, data.Id(), err)
 		}
 		continue
@@ -229,13 +229,13 @@ func updateContactPoint(ctx context.Context, data *schema.ResourceData, meta int
 func deleteContactPoint(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, name := OAPIClientFromExistingOrgResource(meta, data.Id())
 
-	resp, err := client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParams().WithName(&name))
+	resp, err := client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParams().WithName(&name).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("contact point", data, err); shouldReturn {
 		return err
 	}
 
 	for _, cp := range resp.Payload {
-		if _, err := client.Provisioning.DeleteContactpoints(cp.UID); err != nil {
+		if _, err := client.Provisioning.DeleteContactpointsWithParams(provisioning.NewDeleteContactpointsParams().WithUID(cp.UID).WithContext(ctx)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
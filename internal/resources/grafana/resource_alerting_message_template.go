@@ -26,10 +26,10 @@ Manages Grafana Alerting message templates.
 
 This resource requires Grafana 9.1.0 or later.
 `,
-		CreateContext: common.WithAlertingMutex[schema.CreateContextFunc](putMessageTemplate),
+		CreateContext: common.WithAlertingMutex[schema.CreateContextFunc](alertingOrgLockKeys, putMessageTemplate),
 		ReadContext:   readMessageTemplate,
-		UpdateContext: common.WithAlertingMutex[schema.UpdateContextFunc](putMessageTemplate),
-		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](deleteMessageTemplate),
+		UpdateContext: common.WithAlertingMutex[schema.UpdateContextFunc](alertingOrgLockKeys, putMessageTemplate),
+		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](alertingOrgLockKeys, deleteMessageTemplate),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -69,16 +69,16 @@ This resource requires Grafana 9.1.0 or later.
 }
 
 func listMessageTemplate(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
-	orgIDs, err := data.OrgIDs(client)
+	orgIDs, err := data.OrgIDs(ctx, client)
 	if err != nil {
 		return nil, err
 	}
 
 	var ids []string
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
-		resp, err := client.Provisioning.GetTemplates()
+		resp, err := client.Provisioning.GetTemplatesWithParams(provisioning.NewGetTemplatesParams().WithContext(ctx))
 		if err != nil {
 			return nil, err
 		}
@@ -94,7 +94,7 @@ func listMessageTemplate(ctx context.Context, client *goapi.GrafanaHTTPAPI, data
 func readMessageTemplate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID, name := OAPIClientFromExistingOrgResource(meta, data.Id())
 
-	resp, err := client.Provisioning.GetTemplate(name)
+	resp, err := client.Provisioning.GetTemplateWithParams(provisioning.NewGetTemplateParams().WithName(name).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("message template", data, err); shouldReturn {
 		return err
 	}
@@ -120,7 +120,7 @@ func putMessageTemplate(ctx context.Context, data *schema.ResourceData, meta int
 			WithName(name).
 			WithBody(&models.NotificationTemplateContent{
 				Template: content,
-			})
+			}).WithContext(ctx)
 		if v, ok := data.GetOk("disable_provenance"); ok && v.(bool) {
 			params.SetXDisableProvenance(&provenanceDisabled)
 		}
@@ -143,7 +143,7 @@ func putMessageTemplate(ctx context.Context, data *schema.ResourceData, meta int
 func deleteMessageTemplate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, name := OAPIClientFromExistingOrgResource(meta, data.Id())
 
-	_, err := client.Provisioning.DeleteTemplate(name)
+	_, err := client.Provisioning.DeleteTemplateWithParams(provisioning.NewDeleteTemplateParams().WithName(name).WithContext(ctx))
 	diag, _ := common.CheckReadError("message template", data, err)
 	return diag
 }
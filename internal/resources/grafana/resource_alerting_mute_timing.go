@@ -25,10 +25,10 @@ Manages Grafana Alerting mute timings.
 This resource requires Grafana 9.1.0 or later.
 `,
 
-		CreateContext: common.WithAlertingMutex[schema.CreateContextFunc](createMuteTiming),
+		CreateContext: common.WithAlertingMutex[schema.CreateContextFunc](alertingOrgLockKeys, createMuteTiming),
 		ReadContext:   readMuteTiming,
-		UpdateContext: common.WithAlertingMutex[schema.UpdateContextFunc](updateMuteTiming),
-		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](deleteMuteTiming),
+		UpdateContext: common.WithAlertingMutex[schema.UpdateContextFunc](alertingOrgLockKeys, updateMuteTiming),
+		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](alertingOrgLockKeys, deleteMuteTiming),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -133,16 +133,16 @@ This resource requires Grafana 9.1.0 or later.
 }
 
 func listMuteTimings(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
-	orgIDs, err := data.OrgIDs(client)
+	orgIDs, err := data.OrgIDs(ctx, client)
 	if err != nil {
 		return nil, err
 	}
 
 	var ids []string
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
-		resp, err := client.Provisioning.GetMuteTimings()
+		resp, err := client.Provisioning.GetMuteTimingsWithParams(provisioning.NewGetMuteTimingsParams().WithContext(ctx))
 		if err != nil {
 			return nil, err
 		}
@@ -158,7 +158,7 @@ func listMuteTimings(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *Li
 func readMuteTiming(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID, name := OAPIClientFromExistingOrgResource(meta, data.Id())
 
-	resp, err := client.Provisioning.GetMuteTiming(name)
+	resp, err := client.Provisioning.GetMuteTimingWithParams(provisioning.NewGetMuteTimingParams().WithName(name).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("mute timing", data, err); shouldReturn {
 		return err
 	}
@@ -179,7 +179,7 @@ func createMuteTiming(ctx context.Context, data *schema.ResourceData, meta inter
 		WithBody(&models.MuteTimeInterval{
 			Name:          data.Get("name").(string),
 			TimeIntervals: unpackIntervals(intervals),
-		})
+		}).WithContext(ctx)
 
 	if v, ok := data.GetOk("disable_provenance"); ok && v.(bool) {
 		params.SetXDisableProvenance(&provenanceDisabled)
@@ -202,7 +202,7 @@ func updateMuteTiming(ctx context.Context, data *schema.ResourceData, meta inter
 		WithBody(&models.MuteTimeInterval{
 			Name:          name,
 			TimeIntervals: unpackIntervals(intervals),
-		})
+		}).WithContext(ctx)
 
 	if v, ok := data.GetOk("disable_provenance"); ok && v.(bool) {
 		params.SetXDisableProvenance(&provenanceDisabled)
@@ -218,7 +218,7 @@ func updateMuteTiming(ctx context.Context, data *schema.ResourceData, meta inter
 func deleteMuteTiming(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, name := OAPIClientFromExistingOrgResource(meta, data.Id())
 
-	_, err := client.Provisioning.DeleteMuteTiming(name)
+	_, err := client.Provisioning.DeleteMuteTimingWithParams(provisioning.NewDeleteMuteTimingParams().WithName(name).WithContext(ctx))
 	diag, _ := common.CheckReadError("mute timing", data, err)
 	return diag
 }
@@ -30,10 +30,10 @@ Sets the global notification policy for Grafana.
 This resource requires Grafana 9.1.0 or later.
 `,
 
-		CreateContext: common.WithAlertingMutex[schema.CreateContextFunc](putNotificationPolicy),
+		CreateContext: common.WithAlertingMutex[schema.CreateContextFunc](alertingOrgLockKeys, putNotificationPolicy),
 		ReadContext:   readNotificationPolicy,
-		UpdateContext: common.WithAlertingMutex[schema.UpdateContextFunc](putNotificationPolicy),
-		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](deleteNotificationPolicy),
+		UpdateContext: common.WithAlertingMutex[schema.UpdateContextFunc](alertingOrgLockKeys, putNotificationPolicy),
+		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](alertingOrgLockKeys, deleteNotificationPolicy),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -191,7 +191,7 @@ func policySchema(depth uint) *schema.Resource {
 }
 
 func listNotificationPolicies(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
-	orgIDs, err := data.OrgIDs(client)
+	orgIDs, err := data.OrgIDs(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -207,9 +207,9 @@ func listNotificationPolicies(ctx context.Context, client *goapi.GrafanaHTTPAPI,
 func readNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID, _ := OAPIClientFromExistingOrgResource(meta, data.Id())
 
-	resp, err := client.Provisioning.GetPolicyTree()
+	resp, err := client.Provisioning.GetPolicyTreeWithParams(provisioning.NewGetPolicyTreeParams().WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	packNotifPolicy(resp.Payload, data)
@@ -226,7 +226,7 @@ func putNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
-	putParams := provisioning.NewPutPolicyTreeParams().WithBody(npt)
+	putParams := provisioning.NewPutPolicyTreeParams().WithBody(npt).WithContext(ctx)
 	if data.Get("disable_provenance").(bool) {
 		putParams.SetXDisableProvenance(&provenanceDisabled)
 	}
@@ -245,7 +245,7 @@ func putNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta
 	})
 
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	data.SetId(MakeOrgResourceID(orgID, PolicySingletonID))
@@ -255,8 +255,8 @@ func putNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta
 func deleteNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, _ := OAPIClientFromExistingOrgResource(meta, data.Id())
 
-	if _, err := client.Provisioning.ResetPolicyTree(); err != nil {
-		return diag.FromErr(err)
+	if _, err := client.Provisioning.ResetPolicyTreeWithParams(provisioning.NewResetPolicyTreeParams().WithContext(ctx)); err != nil {
+		return common.APIErrorDiagnostics("", err)
 	}
 
 	return diag.Diagnostics{}
@@ -4,9 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"log"
-	"reflect"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/go-openapi/strfmt"
@@ -35,16 +33,18 @@ Manages Grafana Alerting rule groups.
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#alert-rules)
 
 This resource requires Grafana 9.1.0 or later.
+
+When importing, colons in the rule group title must be escaped with a backslash (ex: ` + "`team\\: alerts`" + `).
 `,
-		CreateContext: putAlertRuleGroup,
+		CreateContext: common.WithAlertingMutex[schema.CreateContextFunc](alertingRuleGroupLockKeys, putAlertRuleGroup),
 		ReadContext:   readAlertRuleGroup,
-		UpdateContext: putAlertRuleGroup,
-		DeleteContext: deleteAlertRuleGroup,
+		UpdateContext: common.WithAlertingMutex[schema.UpdateContextFunc](alertingRuleGroupLockKeys, putAlertRuleGroup),
+		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](alertingRuleGroupLockKeys, deleteAlertRuleGroup),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		SchemaVersion: 0,
+		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
 			"org_id": orgIDAttribute(),
 			"name": {
@@ -118,11 +118,10 @@ This resource requires Grafana 9.1.0 or later.
 							Description: "The `ref_id` of the query node in the `data` field to use as the alert condition.",
 						},
 						"data": {
-							Type:             schema.TypeList,
-							Required:         true,
-							MinItems:         1,
-							Description:      "A sequence of stages that describe the contents of the rule.",
-							DiffSuppressFunc: diffSuppressJSON,
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "A sequence of stages that describe the contents of the rule.",
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"ref_id": {
@@ -142,11 +141,12 @@ This resource requires Grafana 9.1.0 or later.
 										Description: "An optional identifier for the type of query being executed.",
 									},
 									"model": {
-										Required:     true,
-										Type:         schema.TypeString,
-										Description:  "Custom JSON data to send to the specified datasource when querying.",
-										ValidateFunc: validation.StringIsJSON,
-										StateFunc:    normalizeModelJSON,
+										Required:         true,
+										Type:             schema.TypeString,
+										Description:      "Custom JSON data to send to the specified datasource when querying.",
+										ValidateFunc:     validation.StringIsJSON,
+										StateFunc:        ruleModelJSON.StateFunc,
+										DiffSuppressFunc: ruleModelJSON.DiffSuppressFunc,
 									},
 									"relative_time_range": {
 										Type:        schema.TypeList,
@@ -247,6 +247,9 @@ This resource requires Grafana 9.1.0 or later.
 		},
 	}
 
+	// Version 1 escapes separators in the rule group title (ex: `1:folder:team\: alerts`)
+	schema.StateUpgraders = append(schema.StateUpgraders, resourceRuleGroupID.StateUpgrader(0, schema))
+
 	return common.NewLegacySDKResource(
 		"grafana_rule_group",
 		resourceRuleGroupID,
@@ -255,22 +258,22 @@ This resource requires Grafana 9.1.0 or later.
 }
 
 func listRuleGroups(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
-	orgIDs, err := data.OrgIDs(client)
+	orgIDs, err := data.OrgIDs(ctx, client)
 	if err != nil {
 		return nil, err
 	}
 
 	idMap := map[string]bool{}
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
-		resp, err := client.Provisioning.GetAlertRules()
+		resp, err := client.Provisioning.GetAlertRulesWithParams(provisioning.NewGetAlertRulesParams().WithContext(ctx))
 		if err != nil {
 			return nil, err
 		}
 
 		for _, rule := range resp.Payload {
-			idMap[MakeOrgResourceID(orgID, resourceRuleGroupID.Make(rule.FolderUID, rule.RuleGroup))] = true
+			idMap[resourceRuleGroupID.Make(orgID, rule.FolderUID, rule.RuleGroup)] = true
 		}
 	}
 
@@ -291,7 +294,7 @@ func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta int
 	}
 	folderUID, title := split[0].(string), split[1].(string)
 
-	resp, err := client.Provisioning.GetAlertRuleGroup(title, folderUID)
+	resp, err := client.Provisioning.GetAlertRuleGroupWithParams(provisioning.NewGetAlertRuleGroupParams().WithGroup(title).WithFolderUID(folderUID).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("rule group", data, err); shouldReturn {
 		return err
 	}
@@ -303,7 +306,7 @@ func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta int
 	disableProvenance := true
 	rules := make([]interface{}, 0, len(g.Rules))
 	for _, r := range g.Rules {
-		ruleResp, err := client.Provisioning.GetAlertRule(r.UID) // We need to get the rule through a separate API call to get the provenance.
+		ruleResp, err := client.Provisioning.GetAlertRuleWithParams(provisioning.NewGetAlertRuleParams().WithUID(r.UID).WithContext(ctx)) // We need to get the rule through a separate API call to get the provenance.
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -332,7 +335,7 @@ func putAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta inte
 		// Check if a rule group with the same name already exists. The API either:
 		// - returns a 500 error if it exists in a different folder, which is not very helpful.
 		// - overwrites the existing rule group if it exists in the same folder, which is not expected of a TF provider.
-		resp, err := client.Provisioning.GetAlertRules()
+		resp, err := client.Provisioning.GetAlertRulesWithParams(provisioning.NewGetAlertRulesParams().WithContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -366,7 +369,7 @@ func putAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta inte
 		FolderUID: folder,
 		Rules:     rules,
 		Interval:  int64(interval),
-	})
+	}).WithContext(ctx)
 
 	if data.Get("disable_provenance").(bool) {
 		putParams.SetXDisableProvenance(&provenanceDisabled)
@@ -390,14 +393,14 @@ func deleteAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta i
 	}
 	folderUID, title := split[0].(string), split[1].(string)
 	// TODO use DeleteAlertRuleGroup method instead (available since Grafana 11)
-	resp, err := client.Provisioning.GetAlertRuleGroup(title, folderUID)
+	resp, err := client.Provisioning.GetAlertRuleGroupWithParams(provisioning.NewGetAlertRuleGroupParams().WithGroup(title).WithFolderUID(folderUID).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	group := resp.Payload
 
 	for _, r := range group.Rules {
-		_, err := client.Provisioning.DeleteAlertRule(provisioning.NewDeleteAlertRuleParams().WithUID(r.UID))
+		_, err := client.Provisioning.DeleteAlertRule(provisioning.NewDeleteAlertRuleParams().WithUID(r.UID).WithContext(ctx))
 		if diag, shouldReturn := common.CheckReadError("rule group", data, err); shouldReturn {
 			return diag
 		}
@@ -406,19 +409,6 @@ func deleteAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta i
 	return nil
 }
 
-func diffSuppressJSON(k, oldValue, newValue string, data *schema.ResourceData) bool {
-	var o, n interface{}
-	d := json.NewDecoder(strings.NewReader(oldValue))
-	if err := d.Decode(&o); err != nil {
-		return false
-	}
-	d = json.NewDecoder(strings.NewReader(newValue))
-	if err := d.Decode(&n); err != nil {
-		return false
-	}
-	return reflect.DeepEqual(o, n)
-}
-
 func packAlertRule(r *models.ProvisionedAlertRule) (interface{}, error) {
 	data, err := packRuleData(r.Data)
 	if err != nil {
@@ -508,7 +498,7 @@ func packRuleData(queries []*models.AlertQuery) (interface{}, error) {
 		timeRange["from"] = int(queries[i].RelativeTimeRange.From)
 		timeRange["to"] = int(queries[i].RelativeTimeRange.To)
 		data["relative_time_range"] = []interface{}{timeRange}
-		data["model"] = normalizeModelJSON(string(model))
+		data["model"] = ruleModelJSON.StateFunc(string(model))
 		result = append(result, data)
 	}
 	return result, nil
@@ -544,47 +534,36 @@ func unpackRuleData(raw interface{}) ([]*models.AlertQuery, error) {
 	return result, nil
 }
 
-// normalizeModelJSON is the StateFunc for the `model`. It removes well-known default
+// ruleModelJSON is the `model` field. It removes well-known default
 // values from the model json, so that users do not see perma-diffs when not specifying
 // the values explicitly in their Terraform.
-func normalizeModelJSON(model interface{}) string {
-	modelJSON := model.(string)
-	var modelMap map[string]interface{}
-	err := json.Unmarshal([]byte(modelJSON), &modelMap)
-	if err != nil {
-		// This should never happen if the field passes validation.
-		log.Printf("[ERROR] Unexpected unmarshal failure for model: %v\n", err)
-		return modelJSON
-	}
-
-	// The default values taken from:
-	//   https://github.com/grafana/grafana/blob/ae688adabcfacd8bd0ac6ebaf8b78506f67962a9/pkg/services/ngalert/models/alert_query.go#L12-L13
-	const defaultMaxDataPoints float64 = 43200
-	const defaultIntervalMS float64 = 1000
-
-	// https://github.com/grafana/grafana/blob/ae688adabcfacd8bd0ac6ebaf8b78506f67962a9/pkg/services/ngalert/models/alert_query.go#L127-L134
-	iMaxDataPoints, ok := modelMap["maxDataPoints"]
-	if ok {
-		maxDataPoints, ok := iMaxDataPoints.(float64)
-		if ok && maxDataPoints == defaultMaxDataPoints {
-			log.Printf("[DEBUG] Removing maxDataPoints from state due to being set to default value (%f)", defaultMaxDataPoints)
-			delete(modelMap, "maxDataPoints")
+var ruleModelJSON = common.NormalizedJSON{
+	NormalizeFields: func(modelMap map[string]interface{}) {
+		// The default values taken from:
+		//   https://github.com/grafana/grafana/blob/ae688adabcfacd8bd0ac6ebaf8b78506f67962a9/pkg/services/ngalert/models/alert_query.go#L12-L13
+		const defaultMaxDataPoints float64 = 43200
+		const defaultIntervalMS float64 = 1000
+
+		// https://github.com/grafana/grafana/blob/ae688adabcfacd8bd0ac6ebaf8b78506f67962a9/pkg/services/ngalert/models/alert_query.go#L127-L134
+		iMaxDataPoints, ok := modelMap["maxDataPoints"]
+		if ok {
+			maxDataPoints, ok := iMaxDataPoints.(float64)
+			if ok && maxDataPoints == defaultMaxDataPoints {
+				log.Printf("[DEBUG] Removing maxDataPoints from state due to being set to default value (%f)", defaultMaxDataPoints)
+				delete(modelMap, "maxDataPoints")
+			}
 		}
-	}
 
-	// https://github.com/grafana/grafana/blob/ae688adabcfacd8bd0ac6ebaf8b78506f67962a9/pkg/services/ngalert/models/alert_query.go#L159-L166
-	iIntervalMs, ok := modelMap["intervalMs"]
-	if ok {
-		intervalMs, ok := iIntervalMs.(float64)
-		if ok && intervalMs == defaultIntervalMS {
-			log.Printf("[DEBUG] Removing intervalMs from state due to being set to default value (%f)", defaultIntervalMS)
-			delete(modelMap, "intervalMs")
+		// https://github.com/grafana/grafana/blob/ae688adabcfacd8bd0ac6ebaf8b78506f67962a9/pkg/services/ngalert/models/alert_query.go#L159-L166
+		iIntervalMs, ok := modelMap["intervalMs"]
+		if ok {
+			intervalMs, ok := iIntervalMs.(float64)
+			if ok && intervalMs == defaultIntervalMS {
+				log.Printf("[DEBUG] Removing intervalMs from state due to being set to default value (%f)", defaultIntervalMS)
+				delete(modelMap, "intervalMs")
+			}
 		}
-	}
-
-	j, _ := json.Marshal(modelMap)
-	resultJSON := string(j)
-	return resultJSON
+	},
 }
 
 func unpackMap(raw interface{}) map[string]string {
@@ -93,7 +93,7 @@ func CreateAnnotation(ctx context.Context, d *schema.ResourceData, meta interfac
 		return diag.FromErr(err)
 	}
 
-	resp, err := client.Annotations.PostAnnotation(annotation)
+	resp, err := client.Annotations.PostAnnotationWithParams(annotations.NewPostAnnotationParams().WithBody(annotation).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -118,14 +118,14 @@ func UpdateAnnotation(ctx context.Context, d *schema.ResourceData, meta interfac
 		TimeEnd: postAnnotation.TimeEnd,
 	}
 
-	_, err = client.Annotations.UpdateAnnotation(idStr, &annotation)
+	_, err = client.Annotations.UpdateAnnotationWithParams(annotations.NewUpdateAnnotationParams().WithAnnotationID(idStr).WithBody(&annotation).WithContext(ctx))
 	return diag.FromErr(err)
 }
 
 func ReadAnnotation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	resp, err := client.Annotations.GetAnnotationByID(idStr)
+	resp, err := client.Annotations.GetAnnotationByIDWithParams(annotations.NewGetAnnotationByIDParams().WithAnnotationID(idStr).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("Annotation", d, err); shouldReturn {
 		return err
 	}
@@ -138,7 +138,8 @@ func ReadAnnotation(ctx context.Context, d *schema.ResourceData, meta interface{
 		listParams := annotations.NewGetAnnotationsParams().
 			WithDashboardID(&annotation.DashboardID).
 			WithFrom(&annotation.Time).
-			WithTo(&annotation.TimeEnd)
+			WithTo(&annotation.TimeEnd).
+			WithContext(ctx)
 
 		listResp, err := client.Annotations.GetAnnotations(listParams)
 		if err != nil {
@@ -169,7 +170,7 @@ func ReadAnnotation(ctx context.Context, d *schema.ResourceData, meta interface{
 func DeleteAnnotation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	_, err := client.Annotations.DeleteAnnotationByID(idStr)
+	_, err := client.Annotations.DeleteAnnotationByIDWithParams(annotations.NewDeleteAnnotationByIDParams().WithAnnotationID(idStr).WithContext(ctx))
 	diag, _ := common.CheckReadError("annotation", d, err)
 	return diag
 }
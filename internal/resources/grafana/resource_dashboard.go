@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/search"
@@ -16,10 +19,6 @@ import (
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 )
 
-var (
-	StoreDashboardSHA256 bool
-)
-
 func resourceDashboard() *common.Resource {
 	schema := &schema.Resource{
 
@@ -74,11 +73,12 @@ Manages Grafana dashboards.
 				},
 			},
 			"config_json": {
-				Type:         schema.TypeString,
-				Required:     true,
-				StateFunc:    NormalizeDashboardConfigJSON,
-				ValidateFunc: validateDashboardConfigJSON,
-				Description:  "The complete dashboard model JSON.",
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        NormalizeDashboardConfigJSON,
+				DiffSuppressFunc: diffSuppressDashboardConfigJSON,
+				ValidateFunc:     validateDashboardConfigJSON,
+				Description:      "The complete dashboard model JSON. Only its SHA256 hash is stored in the state if `store_dashboard_sha256` is set in the provider configuration.",
 			},
 			"overwrite": {
 				Type:        schema.TypeBool,
@@ -90,7 +90,28 @@ Manages Grafana dashboards.
 				Optional:    true,
 				Description: "Set a commit message for the version history.",
 			},
+			"validate_references": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Checks that the data sources, library panels and dashboards that `config_json` references exist. " +
+					"With `warn`, broken references are reported as warnings in the plan, or during the apply if `config_json` isn't known during the plan. " +
+					"With `error`, they fail the plan, or the apply if `config_json` isn't known during the plan. " +
+					"References that use variables aren't checked. By default, references aren't checked. " +
+					"To check references to resources created in the same apply, use their attributes in `config_json`, so that it's only checked during the apply.",
+				ValidateFunc: validation.StringInSlice(dashboardReferenceModes, false),
+			},
+			"migrate_schema": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Removes the fields of `config_json` that are set to their default values before it is saved and compared. " +
+					fmt.Sprintf("Dashboards with a `schemaVersion` from %d to %d are also migrated to schema version %d, with a port of the first schema migrations of Grafana. ", minMigratedDashboardSchemaVersion, latestMigratedDashboardSchemaVersion-1, latestMigratedDashboardSchemaVersion) +
+					"The later migrations aren't applied, since some of them depend on the data sources and plugins of the Grafana instance, " +
+					"so the result can differ from the dashboard that Grafana saves after migrating it in its UI. " +
+					fmt.Sprintf("The plan warns about dashboards with a `schemaVersion` older than %d, the version that Grafana migrates dashboards to.", latestDashboardSchemaVersion),
+			},
 		},
+		CustomizeDiff: customizeDiffDashboardReferences,
 		SchemaVersion: 1, // The state upgrader was removed in v2. To upgrade, users can first upgrade to the last v1 release, apply, then upgrade to v2.
 	}
 
@@ -98,24 +119,28 @@ Manages Grafana dashboards.
 		"grafana_dashboard",
 		orgResourceIDString("uid"),
 		schema,
-	).WithLister(listerFunction(listDashboards))
+	).WithLister(listerFunction(listDashboards)).WithPlanWarnings(dashboardPlanWarnings)
+}
+
+func dashboardPlanWarnings(ctx context.Context, config cty.Value, meta interface{}) diag.Diagnostics {
+	return append(dashboardReferencesPlanWarnings(ctx, config, meta), dashboardMigrationPlanWarnings(ctx, config, meta)...)
 }
 
 func listDashboards(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
-	return listDashboardOrFolder(client, data, "dash-db")
+	return listDashboardOrFolder(ctx, client, data, "dash-db")
 }
 
-func listDashboardOrFolder(client *goapi.GrafanaHTTPAPI, data *ListerData, searchType string) ([]string, error) {
-	orgIDs, err := data.OrgIDs(client)
+func listDashboardOrFolder(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData, searchType string) ([]string, error) {
+	orgIDs, err := data.OrgIDs(ctx, client)
 	if err != nil {
 		return nil, err
 	}
 
 	uids := []string{}
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
-		resp, err := client.Search.Search(search.NewSearchParams().WithType(common.Ref(searchType)))
+		resp, err := client.Search.Search(search.NewSearchParams().WithType(common.Ref(searchType)).WithContext(ctx))
 		if err != nil {
 			return nil, err
 		}
@@ -131,23 +156,27 @@ func listDashboardOrFolder(client *goapi.GrafanaHTTPAPI, data *ListerData, searc
 func CreateDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 
-	dashboard, err := makeDashboard(d)
+	dashboard, err := makeDashboard(d, d.Get("config_json").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	resp, err := client.Dashboards.PostDashboard(&dashboard)
+	diags := checkDashboardReferencesBeforeSave(ctx, d, meta, client, dashboard.Dashboard.(map[string]interface{}))
+	if diags.HasError() {
+		return diags
+	}
+	resp, err := client.Dashboards.PostDashboardWithParams(dashboards.NewPostDashboardParams().WithBody(&dashboard).WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 	d.SetId(MakeOrgResourceID(orgID, *resp.Payload.UID))
-	return ReadDashboard(ctx, d, meta)
+	return append(diags, ReadDashboard(ctx, d, meta)...)
 }
 
 func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	metaClient := meta.(*common.Client)
 	client, orgID, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	resp, err := client.Dashboards.GetDashboardByUID(uid)
+	resp, err := client.Dashboards.GetDashboardByUIDWithParams(dashboards.NewGetDashboardByUIDParams().WithUID(uid).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("dashboard", d, err); shouldReturn {
 		return err
 	}
@@ -162,63 +191,93 @@ func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}
 	d.Set("url", metaClient.GrafanaSubpath(dashboard.Meta.URL))
 	d.Set("folder", dashboard.Meta.FolderUID)
 
-	configJSONBytes, err := json.Marshal(dashboard.Dashboard)
+	configJSON, err := readDashboardConfigJSON(metaClient, d.Get("config_json").(string), dashboard.Dashboard, d.Get("migrate_schema").(bool))
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	d.Set("config_json", configJSON)
+
+	return nil
+}
+
+// readDashboardConfigJSON returns the `config_json` value of a dashboard model read from Grafana.
+// configJSON is the current value (from the configuration or the state), which is used to know whether the UID is managed.
+// The model is migrated if migrateSchema is set, so that it is stored like the configuration is compared.
+func readDashboardConfigJSON(metaClient *common.Client, configJSON string, remoteDashboard interface{}, migrateSchema bool) (string, error) {
+	configJSONBytes, err := json.Marshal(remoteDashboard)
+	if err != nil {
+		return "", err
+	}
 	remoteDashJSON, err := UnmarshalDashboardConfigJSON(string(configJSONBytes))
 	if err != nil {
-		return diag.FromErr(err)
+		return "", err
+	}
+	if migrateSchema {
+		migrateDashboardSchema(remoteDashJSON)
 	}
 
-	configJSON := d.Get("config_json").(string)
-
-	// Skip if configJSON string is a sha256 hash
 	// If `uid` is not set in configuration, we need to delete it from the
 	// dashboard JSON we just read from the Grafana API. This is so it does not
 	// create a diff. We can assume the uid was randomly generated by Grafana or
 	// it was removed after dashboard creation. In any case, the user doesn't
 	// care to manage it.
-	if configJSON != "" && !common.SHA256Regexp.MatchString(configJSON) {
+	if common.SHA256Regexp.MatchString(configJSON) {
+		// Only the hash of the configuration is known, check if it matches the dashboard without its uid
+		remoteWithoutUID := map[string]interface{}{}
+		for k, v := range remoteDashJSON {
+			if k != "uid" {
+				remoteWithoutUID[k] = v
+			}
+		}
+		if hashDashboardConfigJSON(NormalizeDashboardConfigJSON(remoteWithoutUID)) == configJSON {
+			delete(remoteDashJSON, "uid")
+		}
+	} else if configJSON != "" {
 		configuredDashJSON, err := UnmarshalDashboardConfigJSON(configJSON)
 		if err != nil {
-			return diag.FromErr(err)
+			return "", err
 		}
 		if _, ok := configuredDashJSON["uid"].(string); !ok {
 			delete(remoteDashJSON, "uid")
 		}
 	}
 	configJSON = NormalizeDashboardConfigJSON(remoteDashJSON)
-	d.Set("config_json", configJSON)
-
-	return nil
+	if metaClient.StoreDashboardSHA256 {
+		configJSON = hashDashboardConfigJSON(configJSON)
+	}
+	return configJSON, nil
 }
 
 func UpdateDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 
-	dashboard, err := makeDashboard(d)
+	dashboard, err := makeDashboard(d, d.Get("config_json").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	dashboard.Dashboard.(map[string]interface{})["id"] = d.Get("dashboard_id").(int)
 	dashboard.Overwrite = true
-	resp, err := client.Dashboards.PostDashboard(&dashboard)
+	diags := checkDashboardReferencesBeforeSave(ctx, d, meta, client, dashboard.Dashboard.(map[string]interface{}))
+	if diags.HasError() {
+		return diags
+	}
+	resp, err := client.Dashboards.PostDashboardWithParams(dashboards.NewPostDashboardParams().WithBody(&dashboard).WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 	d.SetId(MakeOrgResourceID(orgID, *resp.Payload.UID))
-	return ReadDashboard(ctx, d, meta)
+	return append(diags, ReadDashboard(ctx, d, meta)...)
 }
 
 func DeleteDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
-	_, deleteErr := client.Dashboards.DeleteDashboardByUID(uid)
+	_, deleteErr := client.Dashboards.DeleteDashboardByUIDWithParams(dashboards.NewDeleteDashboardByUIDParams().WithUID(uid).WithContext(ctx))
 	err, _ := common.CheckReadError("dashboard", d, deleteErr)
 	return err
 }
 
-func makeDashboard(d *schema.ResourceData) (models.SaveDashboardCommand, error) {
+// makeDashboard returns the command to save a dashboard model, with the `folder`, `overwrite` and `message` attributes of the resource
+func makeDashboard(d *schema.ResourceData, configJSON string) (models.SaveDashboardCommand, error) {
 	_, folderID := SplitOrgResourceID(d.Get("folder").(string))
 	dashboard := models.SaveDashboardCommand{
 		Overwrite: d.Get("overwrite").(bool),
@@ -226,11 +285,13 @@ func makeDashboard(d *schema.ResourceData) (models.SaveDashboardCommand, error)
 		FolderUID: folderID,
 	}
 
-	configJSON := d.Get("config_json").(string)
 	dashboardJSON, err := UnmarshalDashboardConfigJSON(configJSON)
 	if err != nil {
 		return dashboard, err
 	}
+	if migrate, _ := d.Get("migrate_schema").(bool); migrate {
+		migrateDashboardSchema(dashboardJSON)
+	}
 	delete(dashboardJSON, "id")
 	dashboard.Dashboard = dashboardJSON
 	return dashboard, nil
@@ -259,7 +320,7 @@ func validateDashboardConfigJSON(config interface{}, k string) ([]string, []erro
 	return nil, nil
 }
 
-// NormalizeDashboardConfigJSON is the StateFunc for the `config_json` field.
+// dashboardConfigJSON is the `config_json` field.
 //
 // It removes the following fields:
 //
@@ -267,45 +328,62 @@ func validateDashboardConfigJSON(config interface{}, k string) ([]string, []erro
 //     creation. We cannot know this before creation and therefore it cannot
 //     be managed in code.
 //   - `version`: is incremented by Grafana each time a dashboard changes.
-func NormalizeDashboardConfigJSON(config interface{}) string {
-	var dashboardJSON map[string]interface{}
-	switch c := config.(type) {
-	case map[string]interface{}:
-		dashboardJSON = c
-	case string:
-		var err error
-		dashboardJSON, err = UnmarshalDashboardConfigJSON(c)
-		if err != nil {
-			return c
-		}
-	}
-
-	delete(dashboardJSON, "id")
-	delete(dashboardJSON, "version")
-
-	// similarly to uid removal above, remove any attributes panels[].libraryPanel.*
-	// from the dashboard JSON other than "name" or "uid".
-	// Grafana will populate all other libraryPanel attributes, so delete them to avoid diff.
-	if panels, ok := dashboardJSON["panels"].([]interface{}); ok {
-		for _, panel := range panels {
-			panelMap := panel.(map[string]interface{})
-			delete(panelMap, "id")
-			if libraryPanel, ok := panelMap["libraryPanel"].(map[string]interface{}); ok {
-				for k := range libraryPanel {
-					if k != "name" && k != "uid" {
-						delete(libraryPanel, k)
+var dashboardConfigJSON = common.NormalizedJSON{
+	NormalizeFields: func(dashboardJSON map[string]interface{}) {
+		delete(dashboardJSON, "id")
+		delete(dashboardJSON, "version")
+
+		// similarly to uid removal above, remove any attributes panels[].libraryPanel.*
+		// from the dashboard JSON other than "name" or "uid".
+		// Grafana will populate all other libraryPanel attributes, so delete them to avoid diff.
+		if panels, ok := dashboardJSON["panels"].([]interface{}); ok {
+			for _, panel := range panels {
+				panelMap, ok := panel.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				delete(panelMap, "id")
+				if libraryPanel, ok := panelMap["libraryPanel"].(map[string]interface{}); ok {
+					for k := range libraryPanel {
+						if k != "name" && k != "uid" {
+							delete(libraryPanel, k)
+						}
 					}
 				}
 			}
 		}
-	}
+	},
+}
+
+// NormalizeDashboardConfigJSON is the StateFunc for the `config_json` field.
+// The SHA256 hash of the normalized JSON is stored instead when the provider's `store_dashboard_sha256` is set, this is done when reading the dashboard.
+func NormalizeDashboardConfigJSON(config interface{}) string {
+	return dashboardConfigJSON.StateFunc(config)
+}
 
-	j, _ := json.Marshal(dashboardJSON)
+// hashDashboardConfigJSON returns the SHA256 hash of a normalized `config_json` value, which is stored instead of the JSON when `store_dashboard_sha256` is set.
+func hashDashboardConfigJSON(normalizedJSON string) string {
+	configHash := sha256.Sum256([]byte(normalizedJSON))
+	return fmt.Sprintf("%x", configHash[:])
+}
 
-	if StoreDashboardSHA256 {
-		configHash := sha256.Sum256(j)
-		return fmt.Sprintf("%x", configHash[:])
-	} else {
-		return string(j)
+// diffSuppressDashboardConfigJSON is the DiffSuppressFunc for the `config_json` field.
+// The state holds either the normalized JSON or its hash, depending on the `store_dashboard_sha256` setting of the provider when the dashboard was last read.
+// Both are compared to the configuration, so that toggling the setting doesn't produce a diff.
+// Both sides are migrated first if `migrate_schema` is set.
+func diffSuppressDashboardConfigJSON(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	migrate := false
+	if d != nil {
+		migrate, _ = d.Get("migrate_schema").(bool)
+	}
+	if common.SHA256Regexp.MatchString(oldValue) && !common.SHA256Regexp.MatchString(newValue) {
+		if migrate {
+			return oldValue == hashDashboardConfigJSON(MigrateDashboardConfigJSON(newValue))
+		}
+		return oldValue == hashDashboardConfigJSON(NormalizeDashboardConfigJSON(newValue))
+	}
+	if migrate {
+		return MigrateDashboardConfigJSON(oldValue) == MigrateDashboardConfigJSON(newValue)
 	}
+	return dashboardConfigJSON.DiffSuppressFunc(k, oldValue, newValue, d)
 }
@@ -0,0 +1,453 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
+	"github.com/grafana/grafana-openapi-client-go/client/folders"
+	"github.com/grafana/grafana-openapi-client-go/client/search"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+)
+
+func resourceDashboardBundle() *common.Resource {
+	schema := &schema.Resource{
+
+		Description: `
+Manages a set of dashboards in a folder as one unit, from a directory of JSON files or from a map of dashboard models.
+The plan shows the changes of each dashboard. Dashboards of the folder that aren't in the bundle can be deleted with ` + "`prune`" + `.
+
+Dashboards are identified by the ` + "`uid`" + ` of their model. A dashboard without a ` + "`uid`" + ` gets one from Grafana, which is kept as long as its key doesn't change.
+
+An imported bundle contains all the dashboards of the folder, keyed by ` + "`uid`" + `. The next apply moves them to the keys of the configuration (ex: the paths of the ` + "`directory`" + ` files) and updates them in place, matching them by ` + "`uid`" + `. Like removed dashboards, the imported dashboards whose ` + "`uid`" + ` isn't in the configuration are deleted.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard/)
+`,
+
+		CreateContext: CreateDashboardBundle,
+		ReadContext:   ReadDashboardBundle,
+		UpdateContext: UpdateDashboardBundle,
+		DeleteContext: DeleteDashboardBundle,
+		Importer: &schema.ResourceImporter{
+			StateContext: importDashboardBundle,
+		},
+		CustomizeDiff: customizeDiffDashboardBundle,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"folder": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The UID of the folder to save the dashboards in.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					_, old = SplitOrgResourceID(old)
+					_, new = SplitOrgResourceID(new)
+					return old == new
+				},
+			},
+			"directory": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"directory", "dashboards"},
+				Description: "A directory of dashboard JSON files. The `.json` files of the directory and its subdirectories are read during the plan, " +
+					"and are keyed by their path relative to the directory in `dashboards`.",
+			},
+			"dashboards": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+				Description: "The dashboard models JSON, by key (ex: a file name). The key only identifies the dashboard in the plan. " +
+					"Only the SHA256 hashes of the models are stored in the state if `store_dashboard_sha256` is set in the provider configuration.",
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateFunc:     validateDashboardBundleConfigJSON,
+				DiffSuppressFunc: diffSuppressDashboardConfigJSON,
+			},
+			"prune": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Delete the dashboards of the folder that aren't in the bundle. " +
+					"Dashboards of the subfolders aren't deleted.",
+			},
+			"overwrite": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Set to true to overwrite existing dashboards with the same title in the folder or the same uid when creating dashboards.",
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Set a commit message for the version history of the dashboards that are saved.",
+			},
+			"uids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The UIDs of the dashboards, by key.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"unmanaged_dashboard_uids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Description: "The UIDs of the dashboards of the folder that aren't in the bundle. " +
+					"They are deleted by the next apply if `prune` is set.",
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+
+	return common.NewLegacySDKResource(
+		"grafana_dashboard_bundle",
+		orgResourceIDString("folderUID"),
+		schema,
+	)
+}
+
+func validateDashboardBundleConfigJSON(value interface{}, k string) ([]string, []error) {
+	var errs []error
+	for key, configJSON := range value.(map[string]interface{}) {
+		if _, keyErrs := validateDashboardConfigJSON(configJSON, k); len(keyErrs) > 0 {
+			errs = append(errs, fmt.Errorf("%s: %s", key, keyErrs[0]))
+		}
+	}
+	return nil, errs
+}
+
+// customizeDiffDashboardBundle reads the `directory`, checks that the dashboards have different UIDs and plans the deletion of the unmanaged dashboards if `prune` is set
+func customizeDiffDashboardBundle(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if directory := d.Get("directory").(string); directory != "" {
+		files, err := readDashboardBundleDirectory(directory)
+		if err != nil {
+			return err
+		}
+		// Keep the state value of the unchanged dashboards, which may be a hash, so that they don't show in the plan
+		old, _ := d.GetChange("dashboards")
+		dashboardsMap := map[string]interface{}{}
+		changed := len(files) != len(old.(map[string]interface{}))
+		for key, configJSON := range files {
+			if oldJSON, ok := old.(map[string]interface{})[key].(string); ok && diffSuppressDashboardConfigJSON("", oldJSON, configJSON, nil) {
+				dashboardsMap[key] = oldJSON
+				continue
+			}
+			dashboardsMap[key] = NormalizeDashboardConfigJSON(configJSON)
+			changed = true
+		}
+		if changed {
+			if err := d.SetNew("dashboards", dashboardsMap); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.NewValueKnown("dashboards") {
+		dashboardsMap := d.Get("dashboards").(map[string]interface{})
+		keys := make([]string, 0, len(dashboardsMap))
+		for key := range dashboardsMap {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		keysByUID := map[string]string{}
+		for _, key := range keys {
+			// Unchanged dashboards may only be known by their hash
+			model, err := UnmarshalDashboardConfigJSON(dashboardsMap[key].(string))
+			if err != nil {
+				continue
+			}
+			uid, _ := model["uid"].(string)
+			if other, ok := keysByUID[uid]; ok && uid != "" {
+				return fmt.Errorf("dashboards %s and %s have the same uid %s", other, key, uid)
+			}
+			keysByUID[uid] = key
+		}
+	}
+
+	if d.HasChange("dashboards") {
+		if err := d.SetNewComputed("uids"); err != nil {
+			return err
+		}
+	}
+	if d.Get("prune").(bool) && len(d.Get("unmanaged_dashboard_uids").([]interface{})) > 0 {
+		if err := d.SetNew("unmanaged_dashboard_uids", []string{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readDashboardBundleDirectory returns the content of the `.json` files of a directory and its subdirectories, by path relative to the directory
+func readDashboardBundleDirectory(directory string) (map[string]string, error) {
+	files := map[string]string{}
+	err := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if _, errs := validateDashboardConfigJSON(string(content), path); len(errs) > 0 {
+			return fmt.Errorf("invalid dashboard JSON in %s: %s", path, errs[0])
+		}
+		key, err := filepath.Rel(directory, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(key)] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the dashboards of directory %s: %w", directory, err)
+	}
+	return files, nil
+}
+
+func CreateDashboardBundle(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+	_, folderUID := SplitOrgResourceID(d.Get("folder").(string))
+	d.SetId(MakeOrgResourceID(orgID, folderUID))
+
+	if err := syncDashboardBundle(ctx, client, d, map[string]interface{}{}, map[string]interface{}{}); err != nil {
+		return diag.FromErr(err)
+	}
+	return ReadDashboardBundle(ctx, d, meta)
+}
+
+func ReadDashboardBundle(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	metaClient := meta.(*common.Client)
+	client, orgID, folderUID := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	_, err := client.Folders.GetFolderByUIDWithParams(folders.NewGetFolderByUIDParams().WithFolderUID(folderUID).WithContext(ctx))
+	if err, shouldReturn := common.CheckReadError("folder", d, err); shouldReturn {
+		return err
+	}
+
+	configured := d.Get("dashboards").(map[string]interface{})
+	dashboardsMap := map[string]interface{}{}
+	uids := map[string]interface{}{}
+	for key, uid := range d.Get("uids").(map[string]interface{}) {
+		resp, err := client.Dashboards.GetDashboardByUIDWithParams(dashboards.NewGetDashboardByUIDParams().WithUID(uid.(string)).WithContext(ctx))
+		if common.IsNotFoundError(err) {
+			continue // The dashboard is created again by the next apply
+		} else if err != nil {
+			return diag.Errorf("failed to read dashboard %s: %s", uid, err)
+		}
+		configuredJSON, _ := configured[key].(string)
+		configJSON, err := readDashboardConfigJSON(metaClient, configuredJSON, resp.Payload.Dashboard, false)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		dashboardsMap[key] = configJSON
+		uids[key] = uid
+	}
+
+	unmanaged, err := listUnmanagedDashboards(ctx, client, folderUID, uids)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	d.Set("folder", folderUID)
+	d.Set("dashboards", dashboardsMap)
+	d.Set("uids", uids)
+	d.Set("unmanaged_dashboard_uids", unmanaged)
+
+	return nil
+}
+
+func UpdateDashboardBundle(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	oldDashboards, _ := d.GetChange("dashboards")
+	oldUIDs, _ := d.GetChange("uids")
+	if err := syncDashboardBundle(ctx, client, d, oldDashboards.(map[string]interface{}), oldUIDs.(map[string]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ReadDashboardBundle(ctx, d, meta)
+}
+
+func DeleteDashboardBundle(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
+	for _, uid := range d.Get("uids").(map[string]interface{}) {
+		if err := deleteBundleDashboard(ctx, client, uid.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// importDashboardBundle imports all the dashboards of a folder, keyed by UID.
+// The next apply matches the configured dashboards to the imported ones by the `uid` of their model, so they are updated in place whatever their key.
+func importDashboardBundle(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, _, folderUID := OAPIClientFromExistingOrgResource(meta, d.Id())
+	unmanaged, err := listUnmanagedDashboards(ctx, client, folderUID, nil)
+	if err != nil {
+		return nil, err
+	}
+	uids := map[string]interface{}{}
+	for _, uid := range unmanaged {
+		uids[uid] = uid
+	}
+	d.Set("uids", uids)
+	d.Set("prune", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// syncDashboardBundle deletes the dashboards that were removed since the previous state, then saves the dashboards that were added or changed.
+// With `prune`, the other dashboards of the folder are also deleted.
+// The state follows the progress, so that it's accurate if an error stops the sync. The next plan shows what's left to do.
+func syncDashboardBundle(ctx context.Context, client *goapi.GrafanaHTTPAPI, d *schema.ResourceData, oldDashboards, oldUIDs map[string]interface{}) error {
+	stateDashboards, stateUIDs := map[string]interface{}{}, map[string]interface{}{}
+	for key, uid := range oldUIDs {
+		stateDashboards[key] = oldDashboards[key]
+		stateUIDs[key] = uid
+	}
+	fail := func(err error) error {
+		d.Set("dashboards", stateDashboards)
+		d.Set("uids", stateUIDs)
+		return err
+	}
+
+	newDashboards := d.Get("dashboards").(map[string]interface{})
+	keys := make([]string, 0, len(newDashboards))
+	for key := range newDashboards {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Removed dashboards are deleted first, so that a dashboard that moves to another key without a uid doesn't conflict with itself.
+	// A removed dashboard whose uid is now set by another key is saved instead.
+	reused := map[string]bool{}
+	for key, configJSON := range newDashboards {
+		if uid, ok := oldUIDs[key].(string); ok {
+			reused[uid] = true
+		}
+		if model, err := UnmarshalDashboardConfigJSON(configJSON.(string)); err == nil {
+			if uid, ok := model["uid"].(string); ok {
+				reused[uid] = true
+			}
+		}
+	}
+	for key, uid := range oldUIDs {
+		if _, ok := newDashboards[key]; ok {
+			continue
+		}
+		if !reused[uid.(string)] {
+			if err := deleteBundleDashboard(ctx, client, uid.(string)); err != nil {
+				return fail(err)
+			}
+		}
+		delete(stateDashboards, key)
+		delete(stateUIDs, key)
+	}
+
+	// Dashboards of the previous state are matched by uid, so that they can move to another key (ex: after an import, where dashboards are keyed by uid)
+	managed := map[string]bool{}
+	for _, uid := range oldUIDs {
+		managed[uid.(string)] = true
+	}
+
+	keep := map[string]bool{}
+	for _, key := range keys {
+		configJSON := newDashboards[key].(string)
+		oldUID, _ := oldUIDs[key].(string)
+		if oldJSON, ok := oldDashboards[key].(string); ok && oldUID != "" && diffSuppressDashboardConfigJSON("", oldJSON, configJSON, d) {
+			keep[oldUID] = true
+			continue
+		}
+
+		dashboard, err := makeDashboard(d, configJSON)
+		if err != nil {
+			return fail(fmt.Errorf("dashboard %s: %w", key, err))
+		}
+		model := dashboard.Dashboard.(map[string]interface{})
+		if _, ok := model["uid"]; !ok && oldUID != "" {
+			model["uid"] = oldUID
+		}
+		uid, _ := model["uid"].(string)
+		if keep[uid] {
+			return fail(fmt.Errorf("dashboard %s: the uid %s is used by another dashboard of the bundle", key, uid))
+		}
+		if oldUID != "" || managed[uid] {
+			dashboard.Overwrite = true
+		}
+		resp, err := client.Dashboards.PostDashboardWithParams(dashboards.NewPostDashboardParams().WithBody(&dashboard).WithContext(ctx))
+		if err != nil {
+			return fail(fmt.Errorf("failed to save dashboard %s: %w", key, err))
+		}
+		stateDashboards[key] = configJSON
+		stateUIDs[key] = *resp.Payload.UID
+		keep[*resp.Payload.UID] = true
+	}
+
+	if d.Get("prune").(bool) {
+		_, folderUID := SplitOrgResourceID(d.Get("folder").(string))
+		unmanaged, err := listUnmanagedDashboards(ctx, client, folderUID, stateUIDs)
+		if err != nil {
+			return fail(err)
+		}
+		for _, uid := range unmanaged {
+			if err := deleteBundleDashboard(ctx, client, uid); err != nil {
+				return fail(err)
+			}
+		}
+	}
+
+	d.Set("uids", stateUIDs)
+	return nil
+}
+
+// listUnmanagedDashboards returns the UIDs of the dashboards of a folder that aren't in the given map of UIDs, excluding the dashboards of its subfolders
+func listUnmanagedDashboards(ctx context.Context, client *goapi.GrafanaHTTPAPI, folderUID string, uids map[string]interface{}) ([]string, error) {
+	managed := map[string]bool{}
+	for _, uid := range uids {
+		managed[uid.(string)] = true
+	}
+
+	var unmanaged []string
+	var page int64 = 1
+	for {
+		params := search.NewSearchParams().
+			WithType(common.Ref("dash-db")).
+			WithFolderUIDs([]string{folderUID}).
+			WithLimit(common.Ref(int64(1000))).
+			WithPage(&page).
+			WithContext(ctx)
+		resp, err := client.Search.Search(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the dashboards of folder %s: %w", folderUID, err)
+		}
+		for _, hit := range resp.Payload {
+			if hit.FolderUID == folderUID && !managed[hit.UID] {
+				unmanaged = append(unmanaged, hit.UID)
+			}
+		}
+		if len(resp.Payload) < 1000 {
+			break
+		}
+		page++
+	}
+	sort.Strings(unmanaged)
+	return unmanaged, nil
+}
+
+func deleteBundleDashboard(ctx context.Context, client *goapi.GrafanaHTTPAPI, uid string) error {
+	_, err := client.Dashboards.DeleteDashboardByUIDWithParams(dashboards.NewDeleteDashboardByUIDParams().WithUID(uid).WithContext(ctx))
+	if err != nil && !common.IsNotFoundError(err) {
+		return fmt.Errorf("failed to delete dashboard %s: %w", uid, err)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package grafana_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDashboardBundle_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	client := func() *goapi.GrafanaHTTPAPI {
+		return testutils.Provider.Meta().(*common.Client).GrafanaAPI.WithOrgID(1)
+	}
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             checkBundleDashboards(client, map[string]string{"team-overview": "", "team-alerts": ""}),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_dashboard_bundle/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.team", "uids.%", "2"),
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.team", "uids.overview", "team-overview"),
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.team", "uids.alerts", "team-alerts"),
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.team", "unmanaged_dashboard_uids.#", "0"),
+					checkBundleDashboards(client, map[string]string{"team-overview": "Team Overview", "team-alerts": "Team Alerts"}),
+				),
+			},
+			{
+				Config:   testutils.TestAccExample(t, "resources/grafana_dashboard_bundle/resource.tf"),
+				PlanOnly: true,
+			},
+			{
+				ResourceName: "grafana_dashboard_bundle.team",
+				ImportState:  true,
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if uids := s[0].Attributes["uids.%"]; uids != "2" {
+						return fmt.Errorf("expected 2 imported dashboards, got %s", uids)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestMockDashboardBundle_directory(t *testing.T) {
+	testutils.IsUnitTest(t)
+	mock := testutils.NewMockGrafana(t)
+
+	client := mock.Client()
+	if _, err := client.Folders.CreateFolder(&models.CreateFolderCommand{UID: "bundle", Title: "Bundle"}); err != nil {
+		t.Fatal(err)
+	}
+	unmanaged := models.SaveDashboardCommand{Dashboard: map[string]interface{}{"uid": "unmanaged", "title": "Unmanaged"}, FolderUID: "bundle"}
+	if _, err := client.Dashboards.PostDashboard(&unmanaged); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("a.json", `{"uid": "a", "title": "A"}`)
+	writeFile("sub/b.json", `{"uid": "b", "title": "B"}`)
+	writeFile("README.md", "Not a dashboard")
+
+	config := func(prune bool) string {
+		return fmt.Sprintf(`
+resource "grafana_dashboard_bundle" "test" {
+	folder    = "bundle"
+	directory = %q
+	prune     = %t
+}
+`, dir, prune)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             checkBundleDashboards(mock.Client, map[string]string{"a": "", "b": ""}),
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "id", "1:bundle"),
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "uids.%", "2"),
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "uids.a.json", "a"),
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "uids.sub/b.json", "b"),
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "unmanaged_dashboard_uids.#", "1"),
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "unmanaged_dashboard_uids.0", "unmanaged"),
+					checkBundleDashboards(mock.Client, map[string]string{"a": "A", "b": "B", "unmanaged": "Unmanaged"}),
+				),
+			},
+			{
+				Config:   config(false),
+				PlanOnly: true,
+			},
+			// A file is changed and the unmanaged dashboard is deleted
+			{
+				PreConfig: func() {
+					writeFile("sub/b.json", `{"uid": "b", "title": "B2"}`)
+				},
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "uids.%", "2"),
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "unmanaged_dashboard_uids.#", "0"),
+					checkBundleDashboards(mock.Client, map[string]string{"a": "A", "b": "B2", "unmanaged": ""}),
+				),
+			},
+			// A file is removed, its dashboard is deleted
+			{
+				PreConfig: func() {
+					if err := os.Remove(filepath.Join(dir, "a.json")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "uids.%", "1"),
+					resource.TestCheckNoResourceAttr("grafana_dashboard_bundle.test", "uids.a.json"),
+					checkBundleDashboards(mock.Client, map[string]string{"a": "", "b": "B2"}),
+				),
+			},
+		},
+	})
+}
+
+func TestMockDashboardBundle_importDirectory(t *testing.T) {
+	testutils.IsUnitTest(t)
+	mock := testutils.NewMockGrafana(t)
+
+	client := mock.Client()
+	if _, err := client.Folders.CreateFolder(&models.CreateFolderCommand{UID: "bundle", Title: "Bundle"}); err != nil {
+		t.Fatal(err)
+	}
+	for uid, title := range map[string]string{"a": "A", "b": "B", "c": "C"} {
+		if _, err := client.Dashboards.PostDashboard(&models.SaveDashboardCommand{Dashboard: map[string]interface{}{"uid": uid, "title": title}, FolderUID: "bundle"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"uid": "a", "title": "A2"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.json"), []byte(`{"uid": "b", "title": "B"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config := fmt.Sprintf(`
+resource "grafana_dashboard_bundle" "test" {
+	folder    = "bundle"
+	directory = %q
+}
+`, dir)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ResourceName:       "grafana_dashboard_bundle.test",
+				ImportState:        true,
+				ImportStateId:      "bundle",
+				ImportStatePersist: true,
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if uids := s[0].Attributes["uids.%"]; uids != "3" {
+						return fmt.Errorf("expected 3 imported dashboards, got %s", uids)
+					}
+					if uid := s[0].Attributes["uids.a"]; uid != "a" {
+						return fmt.Errorf("expected the imported dashboards to be keyed by uid, got %s for key a", uid)
+					}
+					return nil
+				},
+			},
+			// The configured dashboards are matched to the imported ones by uid and updated in place, without `overwrite`.
+			// The imported dashboard that isn't configured is deleted.
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "uids.%", "2"),
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "uids.a.json", "a"),
+					resource.TestCheckResourceAttr("grafana_dashboard_bundle.test", "uids.b.json", "b"),
+					resource.TestCheckNoResourceAttr("grafana_dashboard_bundle.test", "uids.a"),
+					checkBundleDashboards(mock.Client, map[string]string{"a": "A2", "b": "B", "c": ""}),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// checkBundleDashboards checks the titles of dashboards by UID. An empty title means that the dashboard must not exist.
+func checkBundleDashboards(client func() *goapi.GrafanaHTTPAPI, titles map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for uid, expected := range titles {
+			resp, err := client().Dashboards.GetDashboardByUID(uid)
+			if expected == "" {
+				if err == nil {
+					return fmt.Errorf("dashboard %s still exists", uid)
+				}
+				if !common.IsNotFoundError(err) {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if title := resp.Payload.Dashboard.(map[string]interface{})["title"]; title != expected {
+				return fmt.Errorf("expected dashboard %s title to be %q, got %q", uid, expected, title)
+			}
+		}
+		return nil
+	}
+}
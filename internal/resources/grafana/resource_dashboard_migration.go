@@ -0,0 +1,655 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	// minMigratedDashboardSchemaVersion is the oldest schema version that is migrated. Older dashboards are left as they are.
+	minMigratedDashboardSchemaVersion = 13
+	// latestMigratedDashboardSchemaVersion is the schema version that dashboards are migrated to.
+	// Grafana's later migrations aren't ported: some of them need the data sources (ex: 33 and 36) or the panel plugins (ex: 28) of the instance.
+	latestMigratedDashboardSchemaVersion = 27
+	// latestDashboardSchemaVersion is the schema version that Grafana migrates dashboards to (as of Grafana 10.3)
+	latestDashboardSchemaVersion = 39
+
+	dashboardGridColumnCount  = 24
+	dashboardPanelHeightStep  = 30 + 8 // The height of a grid cell and its margin, in pixels
+	dashboardMinPanelHeight   = 30 * 3
+	dashboardDefaultRowHeight = 250
+	dashboardDefaultPanelSpan = 4
+)
+
+// dashboardSchemaMigrations are the ported migrations of Grafana's DashboardMigrator, by the schema version that they upgrade to.
+// They are applied in order to the dashboards whose schema version is lower.
+var dashboardSchemaMigrations = []struct {
+	version int
+	migrate func(dashboard map[string]interface{})
+}{
+	{14, migrateDashboardSharedCrosshair},
+	{15, func(map[string]interface{}) {}},
+	{16, migrateDashboardRowsToGridLayout},
+	{17, forEachDashboardPanel(migratePanelMinSpan)},
+	{18, forEachDashboardPanel(migratePanelGaugeOptions)},
+	{19, forEachDashboardPanel(migratePanelLinks)},
+	{20, forEachDashboardPanel(migratePanelDataLinksVariables)},
+	{21, forEachDashboardPanel(migratePanelDataLinksLabels)},
+	{22, forEachDashboardPanel(migratePanelTableStylesAlign)},
+	{23, forEachDashboardVariable(migrateVariableCurrentMulti)},
+	{24, forEachDashboardPanel(migratePanelAngularTable)},
+	{25, func(map[string]interface{}) {}},
+	{26, forEachDashboardPanel(migratePanelText2)},
+	{27, forEachDashboardVariable(migrateVariableConstant)},
+}
+
+// dashboardDefaults are the dashboard fields that are removed when they are set to the default value that Grafana uses when they are missing.
+var dashboardDefaults = mustDecodeJSONObject(`{
+	"annotations": {"list": []},
+	"editable": true,
+	"fiscalYearStartMonth": 0,
+	"gnetId": null,
+	"graphTooltip": 0,
+	"links": [],
+	"liveNow": false,
+	"refresh": "",
+	"style": "dark",
+	"tags": [],
+	"templating": {"list": []},
+	"time": {"from": "now-6h", "to": "now"},
+	"timepicker": {},
+	"timezone": "",
+	"weekStart": ""
+}`)
+
+// dashboardPanelDefaults are the panel fields that are removed when they are set to the default value that Grafana uses when they are missing.
+var dashboardPanelDefaults = mustDecodeJSONObject(`{
+	"datasource": null,
+	"fieldConfig": {"defaults": {}, "overrides": []},
+	"links": [],
+	"options": {},
+	"transparent": false
+}`)
+
+// MigrateDashboardConfigJSON returns the normalized `config_json` value of a dashboard, without the fields that are set to their default values
+// and migrated to the latest schema version supported by the provider if it's older. This is how `config_json` is compared and saved when `migrate_schema` is set.
+// Invalid JSON is returned as is.
+func MigrateDashboardConfigJSON(config interface{}) string {
+	var dashboard map[string]interface{}
+	switch v := config.(type) {
+	case string:
+		if err := json.Unmarshal([]byte(v), &dashboard); err != nil {
+			return v
+		}
+	case map[string]interface{}:
+		// The dashboard is copied, it is modified in place by the migrations
+		encoded, err := json.Marshal(v)
+		if err != nil || json.Unmarshal(encoded, &dashboard) != nil {
+			return NormalizeDashboardConfigJSON(v)
+		}
+	default:
+		return NormalizeDashboardConfigJSON(config)
+	}
+	migrateDashboardSchema(dashboard)
+	return NormalizeDashboardConfigJSON(dashboard)
+}
+
+// migrateDashboardSchema migrates a dashboard model to the latest schema version supported by the provider, and removes the fields set to their default values.
+// Dashboards without a schema version, or with a schema version older than the oldest supported version, aren't migrated.
+func migrateDashboardSchema(dashboard map[string]interface{}) {
+	if version, ok := jsonNumber(dashboard["schemaVersion"]); ok && version >= minMigratedDashboardSchemaVersion {
+		for _, migration := range dashboardSchemaMigrations {
+			if float64(migration.version) > version {
+				migration.migrate(dashboard)
+			}
+		}
+		if version < latestMigratedDashboardSchemaVersion {
+			dashboard["schemaVersion"] = latestMigratedDashboardSchemaVersion
+		}
+	}
+
+	removeJSONDefaults(dashboard, dashboardDefaults)
+	forEachDashboardPanel(func(panel map[string]interface{}) {
+		removeJSONDefaults(panel, dashboardPanelDefaults)
+	})(dashboard)
+}
+
+// dashboardMigrationPlanWarnings warns when `migrate_schema` is set on a dashboard that can't be fully migrated, because its schema version is older
+// than the version that Grafana migrates dashboards to. The changes of the migrations that aren't ported then show as differences
+// once Grafana migrates the dashboard (ex: when it's saved in its UI).
+func dashboardMigrationPlanWarnings(ctx context.Context, config cty.Value, meta interface{}) diag.Diagnostics {
+	migrate, configJSON := config.GetAttr("migrate_schema"), config.GetAttr("config_json")
+	if !migrate.IsKnown() || migrate.IsNull() || migrate.False() || !configJSON.IsKnown() || configJSON.IsNull() {
+		return nil
+	}
+	dashboard, err := UnmarshalDashboardConfigJSON(configJSON.AsString())
+	if err != nil {
+		return nil // Reported by the ValidateFunc
+	}
+	version, ok := jsonNumber(dashboard["schemaVersion"])
+	if !ok || version >= latestDashboardSchemaVersion {
+		return nil
+	}
+
+	migrated := fmt.Sprintf("it is only migrated to schema version %d", latestMigratedDashboardSchemaVersion)
+	if version < minMigratedDashboardSchemaVersion {
+		migrated = fmt.Sprintf("it isn't migrated, since schema versions older than %d aren't supported", minMigratedDashboardSchemaVersion)
+	} else if version >= latestMigratedDashboardSchemaVersion {
+		migrated = "it isn't migrated further"
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "The dashboard can't be fully migrated",
+		Detail: fmt.Sprintf("`config_json` has schema version %v and %s, while Grafana migrates dashboards to schema version %d. "+
+			"The changes of the later migrations show as differences once Grafana migrates the dashboard (ex: when it is saved in its UI). "+
+			"Update `config_json` to the latest schema version (ex: by exporting the dashboard from Grafana) to avoid them.",
+			version, migrated, latestDashboardSchemaVersion),
+	}}
+}
+
+// forEachDashboardPanel returns a migration that applies a function to each panel of a dashboard, including the panels of collapsed rows.
+func forEachDashboardPanel(fn func(panel map[string]interface{})) func(dashboard map[string]interface{}) {
+	var walk func(panels interface{})
+	walk = func(panels interface{}) {
+		list, _ := panels.([]interface{})
+		for _, p := range list {
+			if panel, ok := p.(map[string]interface{}); ok {
+				fn(panel)
+				walk(panel["panels"])
+			}
+		}
+	}
+	return func(dashboard map[string]interface{}) {
+		walk(dashboard["panels"])
+	}
+}
+
+// forEachDashboardVariable returns a migration that applies a function to each template variable of a dashboard.
+func forEachDashboardVariable(fn func(variable map[string]interface{})) func(dashboard map[string]interface{}) {
+	return func(dashboard map[string]interface{}) {
+		templating, _ := dashboard["templating"].(map[string]interface{})
+		list, _ := templating["list"].([]interface{})
+		for _, v := range list {
+			if variable, ok := v.(map[string]interface{}); ok {
+				fn(variable)
+			}
+		}
+	}
+}
+
+// migrateDashboardSharedCrosshair replaces the `sharedCrosshair` option by `graphTooltip`.
+func migrateDashboardSharedCrosshair(dashboard map[string]interface{}) {
+	dashboard["graphTooltip"] = 0
+	if jsonTruthy(dashboard["sharedCrosshair"]) {
+		dashboard["graphTooltip"] = 1
+	}
+	delete(dashboard, "sharedCrosshair")
+}
+
+// migrateDashboardRowsToGridLayout replaces the rows of a dashboard by panels positioned on the grid.
+// Row panels are added if any row is collapsed, repeated or shows its title.
+func migrateDashboardRowsToGridLayout(dashboard map[string]interface{}) {
+	rows, ok := dashboard["rows"].([]interface{})
+	delete(dashboard, "rows")
+	if !ok {
+		return
+	}
+	panels, _ := dashboard["panels"].([]interface{})
+
+	var maxPanelID float64
+	showRows := false
+	for _, r := range rows {
+		row, _ := r.(map[string]interface{})
+		showRows = showRows || jsonTruthy(row["collapse"]) || jsonTruthy(row["showTitle"]) || jsonTruthy(row["repeat"])
+		rowPanels, _ := row["panels"].([]interface{})
+		for _, p := range rowPanels {
+			panel, _ := p.(map[string]interface{})
+			if id, ok := jsonNumber(panel["id"]); ok && id > maxPanelID {
+				maxPanelID = id
+			}
+		}
+	}
+
+	nextRowID := maxPanelID + 1
+	yPos := 0
+	for _, r := range rows {
+		row, ok := r.(map[string]interface{})
+		if !ok || jsonTruthy(row["repeatIteration"]) {
+			continue
+		}
+		rowHeight := dashboardGridHeight(row["height"], dashboardDefaultRowHeight)
+		collapsed := jsonTruthy(row["collapse"])
+
+		var rowPanel map[string]interface{}
+		if showRows {
+			rowPanel = map[string]interface{}{
+				"id":      nextRowID,
+				"type":    "row",
+				"panels":  []interface{}{},
+				"gridPos": dashboardGridPos(0, yPos, dashboardGridColumnCount, rowHeight),
+			}
+			for key, rowKey := range map[string]string{"title": "title", "collapsed": "collapse", "repeat": "repeat"} {
+				if value, ok := row[rowKey]; ok {
+					rowPanel[key] = value
+				}
+			}
+			nextRowID++
+			yPos++
+		}
+
+		area := newDashboardRowArea(rowHeight, yPos)
+		rowPanels, _ := row["panels"].([]interface{})
+		for _, p := range rowPanels {
+			panel, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			span, ok := jsonNumber(panel["span"])
+			if !ok || span == 0 {
+				span = dashboardDefaultPanelSpan
+			}
+			if minSpan, ok := jsonNumber(panel["minSpan"]); ok && minSpan != 0 {
+				panel["minSpan"] = math.Min(dashboardGridColumnCount, dashboardGridColumnCount/12*minSpan)
+			}
+			width := int(math.Floor(span)) * dashboardGridColumnCount / 12
+			height := rowHeight
+			if jsonTruthy(panel["height"]) {
+				height = dashboardGridHeight(panel["height"], dashboardDefaultRowHeight)
+			}
+
+			x, y := area.panelPosition(width, false)
+			yPos = area.yPos
+			panel["gridPos"] = dashboardGridPos(x, yPos+y, width, height)
+			area.addPanel(x, yPos+y, width, height)
+			delete(panel, "span")
+
+			if rowPanel != nil && collapsed {
+				rowPanel["panels"] = append(rowPanel["panels"].([]interface{}), panel)
+			} else {
+				panels = append(panels, panel)
+			}
+		}
+
+		if rowPanel != nil {
+			panels = append(panels, rowPanel)
+		}
+		if rowPanel == nil || !collapsed {
+			yPos += rowHeight
+		}
+	}
+
+	// Grafana sorts the panels by position when it loads a dashboard
+	sort.SliceStable(panels, func(i, j int) bool {
+		xi, yi := dashboardPanelPosition(panels[i])
+		xj, yj := dashboardPanelPosition(panels[j])
+		if yi == yj {
+			return xi < xj
+		}
+		return yi < yj
+	})
+	dashboard["panels"] = panels
+}
+
+// dashboardGridHeight converts a height in pixels (a number or a string such as `250px`) to a number of grid cells
+func dashboardGridHeight(height interface{}, defaultHeight float64) int {
+	pixels, ok := jsonNumber(height)
+	if s, isString := height.(string); isString {
+		var err error
+		pixels, err = strconv.ParseFloat(strings.TrimSuffix(s, "px"), 64)
+		pixels, ok = math.Trunc(pixels), err == nil
+	}
+	if !ok || pixels == 0 {
+		pixels = defaultHeight
+	}
+	if pixels < dashboardMinPanelHeight {
+		pixels = dashboardMinPanelHeight
+	}
+	return int(math.Ceil(pixels / dashboardPanelHeightStep))
+}
+
+func dashboardGridPos(x, y, w, h int) map[string]interface{} {
+	return map[string]interface{}{"x": x, "y": y, "w": w, "h": h}
+}
+
+func dashboardPanelPosition(p interface{}) (x, y float64) {
+	panel, _ := p.(map[string]interface{})
+	gridPos, _ := panel["gridPos"].(map[string]interface{})
+	x, _ = jsonNumber(gridPos["x"])
+	y, _ = jsonNumber(gridPos["y"])
+	return x, y
+}
+
+// dashboardRowArea tracks the height used by each column of a row while its panels are positioned, like Grafana does when it migrates rows.
+type dashboardRowArea struct {
+	area   []int
+	yPos   int
+	height int
+}
+
+func newDashboardRowArea(height, yPos int) *dashboardRowArea {
+	return &dashboardRowArea{area: make([]int, dashboardGridColumnCount), yPos: yPos, height: height}
+}
+
+func (a *dashboardRowArea) addPanel(x, y, w, h int) {
+	for i := x; i < x+w && i < len(a.area); i++ {
+		if a.area[i] == 0 || y+h-a.yPos > a.area[i] {
+			a.area[i] = y + h - a.yPos
+		}
+	}
+}
+
+// panelPosition returns the position of a panel of the given width, relative to the row area.
+// The panel is placed in the free space at the end of the area, or at the start of a new area if it doesn't fit.
+func (a *dashboardRowArea) panelPosition(width int, wrapped bool) (x, y int) {
+	start, end := -1, -1
+	for i := len(a.area) - 1; i >= 0; i-- {
+		if a.height-a.area[i] <= 0 {
+			break
+		}
+		if end == -1 {
+			end = i
+		} else if i < len(a.area)-1 && a.area[i] <= a.area[i+1] {
+			start = i
+		} else {
+			break
+		}
+	}
+	if start != -1 && end != -1 && end-start >= width-1 {
+		for _, height := range a.area[start:] {
+			y = max(y, height)
+		}
+		return start, y
+	}
+	if wrapped {
+		// The panel is wider than the grid
+		return 0, 0
+	}
+	a.yPos += a.height
+	a.area = make([]int, dashboardGridColumnCount)
+	return a.panelPosition(width, true)
+}
+
+// migratePanelMinSpan replaces the `minSpan` option of repeated panels by `maxPerRow`.
+func migratePanelMinSpan(panel map[string]interface{}) {
+	if minSpan, ok := jsonNumber(panel["minSpan"]); ok && minSpan != 0 {
+		maxPerRow := dashboardGridColumnCount / minSpan
+		factors := []float64{1, 2, 3, 4, 6, 8, 12, 24}
+		for i, factor := range factors {
+			if factor > maxPerRow {
+				if i > 0 {
+					panel["maxPerRow"] = factors[i-1]
+				}
+				break
+			}
+		}
+	}
+	delete(panel, "minSpan")
+}
+
+// migratePanelGaugeOptions moves the options of gauge panels from `options-gauge` to `options`.
+func migratePanelGaugeOptions(panel map[string]interface{}) {
+	options, ok := panel["options-gauge"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	valueOption := func(key string, defaultValue interface{}) interface{} {
+		if value := options[key]; jsonTruthy(value) {
+			return value
+		}
+		return defaultValue
+	}
+	options["valueOptions"] = map[string]interface{}{
+		"unit":     valueOption("unit", "none"),
+		"stat":     valueOption("stat", "avg"),
+		"decimals": valueOption("decimals", 0),
+		"prefix":   valueOption("prefix", ""),
+		"suffix":   valueOption("suffix", ""),
+	}
+	if thresholds, ok := options["thresholds"].([]interface{}); ok {
+		for i, j := 0, len(thresholds)-1; i < j; i, j = i+1, j-1 {
+			thresholds[i], thresholds[j] = thresholds[j], thresholds[i]
+		}
+	}
+	for _, key := range []string{"options", "unit", "stat", "decimals", "prefix", "suffix"} {
+		delete(options, key)
+	}
+	panel["options"] = options
+	delete(panel, "options-gauge")
+}
+
+var slugifyRegexps = []*regexp.Regexp{regexp.MustCompile(`[^\w ]+`), regexp.MustCompile(` +`)}
+
+// migratePanelLinks replaces the dashboard links of panels by URLs.
+func migratePanelLinks(panel map[string]interface{}) {
+	links, ok := panel["links"].([]interface{})
+	if !ok {
+		return
+	}
+	for i, l := range links {
+		link, _ := l.(map[string]interface{})
+		url, _ := link["url"].(string)
+		if dashboard, _ := link["dashboard"].(string); url == "" && dashboard != "" {
+			slug := slugifyRegexps[0].ReplaceAllString(strings.ToLower(dashboard), "")
+			url = "dashboard/db/" + slugifyRegexps[1].ReplaceAllString(slug, "-")
+		}
+		if dashURI, _ := link["dashUri"].(string); url == "" && dashURI != "" {
+			url = "dashboard/" + dashURI
+		}
+		if url == "" {
+			url = "/"
+		}
+		appendQuery := func(query string) {
+			if strings.Contains(url, "?") {
+				url += "&" + query
+			} else {
+				url += "?" + query
+			}
+		}
+		if jsonTruthy(link["keepTime"]) {
+			appendQuery("$__url_time_range")
+		}
+		if jsonTruthy(link["includeVars"]) {
+			appendQuery("$__all_variables")
+		}
+		if params, _ := link["params"].(string); params != "" {
+			appendQuery(params)
+		}
+
+		migrated := map[string]interface{}{"url": url}
+		for _, key := range []string{"title", "targetBlank"} {
+			if value, ok := link[key]; ok {
+				migrated[key] = value
+			}
+		}
+		links[i] = migrated
+	}
+}
+
+var legacyDataLinkVariablesRegexp = regexp.MustCompile(`(__series_name)|(\$__series_name)|(__value_time)|(__field_name)|(\$__field_name)`)
+
+// migratePanelDataLinksVariables replaces the legacy variables of data links.
+func migratePanelDataLinksVariables(panel map[string]interface{}) {
+	replacements := map[string]string{
+		"__series_name":  "__series.name",
+		"$__series_name": "${__series.name}",
+		"__value_time":   "__value.time",
+		"__field_name":   "__field.name",
+		"$__field_name":  "${__field.name}",
+	}
+	replace := func(s string) string {
+		return legacyDataLinkVariablesRegexp.ReplaceAllStringFunc(s, func(match string) string { return replacements[match] })
+	}
+	migratePanelDataLinks(panel, replace, true)
+}
+
+var seriesLabelsRegexp = regexp.MustCompile(`__series.labels`)
+
+// migratePanelDataLinksLabels replaces the series labels by the field labels in data links.
+func migratePanelDataLinksLabels(panel map[string]interface{}) {
+	migratePanelDataLinks(panel, func(s string) string { return seriesLabelsRegexp.ReplaceAllString(s, "__field.labels") }, false)
+}
+
+// migratePanelDataLinks applies a replacement to the URLs of the data links of graph panels and of panels with field options, and optionally to their field title.
+func migratePanelDataLinks(panel map[string]interface{}, replace func(string) string, title bool) {
+	options, _ := panel["options"].(map[string]interface{})
+	migrateLinks := func(links interface{}) {
+		list, _ := links.([]interface{})
+		for _, l := range list {
+			if link, ok := l.(map[string]interface{}); ok {
+				if url, ok := link["url"].(string); ok {
+					link["url"] = replace(url)
+				}
+			}
+		}
+	}
+	migrateLinks(options["dataLinks"])
+
+	fieldOptions, _ := options["fieldOptions"].(map[string]interface{})
+	if defaults, ok := fieldOptions["defaults"].(map[string]interface{}); ok {
+		migrateLinks(defaults["links"])
+		if fieldTitle, ok := defaults["title"].(string); ok && title {
+			defaults["title"] = replace(fieldTitle)
+		}
+	}
+}
+
+// migratePanelTableStylesAlign sets the alignment of the column styles of table panels to `auto`.
+func migratePanelTableStylesAlign(panel map[string]interface{}) {
+	if panel["type"] != "table" {
+		return
+	}
+	styles, _ := panel["styles"].([]interface{})
+	for _, s := range styles {
+		if style, ok := s.(map[string]interface{}); ok {
+			style["align"] = "auto"
+		}
+	}
+}
+
+// migrateVariableCurrentMulti aligns the current value of variables with their `multi` option.
+func migrateVariableCurrentMulti(variable map[string]interface{}) {
+	multi, ok := variable["multi"].(bool)
+	if !ok {
+		return
+	}
+	current, ok := variable["current"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	_, isList := current["value"].([]interface{})
+	switch {
+	case multi && !isList:
+		for _, key := range []string{"value", "text"} {
+			if _, ok := current[key].([]interface{}); !ok {
+				current[key] = []interface{}{current[key]}
+			}
+		}
+	case !multi && isList:
+		for _, key := range []string{"value", "text"} {
+			if list, ok := current[key].([]interface{}); ok {
+				current[key] = ""
+				if len(list) > 0 {
+					current[key] = list[0]
+				}
+			}
+		}
+	}
+}
+
+// migratePanelAngularTable renames the table panels that use column styles, which are only supported by the old table panel.
+func migratePanelAngularTable(panel map[string]interface{}) {
+	if panel["type"] != "table" || panel["styles"] == nil || panel["table"] == "table2" {
+		return
+	}
+	panel["type"] = "table-old"
+}
+
+// migratePanelText2 renames the `text2` panels to `text`.
+func migratePanelText2(panel map[string]interface{}) {
+	if panel["type"] != "text2" {
+		return
+	}
+	panel["type"] = "text"
+	if options, ok := panel["options"].(map[string]interface{}); ok {
+		delete(options, "angular")
+	}
+}
+
+// migrateVariableConstant converts the visible constant variables to text box variables, and hides the other constant variables.
+func migrateVariableConstant(variable map[string]interface{}) {
+	if variable["type"] != "constant" {
+		return
+	}
+	if hide, _ := jsonNumber(variable["hide"]); hide == 0 || hide == 1 {
+		variable["type"] = "textbox"
+		variable["originalQuery"] = variable["query"]
+		return
+	}
+	variable["options"] = []interface{}{variable["current"]}
+	variable["hide"] = 2
+}
+
+// removeJSONDefaults removes the fields of a JSON object that are set to their default value.
+func removeJSONDefaults(object, defaults map[string]interface{}) {
+	for key, defaultValue := range defaults {
+		value, ok := object[key]
+		if !ok {
+			continue
+		}
+		// The values are compared once encoded, since migrated values may not have the types of decoded JSON values
+		encodedValue, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		if encodedDefault, _ := json.Marshal(defaultValue); string(encodedValue) == string(encodedDefault) {
+			delete(object, key)
+		}
+	}
+}
+
+// jsonNumber returns the value of a decoded JSON number.
+func jsonNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// jsonTruthy returns whether a decoded JSON value is truthy, like in JavaScript.
+func jsonTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case int:
+		return v != 0
+	case string:
+		return v != ""
+	}
+	return true
+}
+
+func mustDecodeJSONObject(s string) map[string]interface{} {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &object); err != nil {
+		panic(err)
+	}
+	return object
+}
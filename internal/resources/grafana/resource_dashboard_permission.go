@@ -1,6 +1,9 @@
 package grafana
 
 import (
+	"context"
+
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
@@ -47,14 +50,14 @@ Manages the entire set of permissions for a dashboard. Permissions that aren't s
 	)
 }
 
-func resourceDashboardPermissionGet(d *schema.ResourceData, meta interface{}) (string, error) {
+func resourceDashboardPermissionGet(ctx context.Context, d *schema.ResourceData, meta interface{}) (string, error) {
 	client, _ := OAPIClientFromNewOrgResource(meta, d)
 	uid := d.Get("dashboard_uid").(string)
 	if d.Id() != "" {
 		client, _, uid = OAPIClientFromExistingOrgResource(meta, d.Id())
 	}
 
-	_, err := client.Dashboards.GetDashboardByUID(uid)
+	_, err := client.Dashboards.GetDashboardByUIDWithParams(dashboards.NewGetDashboardByUIDParams().WithUID(uid).WithContext(ctx))
 	if err != nil {
 		return "", err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -30,13 +31,14 @@ func makeResourceDashboardPermissionItem() *common.Resource {
 }
 
 type resourceDashboardPermissionItemModel struct {
-	ID           types.String `tfsdk:"id"`
-	OrgID        types.String `tfsdk:"org_id"`
-	Role         types.String `tfsdk:"role"`
-	Team         types.String `tfsdk:"team"`
-	User         types.String `tfsdk:"user"`
-	Permission   types.String `tfsdk:"permission"`
-	DashboardUID types.String `tfsdk:"dashboard_uid"`
+	ID           types.String             `tfsdk:"id"`
+	OrgID        types.String             `tfsdk:"org_id"`
+	Role         types.String             `tfsdk:"role"`
+	Team         types.String             `tfsdk:"team"`
+	User         types.String             `tfsdk:"user"`
+	Permission   types.String             `tfsdk:"permission"`
+	DashboardUID types.String             `tfsdk:"dashboard_uid"`
+	Timeouts     *common.ResourceTimeouts `tfsdk:"timeouts"`
 }
 
 // Framework doesn't support embedding a base struct: https://github.com/hashicorp/terraform-plugin-framework/issues/242
@@ -79,11 +81,14 @@ func (r *resourceDashboardPermissionItem) Schema(ctx context.Context, req resour
 				},
 			},
 		}),
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(),
+		},
 	}
 }
 
 func (r *resourceDashboardPermissionItem) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	readData, diags := r.readItem(req.ID, r.dashboardQuery)
+	readData, diags := r.readItem(ctx, req.ID, r.dashboardQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -106,7 +111,7 @@ func (r *resourceDashboardPermissionItem) Create(ctx context.Context, req resour
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.DashboardUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.DashboardUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -121,13 +126,13 @@ func (r *resourceDashboardPermissionItem) Read(ctx context.Context, req resource
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
 	// Read from API
-	readData, diags := r.readItem(data.ID.ValueString(), r.dashboardQuery)
+	readData, diags := r.readItem(ctx, data.ID.ValueString(), r.dashboardQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
 	if readData == nil {
-		resp.State.RemoveResource(ctx)
+		common.WarnMissingPluginFramework(ctx, "dashboard permission item", data.ID.ValueString(), resp)
 		return
 	}
 	data.SetFromBase(readData)
@@ -144,7 +149,7 @@ func (r *resourceDashboardPermissionItem) Update(ctx context.Context, req resour
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.DashboardUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.DashboardUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -159,12 +164,12 @@ func (r *resourceDashboardPermissionItem) Delete(ctx context.Context, req resour
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	data.Permission = types.StringValue("")
 
-	if diags := r.writeItem(data.DashboardUID.ValueString(), data.ToBase()); diags != nil {
+	if diags := r.writeItem(ctx, data.DashboardUID.ValueString(), data.ToBase()); diags != nil {
 		resp.Diagnostics = diags
 	}
 }
 
-func (r *resourceDashboardPermissionItem) dashboardQuery(client *client.GrafanaHTTPAPI, dashboardUID string) error {
-	_, err := client.Dashboards.GetDashboardByUID(dashboardUID)
+func (r *resourceDashboardPermissionItem) dashboardQuery(ctx context.Context, client *client.GrafanaHTTPAPI, dashboardUID string) error {
+	_, err := client.Dashboards.GetDashboardByUIDWithParams(dashboards.NewGetDashboardByUIDParams().WithUID(dashboardUID).WithContext(ctx))
 	return err
 }
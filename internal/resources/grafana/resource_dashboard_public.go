@@ -95,7 +95,7 @@ func CreatePublicDashboard(ctx context.Context, d *schema.ResourceData, meta int
 	dashboardUID := d.Get("dashboard_uid").(string)
 
 	publicDashboardPayload := makePublicDashboard(d)
-	resp, err := client.DashboardPublic.CreatePublicDashboard(dashboardUID, publicDashboardPayload)
+	resp, err := client.DashboardPublic.CreatePublicDashboardWithParams(dashboard_public.NewCreatePublicDashboardParams().WithDashboardUID(dashboardUID).WithBody(publicDashboardPayload).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -112,7 +112,8 @@ func UpdatePublicDashboard(ctx context.Context, d *schema.ResourceData, meta int
 	params := dashboard_public.NewUpdatePublicDashboardParams().
 		WithDashboardUID(dashboardUID).
 		WithUID(publicDashboardUID).
-		WithBody(publicDashboard)
+		WithBody(publicDashboard).
+		WithContext(ctx)
 	resp, err := client.DashboardPublic.UpdatePublicDashboard(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -126,7 +127,7 @@ func UpdatePublicDashboard(ctx context.Context, d *schema.ResourceData, meta int
 func DeletePublicDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, compositeID := OAPIClientFromExistingOrgResource(meta, d.Id())
 	dashboardUID, publicDashboardUID, _ := strings.Cut(compositeID, ":")
-	_, err := client.DashboardPublic.DeletePublicDashboard(publicDashboardUID, dashboardUID)
+	_, err := client.DashboardPublic.DeletePublicDashboardWithParams(dashboard_public.NewDeletePublicDashboardParams().WithUID(publicDashboardUID).WithDashboardUID(dashboardUID).WithContext(ctx))
 
 	return diag.FromErr(err)
 }
@@ -146,7 +147,7 @@ func ReadPublicDashboard(ctx context.Context, d *schema.ResourceData, meta inter
 	client, orgID, compositeID := OAPIClientFromExistingOrgResource(meta, d.Id())
 	dashboardUID, _, _ := strings.Cut(compositeID, ":")
 
-	resp, err := client.DashboardPublic.GetPublicDashboard(dashboardUID)
+	resp, err := client.DashboardPublic.GetPublicDashboardWithParams(dashboard_public.NewGetPublicDashboardParams().WithDashboardUID(dashboardUID).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("dashboard", d, err); shouldReturn {
 		return err
 	}
@@ -0,0 +1,264 @@
+package grafana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
+	"github.com/grafana/grafana-openapi-client-go/client/library_elements"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	dashboardReferenceModes = []string{"warn", "error"}
+
+	// builtInDatasourceRefs are the data sources that exist in every instance, by name or UID
+	builtInDatasourceRefs = map[string]bool{
+		"grafana":         true,
+		"-- Grafana --":   true,
+		"-- Mixed --":     true,
+		"-- Dashboard --": true,
+		"__expr__":        true,
+		"default":         true,
+	}
+
+	dashboardLinkPathRegexp = regexp.MustCompile(`(?:^|/)d/([^/?#]+)`)
+)
+
+// dashboardReferenceIssue is a reference of a dashboard to something that doesn't exist in the Grafana instance
+type dashboardReferenceIssue struct {
+	// path is the JSON path of the reference in the dashboard model (ex: `panels[0].targets[1].datasource`)
+	path    string
+	message string
+}
+
+func (i dashboardReferenceIssue) Error() string {
+	return fmt.Sprintf("%s: %s", i.path, i.message)
+}
+
+// customizeDiffDashboardReferences fails the plan if `validate_references` is `error` and the dashboard references something that doesn't exist.
+// The references of a `config_json` that isn't known yet are checked when the dashboard is saved.
+// Warnings can't be returned from a CustomizeDiff function, the `warn` mode is reported by dashboardReferencesPlanWarnings.
+func customizeDiffDashboardReferences(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("validate_references").(string) != "error" {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("config_json") && !d.HasChange("validate_references") {
+		return nil
+	}
+	metaClient := meta.(*common.Client)
+	if metaClient.GrafanaAPI == nil {
+		return nil
+	}
+	if !d.NewValueKnown("config_json") {
+		return nil
+	}
+	// The planned value is a hash if `store_dashboard_sha256` is set and the configuration didn't change, the configuration is always the JSON
+	configJSON := d.Get("config_json").(string)
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() {
+		if value := rawConfig.GetAttr("config_json"); value.IsKnown() && !value.IsNull() {
+			configJSON = value.AsString()
+		}
+	}
+	dashboardJSON, err := UnmarshalDashboardConfigJSON(configJSON)
+	if err != nil {
+		return nil // Reported by the ValidateFunc
+	}
+
+	client := metaClient.GrafanaAPI.Clone()
+	if orgID, _ := strconv.ParseInt(d.Get("org_id").(string), 10, 64); orgID > 0 {
+		client = common.GrafanaAPIWithOrgID(client, orgID)
+	}
+	issues, err := checkDashboardReferences(ctx, client, metaClient.GrafanaAPIURLParsed, dashboardJSON)
+	if err != nil {
+		return fmt.Errorf("failed to check the references of the dashboard: %w", err)
+	}
+	errs := make([]error, len(issues))
+	for i, issue := range issues {
+		errs[i] = issue
+	}
+	return errors.Join(errs...)
+}
+
+// dashboardReferencesPlanWarnings reports the references to things that don't exist as plan warnings, if `validate_references` is `warn`.
+// If `config_json` isn't known yet, they're reported when the dashboard is planned again during the apply, once it is known.
+func dashboardReferencesPlanWarnings(ctx context.Context, config cty.Value, meta interface{}) diag.Diagnostics {
+	mode, configJSON, orgID := config.GetAttr("validate_references"), config.GetAttr("config_json"), config.GetAttr("org_id")
+	if !mode.IsKnown() || mode.IsNull() || mode.AsString() != "warn" || !configJSON.IsKnown() || configJSON.IsNull() || !orgID.IsKnown() {
+		return nil
+	}
+	metaClient := meta.(*common.Client)
+	if metaClient.GrafanaAPI == nil {
+		return nil
+	}
+	dashboardJSON, err := UnmarshalDashboardConfigJSON(configJSON.AsString())
+	if err != nil {
+		return nil // Reported by the ValidateFunc
+	}
+
+	client := metaClient.GrafanaAPI
+	if !orgID.IsNull() {
+		if id, _ := strconv.ParseInt(orgID.AsString(), 10, 64); id > 0 {
+			client = common.GrafanaAPIWithOrgID(client, id)
+		}
+	}
+	issues, err := checkDashboardReferences(ctx, client, metaClient.GrafanaAPIURLParsed, dashboardJSON)
+	if err != nil {
+		return diag.Diagnostics{{Severity: diag.Warning, Summary: "Failed to check the references of the dashboard", Detail: err.Error()}}
+	}
+	return dashboardReferenceDiagnostics(issues, diag.Warning)
+}
+
+// checkDashboardReferencesBeforeSave fails the save of a dashboard that references something that doesn't exist, if `validate_references` is `error`.
+// This covers the dashboards whose `config_json` wasn't known during the plan. With `warn`, the references are reported in the plan instead.
+func checkDashboardReferencesBeforeSave(ctx context.Context, d *schema.ResourceData, meta interface{}, client *goapi.GrafanaHTTPAPI, dashboardJSON map[string]interface{}) diag.Diagnostics {
+	if d.Get("validate_references").(string) != "error" {
+		return nil
+	}
+	issues, err := checkDashboardReferences(ctx, client, meta.(*common.Client).GrafanaAPIURLParsed, dashboardJSON)
+	if err != nil {
+		return diag.Errorf("failed to check the references of the dashboard: %s", err)
+	}
+	return dashboardReferenceDiagnostics(issues, diag.Error)
+}
+
+func dashboardReferenceDiagnostics(issues []dashboardReferenceIssue, severity diag.Severity) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, issue := range issues {
+		diags = append(diags, diag.Diagnostic{
+			Severity: severity,
+			Summary:  fmt.Sprintf("Broken reference at %s", issue.path),
+			Detail:   issue.message,
+		})
+	}
+	return diags
+}
+
+// checkDashboardReferences returns the data sources, library panels and dashboard links of a dashboard that don't exist in the Grafana instance.
+// References that use variables can't be resolved and aren't checked. Links are only checked if they point to the Grafana instance.
+func checkDashboardReferences(ctx context.Context, client *goapi.GrafanaHTTPAPI, grafanaURL *url.URL, dashboardJSON map[string]interface{}) ([]dashboardReferenceIssue, error) {
+	type reference struct {
+		path, kind, target string
+	}
+	var references []reference
+	walkDashboardJSON("", dashboardJSON, func(path, key string, value interface{}) {
+		switch key {
+		case "datasource":
+			var ref string
+			switch v := value.(type) {
+			case string:
+				ref = v
+			case map[string]interface{}:
+				ref, _ = v["uid"].(string)
+			}
+			if ref != "" && !strings.Contains(ref, "$") && !builtInDatasourceRefs[ref] {
+				references = append(references, reference{path: path, kind: "data source", target: ref})
+			}
+		case "libraryPanel":
+			if v, ok := value.(map[string]interface{}); ok {
+				if uid, _ := v["uid"].(string); uid != "" {
+					references = append(references, reference{path: path + ".uid", kind: "library panel", target: uid})
+				}
+			}
+		case "url":
+			link, _ := value.(string)
+			if uid := dashboardLinkUID(link, grafanaURL); uid != "" && uid != dashboardJSON["uid"] {
+				references = append(references, reference{path: path, kind: "linked dashboard", target: uid})
+			}
+		}
+	})
+
+	// Data sources are listed once, library panels and dashboards are fetched once per UID
+	var datasourceRefs map[string]bool
+	exists := map[reference]bool{}
+	var issues []dashboardReferenceIssue
+	for _, ref := range references {
+		found, checked := exists[reference{kind: ref.kind, target: ref.target}]
+		if !checked {
+			var err error
+			switch ref.kind {
+			case "data source":
+				if datasourceRefs == nil {
+					resp, err := client.Datasources.GetDataSourcesWithParams(datasources.NewGetDataSourcesParams().WithContext(ctx))
+					if err != nil {
+						return nil, err
+					}
+					datasourceRefs = map[string]bool{}
+					for _, ds := range resp.Payload {
+						datasourceRefs[ds.UID] = true
+						datasourceRefs[ds.Name] = true
+					}
+				}
+				found = datasourceRefs[ref.target]
+			case "library panel":
+				_, err = client.LibraryElements.GetLibraryElementByUIDWithParams(library_elements.NewGetLibraryElementByUIDParams().WithLibraryElementUID(ref.target).WithContext(ctx))
+				found = err == nil
+			case "linked dashboard":
+				_, err = client.Dashboards.GetDashboardByUIDWithParams(dashboards.NewGetDashboardByUIDParams().WithUID(ref.target).WithContext(ctx))
+				found = err == nil
+			}
+			if err != nil && !common.IsNotFoundError(err) {
+				return nil, err
+			}
+			exists[reference{kind: ref.kind, target: ref.target}] = found
+		}
+		if !found {
+			issues = append(issues, dashboardReferenceIssue{path: ref.path, message: fmt.Sprintf("%s %q was not found", ref.kind, ref.target)})
+		}
+	}
+
+	return issues, nil
+}
+
+// dashboardLinkUID returns the UID of the dashboard that a link points to,
+// or an empty string if the link doesn't point to a dashboard of the Grafana instance or if the UID is a variable.
+func dashboardLinkUID(link string, grafanaURL *url.URL) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	if u.Host != "" && (grafanaURL == nil || !strings.EqualFold(u.Host, grafanaURL.Host)) {
+		return ""
+	}
+	match := dashboardLinkPathRegexp.FindStringSubmatch(u.Path)
+	if match == nil || strings.Contains(match[1], "$") {
+		return ""
+	}
+	return match[1]
+}
+
+// walkDashboardJSON calls visit for each key of the objects in a dashboard model, with the JSON path of the value.
+// Keys are visited in order so that the results are stable.
+func walkDashboardJSON(path string, value interface{}, visit func(path, key string, value interface{})) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			visit(keyPath, key, v[key])
+			walkDashboardJSON(keyPath, v[key], visit)
+		}
+	case []interface{}:
+		for i, item := range v {
+			walkDashboardJSON(fmt.Sprintf("%s[%d]", path, i), item, visit)
+		}
+	}
+}
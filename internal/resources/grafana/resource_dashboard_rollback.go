@@ -0,0 +1,136 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/grafana/grafana-openapi-client-go/client/dashboard_versions"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDashboardRollback() *common.Resource {
+	schema := &schema.Resource{
+
+		Description: `
+Pins a dashboard to one of its versions. The dashboard is restored to the given version when it is created, when the version changes,
+and whenever the dashboard is saved again after the restore (ex: when someone edits it in the UI).
+
+This is meant for dashboards that are managed in the UI. Do not use it on dashboards managed by the ` + "`grafana_dashboard`" + ` resource.
+Deleting this resource doesn't change the dashboard.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/manage-version-history/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard_versions/#restore-dashboard)
+`,
+
+		CreateContext: CreateDashboardRollback,
+		ReadContext:   ReadDashboardRollback,
+		UpdateContext: UpdateDashboardRollback,
+		DeleteContext: DeleteDashboardRollback,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"dashboard_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The UID of the dashboard to restore.",
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Required: true,
+				Description: "The version to restore the dashboard to. " +
+					"When the dashboard is saved after the restore, this is read as the version that the dashboard currently matches, so that the plan shows the restore.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"dashboard_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the dashboard. Restoring a version creates a new version of the dashboard.",
+			},
+		},
+	}
+
+	return common.NewLegacySDKResource(
+		"grafana_dashboard_rollback",
+		orgResourceIDString("dashboardUID"),
+		schema,
+	)
+}
+
+func CreateDashboardRollback(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, orgID := OAPIClientFromNewOrgResource(meta, d)
+	d.SetId(MakeOrgResourceID(orgID, d.Get("dashboard_uid").(string)))
+	return UpdateDashboardRollback(ctx, d, meta)
+}
+
+func UpdateDashboardRollback(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
+	version := int64(d.Get("version").(int))
+
+	latest, err := getLatestDashboardVersion(ctx, meta, d.Id())
+	if err != nil {
+		return diag.Errorf("failed to get the latest version of dashboard %s: %s", uid, err)
+	}
+	// Restoring the version that the dashboard already matches would only add a version
+	if dashboardMatchesVersion(latest) != version {
+		body := models.RestoreDashboardVersionCommand{Version: version}
+		params := dashboard_versions.NewRestoreDashboardVersionByUIDParams().WithUID(uid).WithBody(&body).WithContext(ctx)
+		if _, err := client.DashboardVersions.RestoreDashboardVersionByUIDWithParams(params); err != nil {
+			return diag.Errorf("failed to restore dashboard %s to version %d: %s", uid, version, err)
+		}
+	}
+
+	return ReadDashboardRollback(ctx, d, meta)
+}
+
+func ReadDashboardRollback(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	orgID, uid := SplitOrgResourceID(d.Id())
+
+	latest, err := getLatestDashboardVersion(ctx, meta, d.Id())
+	if err, shouldReturn := common.CheckReadError("dashboard", d, err); shouldReturn {
+		return err
+	}
+
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	d.Set("dashboard_uid", uid)
+	d.Set("version", dashboardMatchesVersion(latest))
+	d.Set("dashboard_version", latest.Version)
+
+	return nil
+}
+
+func DeleteDashboardRollback(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Restores can't be undone, the dashboard is left as is
+	return nil
+}
+
+func getLatestDashboardVersion(ctx context.Context, meta interface{}, id string) (*models.DashboardVersionMeta, error) {
+	client, _, uid := OAPIClientFromExistingOrgResource(meta, id)
+	limit := int64(1)
+	params := dashboard_versions.NewGetDashboardVersionsByUIDParams().WithUID(uid).WithLimit(&limit).WithContext(ctx)
+	resp, err := client.DashboardVersions.GetDashboardVersionsByUID(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.GetPayload()) == 0 {
+		return nil, fmt.Errorf("dashboard %s has no versions", uid)
+	}
+	return resp.GetPayload()[0], nil
+}
+
+// dashboardMatchesVersion returns the version that the dashboard's content comes from, given its latest version.
+// This is the version that was restored if the latest version was created by a restore.
+func dashboardMatchesVersion(latest *models.DashboardVersionMeta) int64 {
+	if latest.RestoredFrom != 0 {
+		return latest.RestoredFrom
+	}
+	return latest.Version
+}
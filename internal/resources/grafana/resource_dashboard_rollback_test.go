@@ -0,0 +1,118 @@
+package grafana_test
+
+import (
+	"fmt"
+	"testing"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDashboardRollback_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	uid := acctest.RandString(10)
+	client := func() *goapi.GrafanaHTTPAPI {
+		return testutils.Provider.Meta().(*common.Client).GrafanaAPI.WithOrgID(1)
+	}
+	t.Cleanup(func() {
+		client().Dashboards.DeleteDashboardByUID(uid) //nolint:errcheck
+	})
+
+	resource.ParallelTest(t, testDashboardRollbackTestCase(t, client, uid))
+}
+
+func TestMockDashboardRollback_basic(t *testing.T) {
+	testutils.IsUnitTest(t)
+	mock := testutils.NewMockGrafana(t)
+
+	resource.UnitTest(t, testDashboardRollbackTestCase(t, mock.Client, "rollback"))
+}
+
+// testDashboardRollbackTestCase saves two versions of a dashboard, restores the first one,
+// then checks that the dashboard is restored again after it's edited outside of Terraform
+func testDashboardRollbackTestCase(t *testing.T, client func() *goapi.GrafanaHTTPAPI, uid string) resource.TestCase {
+	saveDashboard := func(title string) {
+		body := models.SaveDashboardCommand{
+			Dashboard: map[string]interface{}{"uid": uid, "title": title},
+			Overwrite: true,
+		}
+		if _, err := client().Dashboards.PostDashboard(&body); err != nil {
+			t.Fatalf("failed to save dashboard %s: %s", uid, err)
+		}
+	}
+	checkTitle := func(expected string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			resp, err := client().Dashboards.GetDashboardByUID(uid)
+			if err != nil {
+				return err
+			}
+			if title := resp.Payload.Dashboard.(map[string]interface{})["title"]; title != expected {
+				return fmt.Errorf("expected dashboard title to be %q, got %q", expected, title)
+			}
+			return nil
+		}
+	}
+
+	config := fmt.Sprintf(`
+resource "grafana_dashboard_rollback" "test" {
+	dashboard_uid = "%[1]s"
+	version       = 1
+}
+
+data "grafana_dashboard_versions" "test" {
+	dashboard_uid = "%[1]s"
+	depends_on    = [grafana_dashboard_rollback.test]
+}
+`, uid)
+
+	return resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					saveDashboard("Version 1")
+					saveDashboard("Version 2")
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard_rollback.test", "id", "1:"+uid),
+					resource.TestCheckResourceAttr("grafana_dashboard_rollback.test", "version", "1"),
+					resource.TestCheckResourceAttr("grafana_dashboard_rollback.test", "dashboard_version", "3"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.#", "3"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.version", "3"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.restored_from", "1"),
+					checkTitle("Version 1"),
+				),
+			},
+			// Nothing changes if the dashboard isn't saved again
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			// The dashboard is edited outside of Terraform, it is restored again
+			{
+				PreConfig: func() {
+					saveDashboard("Version 4")
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard_rollback.test", "version", "1"),
+					resource.TestCheckResourceAttr("grafana_dashboard_rollback.test", "dashboard_version", "5"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.#", "5"),
+					checkTitle("Version 1"),
+				),
+			},
+			{
+				ResourceName:      "grafana_dashboard_rollback.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,186 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana-openapi-client-go/client/snapshots"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDashboardSnapshot() *common.Resource {
+	schema := &schema.Resource{
+
+		Description: `
+Manages a dashboard snapshot. A snapshot is a copy of a dashboard model, including the data of its panels, that can be shared without access to the data sources.
+Snapshots can't be updated, any change creates a new snapshot.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/share-dashboards-panels/#publish-a-snapshot)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/snapshot/)
+`,
+
+		CreateContext: CreateDashboardSnapshot,
+		ReadContext:   ReadDashboardSnapshot,
+		DeleteContext: DeleteDashboardSnapshot,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"config_json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				StateFunc:        NormalizeDashboardConfigJSON,
+				DiffSuppressFunc: diffSuppressDashboardConfigJSON,
+				ValidateFunc:     validateDashboardConfigJSON,
+				Description: "The dashboard model JSON to take the snapshot of. " +
+					"The data of the panels is read from their `snapshotData` attribute, like in the snapshots taken in the Grafana UI. Panels without data are shown empty.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the snapshot. Defaults to `Unnamed snapshot`.",
+			},
+			"expires": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  0,
+				Description: "The number of seconds after which the snapshot expires. The snapshot never expires if set to 0. " +
+					"Expired snapshots are deleted by Grafana and are created again by the next apply.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"external": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Save the snapshot on the external snapshot server configured in Grafana (`external_snapshot_url`) instead of the Grafana instance.",
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The key of the snapshot, used in its URL. Generated by Grafana if not set.",
+				ValidateFunc: validation.StringMatch(common.UIDRegexp, "must only contain letters, numbers, dashes and underscores"),
+			},
+			"delete_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				Description:  "The key that allows deleting the snapshot without authentication, through `delete_url`. Generated by Grafana if not set. It isn't known for imported snapshots.",
+				ValidateFunc: validation.StringMatch(common.UIDRegexp, "must only contain letters, numbers, dashes and underscores"),
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the snapshot.",
+			},
+			"delete_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The URL that deletes the snapshot without authentication. It isn't known for imported snapshots.",
+			},
+		},
+	}
+
+	return common.NewLegacySDKResource(
+		"grafana_dashboard_snapshot",
+		orgResourceIDString("key"),
+		schema,
+	)
+}
+
+func CreateDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+
+	dashboardJSON, err := UnmarshalDashboardConfigJSON(d.Get("config_json").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// The dashboard is sent as is, the Unstructured model of the OpenAPI client would wrap it in an `Object` attribute
+	body := map[string]interface{}{
+		"dashboard": dashboardJSON,
+		"name":      d.Get("name").(string),
+		"expires":   d.Get("expires").(int),
+		"external":  d.Get("external").(bool),
+		"key":       d.Get("key").(string),
+		"deleteKey": d.Get("delete_key").(string),
+	}
+	var resp models.CreateDashboardSnapshotOKBody
+	if err := common.SubmitGrafanaJSON(ctx, client, meta.(*common.Client).GrafanaAPIConfig.Schemes, http.MethodPost, "/snapshots", body, &resp); err != nil {
+		return diag.Errorf("failed to create dashboard snapshot: %s", err)
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, resp.Key))
+	d.Set("delete_key", resp.DeleteKey)
+	d.Set("url", resp.URL)
+	d.Set("delete_url", resp.DeleteURL)
+	return ReadDashboardSnapshot(ctx, d, meta)
+}
+
+func ReadDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID, key := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	var snapshot struct {
+		Dashboard map[string]interface{} `json:"dashboard"`
+	}
+	err := common.SubmitGrafanaJSON(ctx, client, meta.(*common.Client).GrafanaAPIConfig.Schemes, http.MethodGet, "/snapshots/"+key, nil, &snapshot)
+	if err, shouldReturn := common.CheckReadError("dashboard snapshot", d, err); shouldReturn {
+		return err
+	}
+
+	// The name and the external URL are only returned by the search, which matches names that contain the query
+	query := d.Get("name").(string)
+	params := snapshots.NewSearchDashboardSnapshotsParams().WithQuery(&query).WithContext(ctx)
+	resp, err := client.Snapshots.SearchDashboardSnapshots(params)
+	if err != nil {
+		return diag.Errorf("failed to search dashboard snapshots: %s", err)
+	}
+	for _, s := range resp.Payload {
+		if s.Key != key {
+			continue
+		}
+		d.Set("name", s.Name)
+		d.Set("external", s.External)
+		if s.External {
+			d.Set("url", s.ExternalURL)
+		} else if d.Get("url").(string) == "" {
+			d.Set("url", meta.(*common.Client).GrafanaAPIURLParsed.JoinPath("dashboard", "snapshot", key).String())
+		}
+	}
+
+	// Grafana returns the model that was sent, but it is only read on import so that a snapshot is never replaced because of how the model is returned
+	if d.Get("config_json").(string) == "" {
+		configJSON, err := json.Marshal(snapshot.Dashboard)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("config_json", NormalizeDashboardConfigJSON(string(configJSON)))
+	}
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	d.Set("key", key)
+
+	return nil
+}
+
+func DeleteDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, key := OAPIClientFromExistingOrgResource(meta, d.Id())
+	_, deleteErr := client.Snapshots.DeleteDashboardSnapshotWithParams(snapshots.NewDeleteDashboardSnapshotParams().WithKey(key).WithContext(ctx))
+	err, _ := common.CheckReadError("dashboard snapshot", d, deleteErr)
+	return err
+}
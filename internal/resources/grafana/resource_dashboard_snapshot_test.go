@@ -0,0 +1,143 @@
+package grafana_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDashboardSnapshot_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	client := func() *goapi.GrafanaHTTPAPI {
+		return testutils.Provider.Meta().(*common.Client).GrafanaAPI.WithOrgID(1)
+	}
+	var dashboard models.DashboardFullWithMeta
+	var key string
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			dashboardCheckExists.destroyed(&dashboard, nil),
+			checkDashboardSnapshotDestroyed(client, &key),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_dashboard_snapshot/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					dashboardCheckExists.exists("grafana_dashboard.incident", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.postmortem", "name", "Postmortem: Incident Overview"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.postmortem", "expires", "2592000"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.postmortem", "external", "false"),
+					resource.TestMatchResourceAttr("grafana_dashboard_snapshot.postmortem", "id", regexp.MustCompile(`^1:[a-zA-Z0-9]+$`)),
+					resource.TestMatchResourceAttr("grafana_dashboard_snapshot.postmortem", "url", regexp.MustCompile(`/dashboard/snapshot/[a-zA-Z0-9]+$`)),
+					resource.TestCheckResourceAttrSet("grafana_dashboard_snapshot.postmortem", "delete_key"),
+					resource.TestCheckResourceAttrSet("grafana_dashboard_snapshot.postmortem", "delete_url"),
+					resource.TestCheckResourceAttrWith("grafana_dashboard_snapshot.postmortem", "key", func(value string) error {
+						key = value
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:            "grafana_dashboard_snapshot.postmortem",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"delete_key", "delete_url", "expires"},
+			},
+		},
+	})
+}
+
+func TestMockDashboardSnapshot_basic(t *testing.T) {
+	testutils.IsUnitTest(t)
+	mock := testutils.NewMockGrafana(t)
+
+	key := "postmortem"
+	config := func(title string) string {
+		return fmt.Sprintf(`
+resource "grafana_dashboard_snapshot" "test" {
+	key         = "postmortem"
+	delete_key  = "postmortem-delete"
+	external    = true
+	config_json = jsonencode({
+		title  = "%s"
+		panels = [{ id = 1, type = "stat", snapshotData = [{ fields = [] }] }]
+	})
+}
+`, title)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             checkDashboardSnapshotDestroyed(mock.Client, &key),
+		Steps: []resource.TestStep{
+			{
+				Config: config("Incident"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.test", "id", "1:postmortem"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.test", "name", "Unnamed snapshot"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.test", "external", "true"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.test", "url", "https://snapshots.example.com/dashboard/snapshot/postmortem"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.test", "delete_key", "postmortem-delete"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.test", "delete_url", "https://snapshots.example.com/api/snapshots-delete/postmortem-delete"),
+				),
+			},
+			{
+				ResourceName:            "grafana_dashboard_snapshot.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"delete_key", "delete_url"},
+			},
+			// Snapshots can't be updated, a change replaces the snapshot
+			{
+				Config: config("Incident (updated)"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.test", "id", "1:postmortem"),
+					checkDashboardSnapshotTitle(mock.Client, key, "Incident (updated)"),
+				),
+			},
+		},
+	})
+}
+
+// checkDashboardSnapshotDestroyed checks that the snapshot with the given key doesn't exist
+func checkDashboardSnapshotDestroyed(client func() *goapi.GrafanaHTTPAPI, key *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if *key == "" {
+			return fmt.Errorf("the snapshot key wasn't read")
+		}
+		err := common.SubmitGrafanaJSON(context.Background(), client(), nil, http.MethodGet, "/snapshots/"+*key, nil, nil)
+		if err == nil {
+			return fmt.Errorf("snapshot %s still exists", *key)
+		}
+		if !common.IsNotFoundError(err) {
+			return err
+		}
+		return nil
+	}
+}
+
+// checkDashboardSnapshotTitle checks the title of the dashboard saved in a snapshot
+func checkDashboardSnapshotTitle(client func() *goapi.GrafanaHTTPAPI, key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var snapshot struct {
+			Dashboard map[string]interface{} `json:"dashboard"`
+		}
+		if err := common.SubmitGrafanaJSON(context.Background(), client(), nil, http.MethodGet, "/snapshots/"+key, nil, &snapshot); err != nil {
+			return err
+		}
+		if title := snapshot.Dashboard["title"]; title != expected {
+			return fmt.Errorf("expected snapshot %s to have title %q, got %q", key, expected, title)
+		}
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package grafana_test
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -93,6 +94,156 @@ func TestAccDashboard_basic(t *testing.T) {
 	}
 }
 
+// TestMockDashboard_storeSHA256PerProvider checks that `store_dashboard_sha256` only applies to the dashboards of the provider that sets it
+func TestMockDashboard_storeSHA256PerProvider(t *testing.T) {
+	testutils.IsUnitTest(t)
+	testutils.NewMockGrafana(t)
+
+	config := func(storeSHA256 bool) string {
+		return fmt.Sprintf(`
+provider "grafana" {
+	alias                  = "other"
+	store_dashboard_sha256 = %t
+}
+
+resource "grafana_dashboard" "json" {
+	config_json = jsonencode({ title = "JSON" })
+}
+
+resource "grafana_dashboard" "other" {
+	provider    = grafana.other
+	config_json = jsonencode({ title = "Other" })
+}
+`, storeSHA256)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard.json", "config_json", `{"title":"JSON"}`),
+					resource.TestCheckResourceAttr("grafana_dashboard.other", "config_json", "dd5c6d4b89901fb82b56a0112dc213da7813facbccd2610b6e6711df13c62742"), //nolint:gosec
+				),
+			},
+			{
+				// Toggling the setting updates the state without changing the dashboard
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard.json", "config_json", `{"title":"JSON"}`),
+					resource.TestCheckResourceAttr("grafana_dashboard.other", "config_json", `{"title":"Other"}`),
+					resource.TestCheckResourceAttr("grafana_dashboard.other", "version", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestMockDashboard_migrateSchema checks that a legacy dashboard reaches a stable state after one apply when `migrate_schema` is set
+func TestMockDashboard_migrateSchema(t *testing.T) {
+	testutils.IsUnitTest(t)
+	testutils.NewMockGrafana(t)
+
+	config := `
+resource "grafana_dashboard" "legacy" {
+	migrate_schema = true
+	config_json = jsonencode({
+		title           = "Legacy"
+		uid             = "legacy"
+		schemaVersion   = 13
+		sharedCrosshair = true
+		editable        = true
+		rows = [
+			{ panels = [{ id = 1, type = "text2", span = 6 }, { id = 2, type = "graph", span = 6, transparent = false }] },
+		]
+	})
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.TestCheckResourceAttr("grafana_dashboard.legacy", "config_json",
+					`{"graphTooltip":1,"panels":[{"gridPos":{"h":7,"w":12,"x":0,"y":0},"type":"text"},{"gridPos":{"h":7,"w":12,"x":12,"y":0},"type":"graph"}],"schemaVersion":27,"title":"Legacy","uid":"legacy"}`,
+				),
+			},
+			{
+				// The migrated dashboard matches the legacy configuration
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestMockDashboard_validateReferences(t *testing.T) {
+	testutils.IsUnitTest(t)
+	testutils.NewMockGrafana(t)
+
+	references := `
+resource "grafana_data_source" "prometheus" {
+	type = "prometheus"
+	name = "Prometheus"
+	url  = "http://localhost:9090"
+}
+
+resource "grafana_library_panel" "panel" {
+	name       = "Library Panel"
+	model_json = jsonencode({ title = "Library Panel", type = "stat" })
+}
+
+resource "grafana_dashboard" "linked" {
+	config_json = jsonencode({ title = "Linked", uid = "linked" })
+}
+`
+	dashboard := func(validateReferences, datasourceUID, libraryPanelUID, linkedUID string) string {
+		return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+	validate_references = "%s"
+	config_json = jsonencode({
+		title = "References"
+		panels = [
+			{ datasource = { uid = %s }, targets = [{ datasource = { uid = "$${ds}" } }] },
+			{ libraryPanel = { uid = %s, name = "Library Panel" } },
+		]
+		links = [
+			{ url = "/d/${%s}/linked?var-env=$${env}" },
+			{ url = "https://other.grafana.net/d/external" },
+		]
+	})
+}
+`, validateReferences, datasourceUID, libraryPanelUID, linkedUID)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			// The references don't exist, the plan fails
+			{
+				Config: dashboard("error", `"prometheus"`, `"library-panel"`, `"linked"`),
+				ExpectError: regexp.MustCompile(`(?s)` +
+					regexp.QuoteMeta(`links[0].url: linked dashboard "linked" was not found`) + `.*` +
+					regexp.QuoteMeta(`panels[0].datasource: data source "prometheus" was not found`) + `.*` +
+					regexp.QuoteMeta(`panels[1].libraryPanel.uid: library panel "library-panel" was not found`),
+				),
+			},
+			// The references are created in the same apply, they are checked once they exist
+			{
+				Config: references + dashboard("error", "grafana_data_source.prometheus.uid", "grafana_library_panel.panel.uid", "grafana_dashboard.linked.uid"),
+				Check:  resource.TestCheckResourceAttrSet("grafana_dashboard.test", "uid"),
+			},
+			// Broken references are only reported as warnings
+			{
+				Config: references + dashboard("warn", `"prometheus"`, "grafana_library_panel.panel.uid", "grafana_dashboard.linked.uid"),
+				Check:  resource.TestCheckResourceAttrSet("grafana_dashboard.test", "uid"),
+			},
+		},
+	})
+}
+
 func TestAccDashboard_uid_unset(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
@@ -313,6 +464,138 @@ func Test_NormalizeDashboardConfigJSON(t *testing.T) {
 	}
 }
 
+func Test_MigrateDashboardConfigJSON(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	tests := []struct {
+		name   string
+		config interface{}
+		want   string
+	}{
+		{
+			name:   "Defaults are removed",
+			config: `{"title":"New Dashboard","editable":true,"style":"dark","tags":[],"panels":[{"type":"text","options":{},"transparent":false}]}`,
+			want:   `{"panels":[{"type":"text"}],"title":"New Dashboard"}`,
+		},
+		{
+			name:   "Dashboard without schema version isn't migrated",
+			config: `{"title":"New Dashboard","sharedCrosshair":true}`,
+			want:   `{"sharedCrosshair":true,"title":"New Dashboard"}`,
+		},
+		{
+			name:   "Shared crosshair is migrated to graph tooltip",
+			config: `{"schemaVersion":13,"sharedCrosshair":true}`,
+			want:   `{"graphTooltip":1,"schemaVersion":27}`,
+		},
+		{
+			name:   "Rows are migrated to panels",
+			config: `{"schemaVersion":14,"rows":[{"panels":[{"id":1,"type":"graph","span":6},{"id":2,"type":"graph","span":6}]}]}`,
+			want:   `{"panels":[{"gridPos":{"h":7,"w":12,"x":0,"y":0},"type":"graph"},{"gridPos":{"h":7,"w":12,"x":12,"y":0},"type":"graph"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "Collapsed rows keep their panels",
+			config: `{"schemaVersion":14,"rows":[{"title":"Details","collapse":true,"panels":[{"id":1,"type":"text2","span":4,"minSpan":3}]}]}`,
+			want:   `{"panels":[{"collapsed":true,"gridPos":{"h":7,"w":24,"x":0,"y":0},"panels":[{"gridPos":{"h":7,"w":8,"x":0,"y":1},"id":1,"maxPerRow":4,"type":"text"}],"title":"Details","type":"row"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "Older schema versions aren't migrated",
+			config: `{"schemaVersion":12,"sharedCrosshair":true}`,
+			want:   `{"schemaVersion":12,"sharedCrosshair":true}`,
+		},
+		{
+			name:   "15: No changes",
+			config: `{"schemaVersion":14,"panels":[{"type":"graph","title":"A"}]}`,
+			want:   `{"panels":[{"title":"A","type":"graph"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "17: Min span is migrated to max per row",
+			config: `{"schemaVersion":16,"panels":[{"type":"graph","repeat":"host","minSpan":8},{"type":"graph","minSpan":0}]}`,
+			want:   `{"panels":[{"maxPerRow":3,"repeat":"host","type":"graph"},{"type":"graph"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "18: Gauge options are moved",
+			config: `{"schemaVersion":17,"panels":[{"type":"gauge","options-gauge":{"unit":"ms","stat":"max","decimals":2,"maxValue":100,"thresholds":[{"value":80},{"value":0}],"options":{}}}]}`,
+			want:   `{"panels":[{"options":{"maxValue":100,"thresholds":[{"value":0},{"value":80}],"valueOptions":{"decimals":2,"prefix":"","stat":"max","suffix":"","unit":"ms"}},"type":"gauge"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "19: Panel links are migrated to URLs",
+			config: `{"schemaVersion":18,"panels":[{"type":"graph","links":[{"type":"dashboard","dashboard":"My Dashboard!","keepTime":true,"includeVars":true,"params":"a=b","title":"Go","targetBlank":true},{"type":"absolute","url":"https://example.com?x=1","keepTime":true},{"dashUri":"db/other"}]}]}`,
+			want:   `{"panels":[{"links":[{"targetBlank":true,"title":"Go","url":"dashboard/db/my-dashboard?$__url_time_range\u0026$__all_variables\u0026a=b"},{"url":"https://example.com?x=1\u0026$__url_time_range"},{"url":"dashboard/db/other"}],"type":"graph"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "20: Data link variables are renamed",
+			config: `{"schemaVersion":19,"panels":[{"type":"graph","options":{"dataLinks":[{"url":"/d?n=$__series_name&t=__value_time&f=__field_name"}]}},{"type":"stat","options":{"fieldOptions":{"defaults":{"title":"$__field_name","links":[{"url":"/d?n=__series_name"}]}}}}]}`,
+			want:   `{"panels":[{"options":{"dataLinks":[{"url":"/d?n=${__series.name}\u0026t=__value.time\u0026f=__field.name"}]},"type":"graph"},{"options":{"fieldOptions":{"defaults":{"links":[{"url":"/d?n=__series.name"}],"title":"${__field.name}"}}},"type":"stat"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "21: Data link series labels are replaced by field labels",
+			config: `{"schemaVersion":20,"panels":[{"type":"stat","options":{"fieldOptions":{"defaults":{"title":"__series.labels.host","links":[{"url":"/d?h=${__series.labels.host}"}]}}}}]}`,
+			want:   `{"panels":[{"options":{"fieldOptions":{"defaults":{"links":[{"url":"/d?h=${__field.labels.host}"}],"title":"__series.labels.host"}}},"type":"stat"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "22: Table column styles are aligned automatically",
+			config: `{"schemaVersion":21,"panels":[{"type":"table","table":"table2","styles":[{"pattern":"Time","align":"left"}]},{"type":"graph","styles":[{"align":"left"}]}]}`,
+			want:   `{"panels":[{"styles":[{"align":"auto","pattern":"Time"}],"table":"table2","type":"table"},{"styles":[{"align":"left"}],"type":"graph"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "23: Variable values match the multi option",
+			config: `{"schemaVersion":22,"templating":{"list":[{"name":"a","multi":true,"current":{"text":"x","value":"x"}},{"name":"b","multi":false,"current":{"text":["y","z"],"value":["y","z"]}},{"name":"c","multi":false,"current":{"text":[],"value":[]}},{"name":"d","current":{"text":["w"],"value":["w"]}}]}}`,
+			want:   `{"schemaVersion":27,"templating":{"list":[{"current":{"text":["x"],"value":["x"]},"multi":true,"name":"a"},{"current":{"text":"y","value":"y"},"multi":false,"name":"b"},{"current":{"text":"","value":""},"multi":false,"name":"c"},{"current":{"text":["w"],"value":["w"]},"name":"d"}]}}`,
+		},
+		{
+			name:   "24: Table panels with styles are renamed",
+			config: `{"schemaVersion":23,"panels":[{"type":"table","styles":[]},{"type":"table"},{"type":"table","table":"table2","styles":[]}]}`,
+			want:   `{"panels":[{"styles":[],"type":"table-old"},{"type":"table"},{"styles":[],"table":"table2","type":"table"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "25: No changes",
+			config: `{"schemaVersion":24,"panels":[{"type":"table-old","styles":[{"align":"left"}]}]}`,
+			want:   `{"panels":[{"styles":[{"align":"left"}],"type":"table-old"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "26: Text2 panels are renamed",
+			config: `{"schemaVersion":25,"panels":[{"type":"text2","options":{"angular":{"content":"x"},"mode":"markdown"}}]}`,
+			want:   `{"panels":[{"options":{"mode":"markdown"},"type":"text"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "27: Constant variables are migrated",
+			config: `{"schemaVersion":26,"templating":{"list":[{"type":"constant","name":"a","query":"x","hide":1},{"type":"constant","name":"b","query":"y","hide":2,"current":{"text":"y","value":"y"}},{"type":"query","name":"c","query":"z"}]}}`,
+			want:   `{"schemaVersion":27,"templating":{"list":[{"hide":1,"name":"a","originalQuery":"x","query":"x","type":"textbox"},{"current":{"text":"y","value":"y"},"hide":2,"name":"b","options":[{"text":"y","value":"y"}],"query":"y","type":"constant"},{"name":"c","query":"z","type":"query"}]}}`,
+		},
+		{
+			name:   "27: Current schema version isn't changed",
+			config: `{"schemaVersion":27,"panels":[{"type":"text2","transparent":false}]}`,
+			want:   `{"panels":[{"type":"text2"}],"schemaVersion":27}`,
+		},
+		{
+			name:   "Newer schema versions aren't changed",
+			config: `{"schemaVersion":39,"panels":[{"type":"text2"}]}`,
+			want:   `{"panels":[{"type":"text2"}],"schemaVersion":39}`,
+		},
+		{
+			name:   "Version and id are removed",
+			config: map[string]interface{}{"title": "New Dashboard", "id": 10, "version": 3},
+			want:   `{"title":"New Dashboard"}`,
+		},
+		{
+			name:   "Bad json is ignored",
+			config: "74D93920-ED26–11E3-AC10–0800200C9A66",
+			want:   "74D93920-ED26–11E3-AC10–0800200C9A66",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grafana.MigrateDashboardConfigJSON(tt.config)
+			if got != tt.want {
+				t.Errorf("MigrateDashboardConfigJSON() = %v, want %v", got, tt.want)
+			}
+			if again := grafana.MigrateDashboardConfigJSON(got); again != got {
+				t.Errorf("MigrateDashboardConfigJSON() isn't stable: %v, then %v", got, again)
+			}
+		})
+	}
+}
+
 func testAccDashboardFolder(uid string, folderRef string) string {
 	return fmt.Sprintf(`
 resource "grafana_folder" "test_folder1" {
@@ -8,9 +8,9 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
@@ -128,6 +128,16 @@ func datasourceHTTPHeadersAttribute() *schema.Schema {
 	}
 }
 
+// datasourceJSONData is the `json_data_encoded` and `secure_json_data_encoded` fields.
+var datasourceJSONData = common.NormalizedJSON{}
+
+func datasourceJSONDataDiffSuppressFunc(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	if oldValue == "{}" && newValue == "" {
+		return true
+	}
+	return datasourceJSONData.DiffSuppressFunc(k, oldValue, newValue, d)
+}
+
 func datasourceJSONDataAttribute() *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeString,
@@ -141,16 +151,8 @@ func datasourceJSONDataAttribute() *schema.Schema {
 			}
 			return validation.StringIsJSON(i, s)
 		},
-		StateFunc: func(v interface{}) string {
-			json, _ := structure.NormalizeJsonString(v)
-			return json
-		},
-		DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-			if oldValue == "{}" && newValue == "" {
-				return true
-			}
-			return common.SuppressEquivalentJSONDiffs(k, oldValue, newValue, d)
-		},
+		StateFunc:        datasourceJSONData.StateFunc,
+		DiffSuppressFunc: datasourceJSONDataDiffSuppressFunc,
 	}
 }
 
@@ -168,29 +170,21 @@ func datasourceSecureJSONDataAttribute() *schema.Schema {
 			}
 			return validation.StringIsJSON(i, s)
 		},
-		StateFunc: func(v interface{}) string {
-			json, _ := structure.NormalizeJsonString(v)
-			return json
-		},
-		DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-			if oldValue == "{}" && newValue == "" {
-				return true
-			}
-			return common.SuppressEquivalentJSONDiffs(k, oldValue, newValue, d)
-		},
+		StateFunc:        datasourceJSONData.StateFunc,
+		DiffSuppressFunc: datasourceJSONDataDiffSuppressFunc,
 	}
 }
 
 func listDatasources(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
-	orgIDs, err := data.OrgIDs(client)
+	orgIDs, err := data.OrgIDs(ctx, client)
 	if err != nil {
 		return nil, err
 	}
 
 	var ids []string
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
-		resp, err := client.Datasources.GetDataSources()
+		client = common.GrafanaAPIWithOrgID(client, orgID)
+		resp, err := client.Datasources.GetDataSourcesWithParams(datasources.NewGetDataSourcesParams().WithContext(ctx))
 		if err != nil {
 			return nil, err
 		}
@@ -212,7 +206,7 @@ func CreateDataSource(ctx context.Context, d *schema.ResourceData, meta interfac
 		return diag.FromErr(err)
 	}
 
-	resp, err := client.Datasources.AddDataSource(dataSource)
+	resp, err := client.Datasources.AddDataSourceWithParams(datasources.NewAddDataSourceParams().WithBody(dataSource).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -244,7 +238,7 @@ func UpdateDataSource(ctx context.Context, d *schema.ResourceData, meta interfac
 		User:            dataSource.User,
 		WithCredentials: dataSource.WithCredentials,
 	}
-	_, err = client.Datasources.UpdateDataSourceByUID(idStr, &body)
+	_, err = client.Datasources.UpdateDataSourceByUIDWithParams(datasources.NewUpdateDataSourceByUIDParams().WithUID(idStr).WithBody(&body).WithContext(ctx))
 
 	return diag.FromErr(err)
 }
@@ -253,7 +247,7 @@ func UpdateDataSource(ctx context.Context, d *schema.ResourceData, meta interfac
 func ReadDataSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	resp, err := client.Datasources.GetDataSourceByUID(idStr)
+	resp, err := client.Datasources.GetDataSourceByUIDWithParams(datasources.NewGetDataSourceByUIDParams().WithUID(idStr).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("datasource", d, err); shouldReturn {
 		return err
 	}
@@ -265,7 +259,7 @@ func ReadDataSource(ctx context.Context, d *schema.ResourceData, meta interface{
 func DeleteDataSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	_, err := client.Datasources.DeleteDataSourceByUID(idStr)
+	_, err := client.Datasources.DeleteDataSourceByUIDWithParams(datasources.NewDeleteDataSourceByUIDParams().WithUID(idStr).WithContext(ctx))
 	diag, _ := common.CheckReadError("datasource", d, err)
 	return diag
 }
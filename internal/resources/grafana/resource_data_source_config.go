@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -53,7 +54,7 @@ func resourceDataSourceConfig() *common.Resource {
 
 func UpdateDataSourceConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _ := OAPIClientFromNewOrgResource(meta, d)
-	if diag := updateGrafanaDataSourceConfig(d, d.Get("uid").(string), client); diag.HasError() {
+	if diag := updateGrafanaDataSourceConfig(ctx, d, d.Get("uid").(string), client); diag.HasError() {
 		return diag
 	}
 	return ReadDataSourceConfig(ctx, d, meta)
@@ -62,7 +63,7 @@ func UpdateDataSourceConfig(ctx context.Context, d *schema.ResourceData, meta in
 func ReadDataSourceConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	resp, err := client.Datasources.GetDataSourceByUID(idStr)
+	resp, err := client.Datasources.GetDataSourceByUIDWithParams(datasources.NewGetDataSourceByUIDParams().WithUID(idStr).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("datasource", d, err); shouldReturn {
 		return err
 	}
@@ -75,11 +76,11 @@ func ReadDataSourceConfig(ctx context.Context, d *schema.ResourceData, meta inte
 func DeleteDataSourceConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 	d.Set("json_data_encoded", "")
-	return updateGrafanaDataSourceConfig(d, idStr, client)
+	return updateGrafanaDataSourceConfig(ctx, d, idStr, client)
 }
 
-func updateGrafanaDataSourceConfig(d *schema.ResourceData, dataSourceUID string, client *goapi.GrafanaHTTPAPI) diag.Diagnostics {
-	resp, err := client.Datasources.GetDataSourceByUID(dataSourceUID)
+func updateGrafanaDataSourceConfig(ctx context.Context, d *schema.ResourceData, dataSourceUID string, client *goapi.GrafanaHTTPAPI) diag.Diagnostics {
+	resp, err := client.Datasources.GetDataSourceByUIDWithParams(datasources.NewGetDataSourceByUIDParams().WithUID(dataSourceUID).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -106,6 +107,6 @@ func updateGrafanaDataSourceConfig(d *schema.ResourceData, dataSourceUID string,
 		JSONData:        jd,
 		SecureJSONData:  sd,
 	}
-	_, err = client.Datasources.UpdateDataSourceByUID(dataSourceUID, &body)
+	_, err = client.Datasources.UpdateDataSourceByUIDWithParams(datasources.NewUpdateDataSourceByUIDParams().WithUID(dataSourceUID).WithBody(&body).WithContext(ctx))
 	return diag.FromErr(err)
 }
@@ -1,6 +1,9 @@
 package grafana
 
 import (
+	"context"
+
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
@@ -42,16 +45,16 @@ Manages the entire set of permissions for a datasource. Permissions that aren't
 		"grafana_data_source_permission",
 		orgResourceIDInt("datasourceID"),
 		schema,
-	)
+	).WithGrafanaRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
-func resourceDatasourcePermissionGet(d *schema.ResourceData, meta interface{}) (string, error) {
+func resourceDatasourcePermissionGet(ctx context.Context, d *schema.ResourceData, meta interface{}) (string, error) {
 	client, _ := OAPIClientFromNewOrgResource(meta, d)
 	_, id := SplitOrgResourceID(d.Get("datasource_uid").(string))
 	if d.Id() != "" {
 		client, _, id = OAPIClientFromExistingOrgResource(meta, d.Id())
 	}
-	resp, err := client.Datasources.GetDataSourceByUID(id)
+	resp, err := client.Datasources.GetDataSourceByUIDWithParams(datasources.NewGetDataSourceByUIDParams().WithUID(id).WithContext(ctx))
 	if err != nil {
 		return "", err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -26,17 +27,18 @@ func makeResourceDatasourcePermissionItem() *common.Resource {
 			resourceType: datasourcesPermissionsType,
 		},
 	}
-	return common.NewResource(resourceDatasourcePermissionItemName, resourceDatasourcePermissionItemID, resourceStruct)
+	return common.NewResource(resourceDatasourcePermissionItemName, resourceDatasourcePermissionItemID, resourceStruct).WithGrafanaRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 type resourceDatasourcePermissionItemModel struct {
-	ID            types.String `tfsdk:"id"`
-	OrgID         types.String `tfsdk:"org_id"`
-	Role          types.String `tfsdk:"role"`
-	Team          types.String `tfsdk:"team"`
-	User          types.String `tfsdk:"user"`
-	Permission    types.String `tfsdk:"permission"`
-	DatasourceUID types.String `tfsdk:"datasource_uid"`
+	ID            types.String             `tfsdk:"id"`
+	OrgID         types.String             `tfsdk:"org_id"`
+	Role          types.String             `tfsdk:"role"`
+	Team          types.String             `tfsdk:"team"`
+	User          types.String             `tfsdk:"user"`
+	Permission    types.String             `tfsdk:"permission"`
+	DatasourceUID types.String             `tfsdk:"datasource_uid"`
+	Timeouts      *common.ResourceTimeouts `tfsdk:"timeouts"`
 }
 
 // Framework doesn't support embedding a base struct: https://github.com/hashicorp/terraform-plugin-framework/issues/242
@@ -79,11 +81,14 @@ func (r *resourceDatasourcePermissionItem) Schema(ctx context.Context, req resou
 				},
 			},
 		}),
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(),
+		},
 	}
 }
 
 func (r *resourceDatasourcePermissionItem) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	readData, diags := r.readItem(req.ID, r.datasourceQuery)
+	readData, diags := r.readItem(ctx, req.ID, r.datasourceQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -106,7 +111,7 @@ func (r *resourceDatasourcePermissionItem) Create(ctx context.Context, req resou
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.DatasourceUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.DatasourceUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -121,13 +126,13 @@ func (r *resourceDatasourcePermissionItem) Read(ctx context.Context, req resourc
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
 	// Read from API
-	readData, diags := r.readItem(data.ID.ValueString(), r.datasourceQuery)
+	readData, diags := r.readItem(ctx, data.ID.ValueString(), r.datasourceQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
 	if readData == nil {
-		resp.State.RemoveResource(ctx)
+		common.WarnMissingPluginFramework(ctx, "data source permission item", data.ID.ValueString(), resp)
 		return
 	}
 	data.SetFromBase(readData)
@@ -144,7 +149,7 @@ func (r *resourceDatasourcePermissionItem) Update(ctx context.Context, req resou
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.DatasourceUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.DatasourceUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -159,12 +164,12 @@ func (r *resourceDatasourcePermissionItem) Delete(ctx context.Context, req resou
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	data.Permission = types.StringValue("")
 
-	if diags := r.writeItem(data.DatasourceUID.ValueString(), data.ToBase()); diags != nil {
+	if diags := r.writeItem(ctx, data.DatasourceUID.ValueString(), data.ToBase()); diags != nil {
 		resp.Diagnostics = diags
 	}
 }
 
-func (r *resourceDatasourcePermissionItem) datasourceQuery(client *client.GrafanaHTTPAPI, datasourceUID string) error {
-	_, err := client.Datasources.GetDataSourceByUID(datasourceUID)
+func (r *resourceDatasourcePermissionItem) datasourceQuery(ctx context.Context, client *client.GrafanaHTTPAPI, datasourceUID string) error {
+	_, err := client.Datasources.GetDataSourceByUIDWithParams(datasources.NewGetDataSourceByUIDParams().WithUID(datasourceUID).WithContext(ctx))
 	return err
 }
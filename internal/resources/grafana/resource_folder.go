@@ -3,6 +3,8 @@ package grafana
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"log"
 	"regexp"
 	"strconv"
 
@@ -64,11 +66,11 @@ func resourceFolder() *common.Resource {
 			"parent_folder_uid": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Description: "The uid of the parent folder. " +
 					"If set, the folder will be nested. " +
 					"If not set, the folder will be created in the root folder. " +
-					"Note: This requires the nestedFolders feature flag to be enabled on your Grafana instance.",
+					"Changing it moves the folder, along with its contents, to the new parent folder. " +
+					"Note: Before Grafana 11, this requires the nestedFolders feature flag to be enabled on your Grafana instance.",
 			},
 		},
 	}
@@ -81,7 +83,7 @@ func resourceFolder() *common.Resource {
 }
 
 func listFolders(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
-	return listDashboardOrFolder(client, data, "dash-folder")
+	return listDashboardOrFolder(ctx, client, data, "dash-folder")
 }
 
 func CreateFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -100,7 +102,7 @@ func CreateFolder(ctx context.Context, d *schema.ResourceData, meta interface{})
 		body.ParentUID = parentUID.(string)
 	}
 
-	resp, err := client.Folders.CreateFolder(&body)
+	resp, err := client.Folders.CreateFolderWithParams(folders.NewCreateFolderParams().WithBody(&body).WithContext(ctx))
 	if err != nil {
 		return diag.Errorf("failed to create folder: %s", err)
 	}
@@ -114,28 +116,56 @@ func CreateFolder(ctx context.Context, d *schema.ResourceData, meta interface{})
 func UpdateFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	folder, err := GetFolderByIDorUID(client.Folders, idStr)
+	folder, err := GetFolderByIDorUID(ctx, client.Folders, idStr)
 	if err != nil {
 		return diag.Errorf("failed to get folder %s: %s", idStr, err)
 	}
 
+	if d.HasChange("parent_folder_uid") {
+		// Keep the previous parent in the state if the folder can't be moved
+		d.Partial(true)
+		if err := checkFolderMoveSupported(ctx, meta); err != nil {
+			return diag.FromErr(err)
+		}
+		body := models.MoveFolderCommand{ParentUID: d.Get("parent_folder_uid").(string)}
+		if _, err := client.Folders.MoveFolderWithParams(folders.NewMoveFolderParams().WithFolderUID(folder.UID).WithBody(&body).WithContext(ctx)); err != nil {
+			return diag.Errorf("failed to move folder %s: %s", folder.UID, err)
+		}
+		d.Partial(false)
+	}
+
 	body := models.UpdateFolderCommand{
 		Overwrite: true,
 		Title:     d.Get("title").(string),
 	}
 
-	if _, err := client.Folders.UpdateFolder(folder.UID, &body); err != nil {
+	if _, err := client.Folders.UpdateFolderWithParams(folders.NewUpdateFolderParams().WithFolderUID(folder.UID).WithBody(&body).WithContext(ctx)); err != nil {
 		return diag.FromErr(err)
 	}
 
 	return ReadFolder(ctx, d, meta)
 }
 
+// checkFolderMoveSupported returns an error if the Grafana instance can't move folders to another parent folder.
+// Nothing is checked if the instance information can't be fetched, the move API call will report the actual error.
+func checkFolderMoveSupported(ctx context.Context, meta interface{}) error {
+	info, err := meta.(*common.Client).GrafanaInstanceInfo(ctx)
+	if err != nil {
+		log.Printf("[WARN] not checking whether the Grafana instance can move folders: %v", err)
+		return nil
+	}
+	if !info.HasNestedFolders() {
+		return fmt.Errorf("moving a folder to another parent folder requires nested folders, which are not enabled on this Grafana instance (version %s). "+
+			"Enable the nestedFolders feature toggle or upgrade to Grafana 11, where it is enabled by default", info.VersionString)
+	}
+	return nil
+}
+
 func ReadFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	metaClient := meta.(*common.Client)
 	client, orgID, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	folder, err := GetFolderByIDorUID(client.Folders, idStr)
+	folder, err := GetFolderByIDorUID(ctx, client.Folders, idStr)
 	if err, shouldReturn := common.CheckReadError("folder", d, err); shouldReturn {
 		return err
 	}
@@ -152,10 +182,10 @@ func ReadFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 
 func DeleteFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
-	deleteParams := folders.NewDeleteFolderParams().WithFolderUID(uid)
+	deleteParams := folders.NewDeleteFolderParams().WithFolderUID(uid).WithContext(ctx)
 	if d.Get("prevent_destroy_if_not_empty").(bool) {
 		searchType := "dash-db"
-		searchParams := search.NewSearchParams().WithFolderUIDs([]string{uid}).WithType(&searchType)
+		searchParams := search.NewSearchParams().WithFolderUIDs([]string{uid}).WithType(&searchType).WithContext(ctx)
 		searchResp, err := client.Search.Search(searchParams)
 		if err != nil {
 			return diag.Errorf("failed to search for dashboards in folder: %s", err)
@@ -212,12 +242,12 @@ func NormalizeFolderConfigJSON(configI interface{}) string {
 	return string(ret)
 }
 
-func GetFolderByIDorUID(client folders.ClientService, id string) (*models.Folder, error) {
+func GetFolderByIDorUID(ctx context.Context, client folders.ClientService, id string) (*models.Folder, error) {
 	// If the ID is a number, find the folder UID
 	// Getting the folder by ID is broken in some versions, but getting by UID works in all versions
 	// We need to use two API calls in the numerical ID case, because the "list" call doesn't have all the info
 	if numericalID, err := strconv.ParseInt(id, 10, 64); err == nil {
-		resp, err := client.GetFolderByID(numericalID)
+		resp, err := client.GetFolderByIDWithParams(folders.NewGetFolderByIDParams().WithFolderID(numericalID).WithContext(ctx))
 		if err != nil && !common.IsNotFoundError(err) {
 			return nil, err
 		} else if err == nil {
@@ -225,7 +255,7 @@ func GetFolderByIDorUID(client folders.ClientService, id string) (*models.Folder
 		}
 	}
 
-	resp, err := client.GetFolderByUID(id)
+	resp, err := client.GetFolderByUIDWithParams(folders.NewGetFolderByUIDParams().WithFolderUID(id).WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,9 @@
 package grafana
 
 import (
+	"context"
+
+	"github.com/grafana/grafana-openapi-client-go/client/folders"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
@@ -47,13 +50,13 @@ Manages the entire set of permissions for a folder. Permissions that aren't spec
 	)
 }
 
-func resourceFolderPermissionGet(d *schema.ResourceData, meta interface{}) (string, error) {
+func resourceFolderPermissionGet(ctx context.Context, d *schema.ResourceData, meta interface{}) (string, error) {
 	client, _ := OAPIClientFromNewOrgResource(meta, d)
 	uid := d.Get("folder_uid").(string)
 	if d.Id() != "" {
 		client, _, uid = OAPIClientFromExistingOrgResource(meta, d.Id())
 	}
-	resp, err := client.Folders.GetFolderByUID(uid)
+	resp, err := client.Folders.GetFolderByUIDWithParams(folders.NewGetFolderByUIDParams().WithFolderUID(uid).WithContext(ctx))
 	if err != nil {
 		return "", err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/folders"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -30,13 +31,14 @@ func makeResourceFolderPermissionItem() *common.Resource {
 }
 
 type resourceFolderPermissionItemModel struct {
-	ID         types.String `tfsdk:"id"`
-	OrgID      types.String `tfsdk:"org_id"`
-	Role       types.String `tfsdk:"role"`
-	Team       types.String `tfsdk:"team"`
-	User       types.String `tfsdk:"user"`
-	Permission types.String `tfsdk:"permission"`
-	FolderUID  types.String `tfsdk:"folder_uid"`
+	ID         types.String             `tfsdk:"id"`
+	OrgID      types.String             `tfsdk:"org_id"`
+	Role       types.String             `tfsdk:"role"`
+	Team       types.String             `tfsdk:"team"`
+	User       types.String             `tfsdk:"user"`
+	Permission types.String             `tfsdk:"permission"`
+	FolderUID  types.String             `tfsdk:"folder_uid"`
+	Timeouts   *common.ResourceTimeouts `tfsdk:"timeouts"`
 }
 
 // Framework doesn't support embedding a base struct: https://github.com/hashicorp/terraform-plugin-framework/issues/242
@@ -81,11 +83,14 @@ func (r *resourceFolderPermissionItem) Schema(ctx context.Context, req resource.
 				},
 			},
 		}),
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(),
+		},
 	}
 }
 
 func (r *resourceFolderPermissionItem) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	readData, diags := r.readItem(req.ID, r.folderQuery)
+	readData, diags := r.readItem(ctx, req.ID, r.folderQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -108,7 +113,7 @@ func (r *resourceFolderPermissionItem) Create(ctx context.Context, req resource.
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.FolderUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.FolderUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -123,13 +128,13 @@ func (r *resourceFolderPermissionItem) Read(ctx context.Context, req resource.Re
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
 	// Read from API
-	readData, diags := r.readItem(data.ID.ValueString(), r.folderQuery)
+	readData, diags := r.readItem(ctx, data.ID.ValueString(), r.folderQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
 	if readData == nil {
-		resp.State.RemoveResource(ctx)
+		common.WarnMissingPluginFramework(ctx, "folder permission item", data.ID.ValueString(), resp)
 		return
 	}
 	data.SetFromBase(readData)
@@ -146,7 +151,7 @@ func (r *resourceFolderPermissionItem) Update(ctx context.Context, req resource.
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.FolderUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.FolderUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -161,12 +166,12 @@ func (r *resourceFolderPermissionItem) Delete(ctx context.Context, req resource.
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	data.Permission = types.StringValue("")
 
-	if diags := r.writeItem(data.FolderUID.ValueString(), data.ToBase()); diags != nil {
+	if diags := r.writeItem(ctx, data.FolderUID.ValueString(), data.ToBase()); diags != nil {
 		resp.Diagnostics = diags
 	}
 }
 
-func (r *resourceFolderPermissionItem) folderQuery(client *client.GrafanaHTTPAPI, folderUID string) error {
-	_, err := client.Folders.GetFolderByUID(folderUID)
+func (r *resourceFolderPermissionItem) folderQuery(ctx context.Context, client *client.GrafanaHTTPAPI, folderUID string) error {
+	_, err := client.Folders.GetFolderByUIDWithParams(folders.NewGetFolderByUIDParams().WithFolderUID(folderUID).WithContext(ctx))
 	return err
 }
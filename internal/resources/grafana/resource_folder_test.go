@@ -1,6 +1,7 @@
 package grafana_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -97,6 +98,136 @@ func TestAccFolder_basic(t *testing.T) {
 	})
 }
 
+// TestMockFolder_basic runs the folder lifecycle against the mock Grafana server, so it doesn't need a running Grafana instance
+func TestMockFolder_basic(t *testing.T) {
+	testutils.IsUnitTest(t)
+	testutils.NewMockGrafana(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_folder/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("grafana_folder.test_folder", "id", defaultOrgIDRegexp),
+					resource.TestMatchResourceAttr("grafana_folder.test_folder", "uid", common.UIDRegexp),
+					resource.TestCheckResourceAttr("grafana_folder.test_folder", "title", "Terraform Test Folder"),
+					resource.TestCheckResourceAttrPair("grafana_dashboard.test_folder", "folder", "grafana_folder.test_folder", "uid"),
+					resource.TestCheckResourceAttr("grafana_folder.test_folder_with_uid", "uid", "test-folder-uid"),
+				),
+			},
+			{
+				ResourceName:            "grafana_folder.test_folder_with_uid",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty"},
+			},
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_folder/resource.tf", map[string]string{
+					"Terraform Test Folder": "Terraform Test Folder Updated",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_folder.test_folder", "title", "Terraform Test Folder Updated"),
+				),
+			},
+		},
+	})
+}
+
+// TestMockFolder_move checks that changing the parent of a folder moves it instead of recreating it
+func TestMockFolder_move(t *testing.T) {
+	testutils.IsUnitTest(t)
+	mock := testutils.NewMockGrafana(t)
+
+	config := func(parent string) string {
+		return fmt.Sprintf(`
+resource grafana_folder parent1 {
+	title = "Parent 1"
+}
+
+resource grafana_folder parent2 {
+	title = "Parent 2"
+}
+
+resource grafana_folder child {
+	uid               = "child"
+	title             = "Child"
+	parent_folder_uid = grafana_folder.%s.uid
+}
+`, parent)
+	}
+
+	var childID int64
+	checkChildID := func(s *terraform.State) error {
+		resp, err := mock.Client().Folders.GetFolderByUID("child")
+		if err != nil {
+			return err
+		}
+		if childID == 0 {
+			childID = resp.Payload.ID
+		} else if resp.Payload.ID != childID {
+			return fmt.Errorf("the folder was recreated: ID %d -> %d", childID, resp.Payload.ID)
+		}
+		return nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("parent1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("grafana_folder.child", "parent_folder_uid", "grafana_folder.parent1", "uid"),
+					checkChildID,
+				),
+			},
+			{
+				Config: config("parent2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("grafana_folder.child", "parent_folder_uid", "grafana_folder.parent2", "uid"),
+					checkChildID,
+				),
+			},
+		},
+	})
+}
+
+func TestMockFolder_moveWithoutNestedFolders(t *testing.T) {
+	testutils.IsUnitTest(t)
+	mock := testutils.NewMockGrafana(t)
+	mock.FeatureToggles = nil
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource grafana_folder parent {
+	title = "Parent"
+}
+
+resource grafana_folder child {
+	title             = "Child"
+	parent_folder_uid = grafana_folder.parent.uid
+}
+`,
+			},
+			{
+				Config: `
+resource grafana_folder parent {
+	title = "Parent"
+}
+
+resource grafana_folder child {
+	title = "Child"
+}
+`,
+				ExpectError: regexp.MustCompile(`moving a folder to another parent folder requires nested folders`),
+			},
+		},
+	})
+}
+
 func TestAccFolder_nested(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">=10.3.0")
 
@@ -289,7 +420,7 @@ func testAccFolderWasntRecreated(rn string, oldFolder *models.Folder) resource.T
 		}
 		orgID, folderUID := grafana.SplitOrgResourceID(newFolderResource.Primary.ID)
 		client := testutils.Provider.Meta().(*common.Client).GrafanaAPI.WithOrgID(orgID)
-		newFolder, err := grafana.GetFolderByIDorUID(client.Folders, folderUID)
+		newFolder, err := grafana.GetFolderByIDorUID(context.Background(), client.Folders, folderUID)
 		if err != nil {
 			return fmt.Errorf("error getting folder: %s", err)
 		}
@@ -3,266 +3,619 @@ package grafana
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
+	"github.com/grafana/grafana-openapi-client-go/client/library_elements"
 	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 )
 
-func resourceLibraryPanel() *common.Resource {
-	schema := &schema.Resource{
+var (
+	resourceLibraryPanelName = "grafana_library_panel"
+	resourceLibraryPanelID   = orgResourceIDString("uid")
+
+	// Check interface
+	_ resource.ResourceWithImportState  = (*resourceLibraryPanel)(nil)
+	_ resource.ResourceWithModifyPlan   = (*resourceLibraryPanel)(nil)
+	_ resource.ResourceWithUpgradeState = (*resourceLibraryPanel)(nil)
+)
+
+func makeResourceLibraryPanel() *common.Resource {
+	return common.NewResource(
+		resourceLibraryPanelName,
+		resourceLibraryPanelID,
+		&resourceLibraryPanel{},
+	)
+}
+
+type resourceLibraryPanelModel struct {
+	ID              types.String               `tfsdk:"id"`
+	OrgID           types.String               `tfsdk:"org_id"`
+	UID             types.String               `tfsdk:"uid"`
+	PanelID         types.Int64                `tfsdk:"panel_id"`
+	FolderUID       types.String               `tfsdk:"folder_uid"`
+	Name            types.String               `tfsdk:"name"`
+	Description     types.String               `tfsdk:"description"`
+	Type            types.String               `tfsdk:"type"`
+	ModelJSON       common.NormalizedJSONValue `tfsdk:"model_json"`
+	Version         types.Int64                `tfsdk:"version"`
+	FolderName      types.String               `tfsdk:"folder_name"`
+	Created         types.String               `tfsdk:"created"`
+	Updated         types.String               `tfsdk:"updated"`
+	DashboardIDs    types.List                 `tfsdk:"dashboard_ids"`
+	DashboardUIDs   types.List                 `tfsdk:"dashboard_uids"`
+	ForceDisconnect types.Bool                 `tfsdk:"force_disconnect"`
+	Timeouts        *common.ResourceTimeouts   `tfsdk:"timeouts"`
+}
 
-		Description: `
+type resourceLibraryPanel struct {
+	basePluginFrameworkResource
+}
+
+func (r *resourceLibraryPanel) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = resourceLibraryPanelName
+}
+
+func (r *resourceLibraryPanel) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
 Manages Grafana library panels.
 
+Changes to the model of a library panel apply to all the dashboards that use it. The plan shows a warning listing these dashboards.
+Library panels that are used by dashboards can't be deleted, unless ` + "`force_disconnect`" + ` is set.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/manage-library-panels/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/library_element/)
 `,
-
-		CreateContext: createLibraryPanel,
-		ReadContext:   readLibraryPanel,
-		UpdateContext: updateLibraryPanel,
-		DeleteContext: deleteLibraryPanel,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-
-		Schema: map[string]*schema.Schema{
-			"org_id": orgIDAttribute(),
-			"uid": {
-				Type:     schema.TypeString,
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": pluginFrameworkOrgIDAttribute(),
+			"uid": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
 				Description: "The unique identifier (UID) of a library panel uniquely identifies library panels between multiple Grafana installs. " +
 					"It’s automatically generated unless you specify it during library panel creation." +
 					"The UID provides consistent URLs for accessing library panels and when syncing library panels between multiple Grafana installs.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
-			"panel_id": {
-				Type:        schema.TypeInt,
+			"panel_id": schema.Int64Attribute{
 				Computed:    true,
 				Description: "The numeric ID of the library panel computed by Grafana.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
-			"folder_uid": {
-				Type:        schema.TypeString,
+			"folder_uid": schema.StringAttribute{
 				Optional:    true,
 				Description: "Unique ID (UID) of the folder containing the library panel.",
-				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					_, old = SplitOrgResourceID(old)
-					_, new = SplitOrgResourceID(new)
-					return old == new
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z0-9\-\_]+$`), "folder UIDs can only be alphanumeric, dashes, or underscores"),
 				},
-				ValidateFunc: folderUIDValidation,
 			},
-			"name": {
-				Type:        schema.TypeString,
+			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "Name of the library panel.",
 			},
-			"description": {
-				Type:        schema.TypeString,
+			"description": schema.StringAttribute{
 				Computed:    true,
 				Description: "Description of the library panel.",
 			},
-			"type": {
-				Type:        schema.TypeString,
+			"type": schema.StringAttribute{
 				Computed:    true,
 				Description: "Type of the library panel (eg. text).",
 			},
-			"model_json": {
-				Type:         schema.TypeString,
-				Required:     true,
-				StateFunc:    normalizeLibraryPanelModelJSON,
-				ValidateFunc: validateLibraryPanelModelJSON,
-				Description:  "The JSON model for the library panel.",
+			"model_json": schema.StringAttribute{
+				Required:    true,
+				CustomType:  libraryPanelModelJSON.FrameworkType(),
+				Description: "The JSON model for the library panel.",
 			},
-			"version": {
-				Type:        schema.TypeInt,
+			"version": schema.Int64Attribute{
 				Computed:    true,
 				Description: "Version of the library panel.",
 			},
-			"folder_name": {
-				Type:        schema.TypeString,
+			"folder_name": schema.StringAttribute{
 				Computed:    true,
 				Description: "Name of the folder containing the library panel.",
 			},
-			"created": {
-				Type:        schema.TypeString,
+			"created": schema.StringAttribute{
 				Computed:    true,
 				Description: "Timestamp when the library panel was created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"updated": {
-				Type:        schema.TypeString,
+			"updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "Timestamp when the library panel was last modified.",
 			},
-			"dashboard_ids": {
-				Type:        schema.TypeList,
+			"dashboard_ids": schema.ListAttribute{
+				Computed:           true,
+				ElementType:        types.Int64Type,
+				Description:        "Numerical IDs of Grafana dashboards containing the library panel.",
+				DeprecationMessage: "Use `dashboard_uids` instead. Numerical dashboard IDs are deprecated in Grafana.",
+			},
+			"dashboard_uids": schema.ListAttribute{
 				Computed:    true,
-				Description: "Numerical IDs of Grafana dashboards containing the library panel.",
-				Elem:        &schema.Schema{Type: schema.TypeInt},
+				ElementType: types.StringType,
+				Description: "UIDs of the Grafana dashboards containing the library panel.",
+			},
+			"force_disconnect": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "Disconnect the library panel from the dashboards that use it when it is deleted, instead of failing. " +
+					"The panels of these dashboards are replaced by a copy of the library panel model. Defaults to `false`. " +
+					"The value of the state is used on deletion, so it must be applied before the library panel is removed from the configuration or replaced.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(),
+		},
 	}
+}
 
-	return common.NewLegacySDKResource(
-		"grafana_library_panel",
-		orgResourceIDString("uid"),
-		schema,
-	)
+// UpgradeState upgrades the state of the SDK version of the resource (version 0).
+func (r *resourceLibraryPanel) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeLibraryPanelStateV0},
+	}
 }
 
-func createLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := OAPIClientFromNewOrgResource(meta, d)
+// upgradeLibraryPanelStateV0 removes the `description` and `type` fields that the SDK version of the resource added to `model_json` when they were missing.
+// The model is otherwise stored as it was normalized, which matches the configuration when it uses `jsonencode`.
+func upgradeLibraryPanelStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var old struct {
+		ID           string  `json:"id"`
+		OrgID        string  `json:"org_id"`
+		UID          string  `json:"uid"`
+		PanelID      int64   `json:"panel_id"`
+		FolderUID    string  `json:"folder_uid"`
+		Name         string  `json:"name"`
+		Description  string  `json:"description"`
+		Type         string  `json:"type"`
+		ModelJSON    string  `json:"model_json"`
+		Version      int64   `json:"version"`
+		FolderName   string  `json:"folder_name"`
+		Created      string  `json:"created"`
+		Updated      string  `json:"updated"`
+		DashboardIDs []int64 `json:"dashboard_ids"`
+	}
+	if err := json.Unmarshal(req.RawState.JSON, &old); err != nil {
+		resp.Diagnostics.AddError("Failed to read the state of the library panel", err.Error())
+		return
+	}
 
-	panel := makeLibraryPanel(d)
-	resp, err := client.LibraryElements.CreateLibraryElement(&panel)
-	if err != nil {
-		return diag.FromErr(err)
+	modelJSON := old.ModelJSON
+	if model, err := unmarshalLibraryPanelModelJSON(old.ModelJSON); err == nil {
+		for _, field := range []string{"description", "type"} {
+			if model[field] == "" {
+				delete(model, field)
+			}
+		}
+		if j, err := json.Marshal(model); err == nil {
+			modelJSON = string(j)
+		}
+	}
+
+	dashboardIDs, diags := types.ListValueFrom(ctx, types.Int64Type, old.DashboardIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	createdPanel := resp.Payload.Result
-	d.SetId(MakeOrgResourceID(createdPanel.OrgID, createdPanel.UID))
-	return readLibraryPanel(ctx, d, meta)
+	_, folderUID := SplitOrgResourceID(old.FolderUID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &resourceLibraryPanelModel{
+		ID:              types.StringValue(old.ID),
+		OrgID:           types.StringValue(old.OrgID),
+		UID:             types.StringValue(old.UID),
+		PanelID:         types.Int64Value(old.PanelID),
+		FolderUID:       stringValue(folderUID, types.StringNull()),
+		Name:            types.StringValue(old.Name),
+		Description:     types.StringValue(old.Description),
+		Type:            types.StringValue(old.Type),
+		ModelJSON:       libraryPanelModelJSON.FrameworkValue(modelJSON),
+		Version:         types.Int64Value(old.Version),
+		FolderName:      types.StringValue(old.FolderName),
+		Created:         types.StringValue(old.Created),
+		Updated:         types.StringValue(old.Updated),
+		DashboardIDs:    dashboardIDs,
+		DashboardUIDs:   types.ListNull(types.StringType),
+		ForceDisconnect: types.BoolValue(false),
+	})...)
 }
 
-func readLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, orgID, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
+func (r *resourceLibraryPanel) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
 
-	resp, err := client.LibraryElements.GetLibraryElementByUID(uid)
-	if err, shouldReturn := common.CheckReadError("library panel", d, err); shouldReturn {
-		return err
+// ModifyPlan sets the type and description of the library panel from its model,
+// and warns about the dashboards that are affected by a change of the model or by the deletion of the library panel.
+func (r *resourceLibraryPanel) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var state *resourceLibraryPanelModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if req.Plan.Raw.IsNull() {
+		// The library panel is deleted
+		if state != nil && !state.ForceDisconnect.ValueBool() {
+			if uids := libraryPanelDashboardUIDs(ctx, state.DashboardUIDs); len(uids) > 0 {
+				resp.Diagnostics.AddWarning(
+					fmt.Sprintf("Library panel %q is used by %d dashboard(s)", state.Name.ValueString(), len(uids)),
+					fmt.Sprintf("Grafana doesn't delete library panels that are used by dashboards, unless `force_disconnect` is set. Dashboards: %s", strings.Join(uids, ", ")),
+				)
+			}
+		}
+		return
 	}
-	panel := resp.Payload.Result
 
-	modelJSONBytes, err := json.Marshal(panel.Model)
+	var plan resourceLibraryPanelModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var panelType string
+	if !plan.ModelJSON.IsUnknown() {
+		model, err := unmarshalLibraryPanelModelJSON(plan.ModelJSON.ValueString())
+		if err != nil {
+			// Invalid JSON is reported by the validation of the attribute
+			return
+		}
+		libraryPanelModelJSON.NormalizeFields(model)
+		description, _ := model["description"].(string)
+		panelType, _ = model["type"].(string)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("description"), description)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), panelType)...)
+	}
+
+	if state == nil || (!plan.ModelJSON.IsUnknown() && libraryPanelModelJSON.Equal(state.ModelJSON.ValueString(), plan.ModelJSON.ValueString())) {
+		return
+	}
+	if uids := libraryPanelDashboardUIDs(ctx, state.DashboardUIDs); len(uids) > 0 {
+		detail := fmt.Sprintf("The change of the model applies to the following dashboards: %s", strings.Join(uids, ", "))
+		if !plan.ModelJSON.IsUnknown() && panelType != state.Type.ValueString() {
+			detail += fmt.Sprintf(". The type of the panel changes from %q to %q.", state.Type.ValueString(), panelType)
+		}
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("Library panel %q is used by %d dashboard(s)", state.Name.ValueString(), len(uids)),
+			detail,
+		)
+	}
+}
+
+func (r *resourceLibraryPanel) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data resourceLibraryPanelModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, orgID, err := r.clientFromNewOrgResource(data.OrgID.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Failed to get client", err.Error())
+		return
 	}
-	remotePanelJSON, err := unmarshalLibraryPanelModelJSON(string(modelJSONBytes))
+
+	model, err := unmarshalLibraryPanelModelJSON(data.ModelJSON.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
-	}
-	modelJSON := normalizeLibraryPanelModelJSON(remotePanelJSON)
-
-	d.SetId(MakeOrgResourceID(orgID, uid))
-	d.Set("uid", panel.UID)
-	d.Set("panel_id", panel.ID)
-	d.Set("org_id", strconv.FormatInt(panel.OrgID, 10))
-	d.Set("folder_uid", panel.Meta.FolderUID)
-	d.Set("description", panel.Description)
-	d.Set("type", panel.Type)
-	d.Set("name", panel.Name)
-	d.Set("model_json", modelJSON)
-	d.Set("version", panel.Version)
-	d.Set("folder_name", panel.Meta.FolderName)
-	d.Set("created", panel.Meta.Created.String())
-	d.Set("updated", panel.Meta.Updated.String())
-
-	connResp, err := client.LibraryElements.GetLibraryElementConnections(uid)
+		resp.Diagnostics.AddError("Invalid model_json", err.Error())
+		return
+	}
+	body := models.CreateLibraryElementCommand{
+		UID:       data.UID.ValueString(),
+		Name:      data.Name.ValueString(),
+		Model:     model,
+		Kind:      1,
+		FolderUID: data.FolderUID.ValueString(),
+	}
+	createResp, err := client.LibraryElements.CreateLibraryElementWithParams(library_elements.NewCreateLibraryElementParams().WithBody(&body).WithContext(ctx))
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(common.APIErrorDiagnosticsPluginFramework("Failed to create the library panel", err)...)
+		return
 	}
-	connections := connResp.Payload.Result
 
-	dashboardIds := make([]int64, 0, len(connections))
-	for _, connection := range connections {
-		dashboardIds = append(dashboardIds, connection.ConnectionID)
+	data.ID = types.StringValue(MakeOrgResourceID(orgID, createResp.Payload.Result.UID))
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	d.Set("dashboard_ids", dashboardIds)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
-	return nil
+func (r *resourceLibraryPanel) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data resourceLibraryPanelModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, orgID, split, err := r.clientFromExistingOrgResource(resourceLibraryPanelID, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get client", err.Error())
+		return
+	}
+	panel, connections, err := getLibraryPanelWithConnections(ctx, client, split[0].(string))
+	if common.CheckReadErrorPluginFramework(ctx, "library panel", data.ID.ValueString(), err, resp) {
+		return
+	}
+
+	resp.Diagnostics.Append(packLibraryPanel(ctx, orgID, panel, connections, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func updateLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
+func (r *resourceLibraryPanel) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state resourceLibraryPanelModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	modelJSON := d.Get("model_json").(string)
-	panelJSON, _ := unmarshalLibraryPanelModelJSON(modelJSON)
+	client, _, split, err := r.clientFromExistingOrgResource(resourceLibraryPanelID, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get client", err.Error())
+		return
+	}
 
+	model, err := unmarshalLibraryPanelModelJSON(data.ModelJSON.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid model_json", err.Error())
+		return
+	}
 	body := models.PatchLibraryElementCommand{
-		Name:    d.Get("name").(string),
-		Model:   panelJSON,
-		Kind:    1,
-		Version: int64(d.Get("version").(int)),
+		Name:      data.Name.ValueString(),
+		Model:     model,
+		Kind:      1,
+		Version:   state.Version.ValueInt64(),
+		FolderUID: data.FolderUID.ValueString(),
+	}
+	params := library_elements.NewUpdateLibraryElementParams().WithLibraryElementUID(split[0].(string)).WithBody(&body).WithContext(ctx)
+	if _, err := client.LibraryElements.UpdateLibraryElementWithParams(params); err != nil {
+		resp.Diagnostics.Append(common.APIErrorDiagnosticsPluginFramework("Failed to update the library panel", err)...)
+		return
 	}
-	_, body.FolderUID = SplitOrgResourceID(d.Get("folder_uid").(string))
 
-	resp, err := client.LibraryElements.UpdateLibraryElement(uid, &body)
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *resourceLibraryPanel) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data resourceLibraryPanelModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, _, split, err := r.clientFromExistingOrgResource(resourceLibraryPanelID, data.ID.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Failed to get client", err.Error())
+		return
+	}
+	uid := split[0].(string)
+
+	if data.ForceDisconnect.ValueBool() {
+		if err := disconnectLibraryPanel(ctx, client, uid); err != nil {
+			resp.Diagnostics.Append(common.APIErrorDiagnosticsPluginFramework("Failed to disconnect the library panel from its dashboards", err)...)
+			return
+		}
+	}
+
+	_, err = client.LibraryElements.DeleteLibraryElementByUIDWithParams(library_elements.NewDeleteLibraryElementByUIDParams().WithLibraryElementUID(uid).WithContext(ctx))
+	if err == nil || common.IsNotFoundError(err) {
+		return
 	}
-	updatedPanel := resp.Payload.Result
-	d.SetId(MakeOrgResourceID(updatedPanel.OrgID, updatedPanel.UID))
-	return readLibraryPanel(ctx, d, meta)
+	// Grafana refuses to delete library panels that are used by dashboards, tell which ones
+	if _, connections, connErr := getLibraryPanelWithConnections(ctx, client, uid); connErr == nil && len(connections) > 0 {
+		uids := make([]string, len(connections))
+		for i, connection := range connections {
+			uids[i] = connection.ConnectionUID
+		}
+		resp.Diagnostics.AddError(
+			"Failed to delete the library panel",
+			fmt.Sprintf("The library panel is used by the following dashboards: %s. Remove it from these dashboards, or set `force_disconnect` to replace it by a copy of its model: %s", strings.Join(uids, ", "), err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(common.APIErrorDiagnosticsPluginFramework("Failed to delete the library panel", err)...)
 }
 
-func deleteLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
-	_, err := client.LibraryElements.DeleteLibraryElementByUID(uid)
-	diag, _ := common.CheckReadError("library panel", d, err)
-	return diag
+func (r *resourceLibraryPanel) read(ctx context.Context, data *resourceLibraryPanelModel) diag.Diagnostics {
+	client, orgID, split, err := r.clientFromExistingOrgResource(resourceLibraryPanelID, data.ID.ValueString())
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get client", err.Error())}
+	}
+
+	panel, connections, err := getLibraryPanelWithConnections(ctx, client, split[0].(string))
+	if err != nil {
+		return common.APIErrorDiagnosticsPluginFramework("Failed to read the library panel", err)
+	}
+	return packLibraryPanel(ctx, orgID, panel, connections, data)
 }
 
-func makeLibraryPanel(d *schema.ResourceData) models.CreateLibraryElementCommand {
-	modelJSON := d.Get("model_json").(string)
-	panelJSON, _ := unmarshalLibraryPanelModelJSON(modelJSON)
+// packLibraryPanel sets the model from the API response.
+func packLibraryPanel(ctx context.Context, orgID int64, panel *models.LibraryElementDTO, connections []*models.LibraryElementConnectionDTO, data *resourceLibraryPanelModel) diag.Diagnostics {
+	modelJSON, err := json.Marshal(panel.Model)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Failed to marshal the library panel model", err.Error())}
+	}
+	normalizedModelJSON, err := libraryPanelModelJSON.Normalize(string(modelJSON))
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Failed to normalize the library panel model", err.Error())}
+	}
 
-	panel := models.CreateLibraryElementCommand{
-		UID:   d.Get("uid").(string),
-		Name:  d.Get("name").(string),
-		Model: panelJSON,
-		Kind:  1,
+	dashboardIDs := make([]int64, len(connections))
+	dashboardUIDs := make([]string, len(connections))
+	for i, connection := range connections {
+		dashboardIDs[i] = connection.ConnectionID
+		dashboardUIDs[i] = connection.ConnectionUID
+	}
+	var diags diag.Diagnostics
+	data.DashboardIDs, diags = types.ListValueFrom(ctx, types.Int64Type, dashboardIDs)
+	if diags.HasError() {
+		return diags
+	}
+	data.DashboardUIDs, diags = types.ListValueFrom(ctx, types.StringType, dashboardUIDs)
+	if diags.HasError() {
+		return diags
 	}
-	_, panel.FolderUID = SplitOrgResourceID(d.Get("folder_uid").(string))
 
-	return panel
+	data.ID = types.StringValue(MakeOrgResourceID(orgID, panel.UID))
+	data.OrgID = types.StringValue(strconv.FormatInt(panel.OrgID, 10))
+	data.UID = types.StringValue(panel.UID)
+	data.PanelID = types.Int64Value(panel.ID)
+	data.FolderUID = stringValue(panel.Meta.FolderUID, data.FolderUID)
+	data.Name = types.StringValue(panel.Name)
+	data.Description = types.StringValue(panel.Description)
+	data.Type = types.StringValue(panel.Type)
+	data.ModelJSON = libraryPanelModelJSON.FrameworkValue(normalizedModelJSON)
+	data.Version = types.Int64Value(panel.Version)
+	data.FolderName = types.StringValue(panel.Meta.FolderName)
+	data.Created = types.StringValue(panel.Meta.Created.String())
+	data.Updated = types.StringValue(panel.Meta.Updated.String())
+	// The attribute isn't set in the state of imported library panels
+	if data.ForceDisconnect.IsNull() || data.ForceDisconnect.IsUnknown() {
+		data.ForceDisconnect = types.BoolValue(false)
+	}
+	return nil
 }
 
-// unmarshalLibraryPanelModelJSON is a convenience func for unmarshalling
-// `model_json` field.
-func unmarshalLibraryPanelModelJSON(modelJSON string) (map[string]interface{}, error) {
-	unmarshalledJSON := map[string]interface{}{}
-	err := json.Unmarshal([]byte(modelJSON), &unmarshalledJSON)
+// getLibraryPanelWithConnections returns a library panel and the connections to the dashboards that use it.
+func getLibraryPanelWithConnections(ctx context.Context, client *goapi.GrafanaHTTPAPI, uid string) (*models.LibraryElementDTO, []*models.LibraryElementConnectionDTO, error) {
+	resp, err := client.LibraryElements.GetLibraryElementByUIDWithParams(library_elements.NewGetLibraryElementByUIDParams().WithLibraryElementUID(uid).WithContext(ctx))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return unmarshalledJSON, nil
+	connResp, err := client.LibraryElements.GetLibraryElementConnectionsWithParams(library_elements.NewGetLibraryElementConnectionsParams().WithLibraryElementUID(uid).WithContext(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Payload.Result, connResp.Payload.Result, nil
 }
 
-// validateLibraryPanelModelJSON is the ValidateFunc for `model_json`. It
-// ensures its value is valid JSON.
-func validateLibraryPanelModelJSON(model interface{}, k string) ([]string, []error) {
-	modelJSON := model.(string)
-	if _, err := unmarshalLibraryPanelModelJSON(modelJSON); err != nil {
-		return nil, []error{err}
+// disconnectLibraryPanel replaces the panels that use a library panel by a copy of its model, in all the dashboards that use it.
+// The copies keep the ID and the position of the panels they replace.
+// It does nothing if the library panel doesn't exist anymore, and skips the connections to dashboards that don't exist anymore.
+func disconnectLibraryPanel(ctx context.Context, client *goapi.GrafanaHTTPAPI, uid string) error {
+	panel, connections, err := getLibraryPanelWithConnections(ctx, client, uid)
+	if common.IsNotFoundError(err) {
+		return nil
+	} else if err != nil {
+		return err
 	}
-	return nil, nil
+	for _, connection := range connections {
+		resp, err := client.Dashboards.GetDashboardByUIDWithParams(dashboards.NewGetDashboardByUIDParams().WithUID(connection.ConnectionUID).WithContext(ctx))
+		if common.IsNotFoundError(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get dashboard %s: %w", connection.ConnectionUID, err)
+		}
+		dashboard, ok := resp.Payload.Dashboard.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected model of dashboard %s", connection.ConnectionUID)
+		}
+		disconnectLibraryPanelFromPanels(dashboard["panels"], panel)
+
+		body := models.SaveDashboardCommand{
+			Dashboard: dashboard,
+			FolderUID: resp.Payload.Meta.FolderUID,
+			Message:   fmt.Sprintf("Disconnected library panel %s", panel.Name),
+		}
+		if _, err := client.Dashboards.PostDashboardWithParams(dashboards.NewPostDashboardParams().WithBody(&body).WithContext(ctx)); err != nil {
+			return fmt.Errorf("failed to save dashboard %s: %w", connection.ConnectionUID, err)
+		}
+	}
+	return nil
 }
 
-// normalizeLibraryPanelModelJSON is the StateFunc for the `model_json` field.
-func normalizeLibraryPanelModelJSON(config interface{}) string {
-	var modelJSON map[string]interface{}
-	switch c := config.(type) {
-	case map[string]interface{}:
-		modelJSON = c
-	case string:
-		var err error
-		modelJSON, err = unmarshalLibraryPanelModelJSON(c)
-		if err != nil {
-			return c
+// disconnectLibraryPanelFromPanels replaces the library panel in a list of panels, including the panels of collapsed rows.
+func disconnectLibraryPanelFromPanels(panels interface{}, libraryPanel *models.LibraryElementDTO) {
+	list, _ := panels.([]interface{})
+	for i, p := range list {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			continue
 		}
+		if ref, ok := panel["libraryPanel"].(map[string]interface{}); ok && ref["uid"] == libraryPanel.UID {
+			model := map[string]interface{}{}
+			if libraryModel, ok := libraryPanel.Model.(map[string]interface{}); ok {
+				for k, v := range libraryModel {
+					model[k] = v
+				}
+			}
+			delete(model, "libraryPanel")
+			for _, key := range []string{"id", "gridPos"} {
+				if v, ok := panel[key]; ok {
+					model[key] = v
+				}
+			}
+			list[i] = model
+			continue
+		}
+		disconnectLibraryPanelFromPanels(panel["panels"], libraryPanel)
 	}
+}
 
-	// replace nil with empty string in JSON
-	// API will always return model JSON with description and type
-	if _, ok := modelJSON["description"]; !ok {
-		modelJSON["description"] = ""
+// libraryPanelDashboardUIDs returns the UIDs of the dashboards that use a library panel, from its state.
+func libraryPanelDashboardUIDs(ctx context.Context, list types.List) []string {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
 	}
-	if _, ok := modelJSON["type"]; !ok {
-		modelJSON["type"] = ""
+	var uids []string
+	list.ElementsAs(ctx, &uids, false)
+	return uids
+}
+
+// unmarshalLibraryPanelModelJSON is a convenience func for unmarshalling
+// `model_json` field.
+func unmarshalLibraryPanelModelJSON(modelJSON string) (map[string]interface{}, error) {
+	unmarshalledJSON := map[string]interface{}{}
+	err := json.Unmarshal([]byte(modelJSON), &unmarshalledJSON)
+	if err != nil {
+		return nil, err
 	}
-	j, _ := json.Marshal(modelJSON)
-	return string(j)
+	return unmarshalledJSON, nil
+}
+
+// libraryPanelModelJSON is the `model_json` field.
+var libraryPanelModelJSON = common.NormalizedJSON{
+	NormalizeFields: func(modelJSON map[string]interface{}) {
+		// replace nil with empty string in JSON
+		// API will always return model JSON with description and type
+		if _, ok := modelJSON["description"]; !ok {
+			modelJSON["description"] = ""
+		}
+		if _, ok := modelJSON["type"]; !ok {
+			modelJSON["type"] = ""
+		}
+	},
 }
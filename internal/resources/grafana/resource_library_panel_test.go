@@ -1,14 +1,20 @@
 package grafana_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccLibraryPanel_basic(t *testing.T) {
@@ -31,7 +37,8 @@ func TestAccLibraryPanel_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("grafana_library_panel.test", "org_id", "1"),
 					resource.TestCheckResourceAttr("grafana_library_panel.test", "name", name),
 					resource.TestCheckResourceAttr("grafana_library_panel.test", "version", "1"),
-					resource.TestCheckResourceAttr("grafana_library_panel.test", "model_json", fmt.Sprintf(`{"description":"","title":"%s","type":""}`, name)),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "model_json", fmt.Sprintf(`{"title":"%s"}`, name)),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "dashboard_uids.#", "0"),
 				),
 			},
 			{
@@ -43,14 +50,16 @@ func TestAccLibraryPanel_basic(t *testing.T) {
 					resource.TestMatchResourceAttr("grafana_library_panel.test", "id", defaultOrgIDRegexp),
 					resource.TestCheckResourceAttr("grafana_library_panel.test", "name", "updated "+name),
 					resource.TestCheckResourceAttr("grafana_library_panel.test", "version", "2"),
-					resource.TestCheckResourceAttr("grafana_library_panel.test", "model_json", fmt.Sprintf(`{"description":"","title":"updated %s","type":""}`, name)),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "model_json", fmt.Sprintf(`{"title":"updated %s"}`, name)),
 				),
 			},
 			{
 				// Importing matches the state of the previous step.
-				ResourceName:      "grafana_library_panel.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				// The imported model is the one returned by Grafana, which is semantically equal to the configured one.
+				ResourceName:            "grafana_library_panel.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"model_json"},
 			},
 		},
 	})
@@ -81,9 +90,10 @@ func TestAccLibraryPanel_folder(t *testing.T) {
 				),
 			},
 			{
-				ImportState:       true,
-				ResourceName:      "grafana_library_panel.test_folder",
-				ImportStateVerify: true,
+				ImportState:             true,
+				ResourceName:            "grafana_library_panel.test_folder",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"model_json"},
 			},
 		},
 	})
@@ -106,6 +116,8 @@ func TestAccLibraryPanel_dashboard(t *testing.T) {
 					resource.TestMatchResourceAttr("grafana_library_panel.dashboard", "id", defaultOrgIDRegexp),
 					libraryPanelCheckExists.exists("grafana_library_panel.dashboard", &panel),
 					dashboardCheckExists.exists("grafana_dashboard.with_library_panel", &dashboard),
+					resource.TestCheckResourceAttr("data.grafana_library_panel.connected_to_dashboard", "dashboard_uids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.grafana_library_panel.connected_to_dashboard", "dashboard_uids.0", "grafana_dashboard.with_library_panel", "uid"),
 				),
 			},
 		},
@@ -134,6 +146,222 @@ func TestAccLibraryPanel_inOrg(t *testing.T) {
 	})
 }
 
+func TestMockLibraryPanel_forceDisconnect(t *testing.T) {
+	testutils.IsUnitTest(t)
+	mock := testutils.NewMockGrafana(t)
+
+	config := func(forceDisconnect bool) string {
+		return fmt.Sprintf(`
+resource "grafana_library_panel" "test" {
+	uid              = "shared"
+	name             = "Shared"
+	force_disconnect = %t
+	model_json = jsonencode({
+		title = "Shared"
+		type  = "text"
+	})
+}
+`, forceDisconnect)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		// The dashboard keeps a copy of the library panel once it is deleted
+		CheckDestroy: func(s *terraform.State) error {
+			if _, err := mock.Client().LibraryElements.GetLibraryElementByUID("shared"); !common.IsNotFoundError(err) {
+				return fmt.Errorf("expected the library panel to be deleted, got %v", err)
+			}
+			resp, err := mock.Client().Dashboards.GetDashboardByUID("connected")
+			if err != nil {
+				return err
+			}
+			panel := resp.Payload.Dashboard.(map[string]interface{})["panels"].([]interface{})[0].(map[string]interface{})
+			if _, ok := panel["libraryPanel"]; ok || panel["title"] != "Shared" || panel["type"] != "text" {
+				return fmt.Errorf("expected the panel to be a copy of the library panel, got %v", panel)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "type", "text"),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "force_disconnect", "false"),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "dashboard_uids.#", "0"),
+				),
+			},
+			// A dashboard starts using the library panel
+			{
+				PreConfig: func() {
+					dashboard := models.SaveDashboardCommand{Dashboard: map[string]interface{}{
+						"uid":   "connected",
+						"title": "Connected",
+						"panels": []interface{}{
+							map[string]interface{}{"id": 1, "libraryPanel": map[string]interface{}{"uid": "shared", "name": "Shared"}},
+						},
+					}}
+					if _, err := mock.Client().Dashboards.PostDashboard(&dashboard); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "dashboard_uids.#", "1"),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "dashboard_uids.0", "connected"),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "dashboard_ids.#", "1"),
+				),
+			},
+			// The dashboards that don't exist anymore are skipped when disconnecting the library panel
+			{
+				PreConfig: func() {
+					mock.StaleLibraryPanelConnections = map[string][]string{"shared": {"deleted"}}
+				},
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("grafana_library_panel.test", "force_disconnect", "true"),
+			},
+		},
+	})
+}
+
+// libraryPanelSDKProviderVersion is the last release of the provider with the SDK version of the resource
+const libraryPanelSDKProviderVersion = "2.19.0"
+
+func TestAccLibraryPanel_upgradeFromSDK(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=8.0.0")
+
+	name := acctest.RandString(10)
+	var panel models.LibraryElementResponse
+
+	resource.ParallelTest(t, resource.TestCase{
+		CheckDestroy: libraryPanelCheckExists.destroyed(&panel, nil),
+		Steps: []resource.TestStep{
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"grafana": {
+						Source:            "grafana/grafana",
+						VersionConstraint: libraryPanelSDKProviderVersion,
+					},
+				},
+				Config: testAccLibraryPanelBasic(name),
+				Check: resource.ComposeTestCheckFunc(
+					libraryPanelCheckExists.exists("grafana_library_panel.test", &panel),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "model_json", fmt.Sprintf(`{"description":"","title":"%s","type":""}`, name)),
+				),
+			},
+			// The upgraded state matches the configuration
+			{
+				ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+				Config:                   testAccLibraryPanelBasic(name),
+				PlanOnly:                 true,
+			},
+			{
+				ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+				Config:                   testAccLibraryPanelBasic(name),
+				Check: resource.ComposeTestCheckFunc(
+					libraryPanelCheckExists.exists("grafana_library_panel.test", &panel),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "model_json", fmt.Sprintf(`{"title":"%s"}`, name)),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "version", "1"),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "force_disconnect", "false"),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "dashboard_uids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestLibraryPanelUpgradeState(t *testing.T) {
+	server, err := testutils.ProtoV5ProviderFactories["grafana"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateType := schemaResp.ResourceSchemas["grafana_library_panel"].ValueType()
+
+	for _, tc := range []struct {
+		name              string
+		modelJSON         string
+		expectedModelJSON string
+	}{
+		{
+			name:              "without description and type",
+			modelJSON:         `{"description":"","title":"Shared","type":""}`,
+			expectedModelJSON: `{"title":"Shared"}`,
+		},
+		{
+			name:              "with description and type",
+			modelJSON:         `{"description":"A panel","title":"Shared","type":"text"}`,
+			expectedModelJSON: `{"description":"A panel","title":"Shared","type":"text"}`,
+		},
+		{
+			name:              "with nested fields",
+			modelJSON:         `{"description":"","options":{"content":"","mode":"markdown"},"title":"Shared","type":"text"}`,
+			expectedModelJSON: `{"options":{"content":"","mode":"markdown"},"title":"Shared","type":"text"}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// State of the SDK version of the resource (schema version 0)
+			oldState, err := json.Marshal(map[string]interface{}{
+				"id":            "1:shared",
+				"org_id":        "1",
+				"uid":           "shared",
+				"panel_id":      3,
+				"folder_uid":    "folder",
+				"name":          "Shared",
+				"description":   "",
+				"type":          "text",
+				"model_json":    tc.modelJSON,
+				"version":       2,
+				"folder_name":   "Folder",
+				"created":       "2024-05-01 10:00:00 +0000 UTC",
+				"updated":       "2024-05-02 10:00:00 +0000 UTC",
+				"dashboard_ids": []int64{12},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := server.UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+				TypeName: "grafana_library_panel",
+				Version:  0,
+				RawState: &tfprotov5.RawState{JSON: oldState},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("%s: %s", d.Summary, d.Detail)
+			}
+
+			upgradedValue, err := resp.UpgradedState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var upgraded map[string]tftypes.Value
+			if err := upgradedValue.As(&upgraded); err != nil {
+				t.Fatal(err)
+			}
+			for attr, expected := range map[string]tftypes.Value{
+				"id":               tftypes.NewValue(tftypes.String, "1:shared"),
+				"uid":              tftypes.NewValue(tftypes.String, "shared"),
+				"panel_id":         tftypes.NewValue(tftypes.Number, 3),
+				"folder_uid":       tftypes.NewValue(tftypes.String, "folder"),
+				"type":             tftypes.NewValue(tftypes.String, "text"),
+				"model_json":       tftypes.NewValue(tftypes.String, tc.expectedModelJSON),
+				"version":          tftypes.NewValue(tftypes.Number, 2),
+				"force_disconnect": tftypes.NewValue(tftypes.Bool, false),
+				"dashboard_ids":    tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{tftypes.NewValue(tftypes.Number, 12)}),
+				"dashboard_uids":   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			} {
+				if actual := upgraded[attr]; !actual.Equal(expected) {
+					t.Errorf("expected %s to be %s, got %s", attr, expected, actual)
+				}
+			}
+		})
+	}
+}
+
 func testAccLibraryPanelBasic(name string) string {
 	return fmt.Sprintf(`
 resource "grafana_library_panel" "test" {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/admin_users"
 	"github.com/grafana/grafana-openapi-client-go/client/orgs"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
@@ -152,7 +153,7 @@ set to true. This feature is only available in Grafana 10.2+.
 }
 
 func listOrganizations(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
-	orgIDs, err := data.OrgIDs(client)
+	orgIDs, err := data.OrgIDs(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -175,7 +176,7 @@ func CreateOrganization(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 	name := d.Get("name").(string)
 
-	resp, err := client.Orgs.CreateOrg(&models.CreateOrgCommand{Name: name})
+	resp, err := client.Orgs.CreateOrgWithParams(orgs.NewCreateOrgParams().WithBody(&models.CreateOrgCommand{Name: name}).WithContext(ctx))
 	if err != nil && strings.Contains(err.Error(), "409") {
 		return diag.Errorf("Error: A Grafana Organization with the name '%s' already exists.", name)
 	}
@@ -183,7 +184,7 @@ func CreateOrganization(ctx context.Context, d *schema.ResourceData, meta interf
 		return diag.FromErr(err)
 	}
 	d.SetId(strconv.FormatInt(*resp.Payload.OrgID, 10))
-	if err = UpdateUsers(d, meta); err != nil {
+	if err = UpdateUsers(ctx, d, meta); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -197,7 +198,7 @@ func ReadOrganization(ctx context.Context, d *schema.ResourceData, meta interfac
 	}
 	orgID, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	resp, err := client.Orgs.GetOrgByID(orgID)
+	resp, err := client.Orgs.GetOrgByIDWithParams(orgs.NewGetOrgByIDParams().WithOrgID(orgID).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("organization", d, err); shouldReturn {
 		return err
 	}
@@ -205,7 +206,7 @@ func ReadOrganization(ctx context.Context, d *schema.ResourceData, meta interfac
 	org := resp.Payload
 	d.Set("org_id", org.ID)
 	d.Set("name", org.Name)
-	if err := ReadUsers(d, meta); err != nil {
+	if err := ReadUsers(ctx, d, meta); err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
@@ -219,11 +220,11 @@ func UpdateOrganization(ctx context.Context, d *schema.ResourceData, meta interf
 	orgID, _ := strconv.ParseInt(d.Id(), 10, 64)
 	if d.HasChange("name") {
 		name := d.Get("name").(string)
-		if _, err := client.Orgs.UpdateOrg(orgID, &models.UpdateOrgForm{Name: name}); err != nil {
+		if _, err := client.Orgs.UpdateOrgWithParams(orgs.NewUpdateOrgParams().WithOrgID(orgID).WithBody(&models.UpdateOrgForm{Name: name}).WithContext(ctx)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
-	if err := UpdateUsers(d, meta); err != nil {
+	if err := UpdateUsers(ctx, d, meta); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -236,18 +237,18 @@ func DeleteOrganization(ctx context.Context, d *schema.ResourceData, meta interf
 		return diag.FromErr(err)
 	}
 	orgID, _ := strconv.ParseInt(d.Id(), 10, 64)
-	_, err = client.Orgs.DeleteOrgByID(orgID)
+	_, err = client.Orgs.DeleteOrgByIDWithParams(orgs.NewDeleteOrgByIDParams().WithOrgID(orgID).WithContext(ctx))
 	diag, _ := common.CheckReadError("organization", d, err)
 	return diag
 }
 
-func ReadUsers(d *schema.ResourceData, meta interface{}) error {
+func ReadUsers(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	client, err := OAPIGlobalClient(meta)
 	if err != nil {
 		return err
 	}
 	orgID, _ := strconv.ParseInt(d.Id(), 10, 64)
-	resp, err := client.Orgs.GetOrgUsers(orgID)
+	resp, err := client.Orgs.GetOrgUsersWithParams(orgs.NewGetOrgUsersParams().WithOrgID(orgID).WithContext(ctx))
 	if err != nil {
 		return err
 	}
@@ -264,18 +265,18 @@ func ReadUsers(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func UpdateUsers(d *schema.ResourceData, meta interface{}) error {
+func UpdateUsers(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	stateUsers, configUsers, err := collectUsers(d)
 	if err != nil {
 		return err
 	}
 	changes := changes(stateUsers, configUsers)
 	orgID, _ := strconv.ParseInt(d.Id(), 10, 64)
-	changes, err = addIdsToChanges(d, meta, changes)
+	changes, err = addIdsToChanges(ctx, d, meta, changes)
 	if err != nil {
 		return err
 	}
-	return applyChanges(meta, orgID, changes)
+	return applyChanges(ctx, meta, orgID, changes)
 }
 
 func collectUsers(d *schema.ResourceData) (map[string]OrgUser, map[string]OrgUser, error) {
@@ -330,13 +331,13 @@ func changes(stateUsers, configUsers map[string]OrgUser) []UserChange {
 	return changes
 }
 
-func addIdsToChanges(d *schema.ResourceData, meta interface{}, changes []UserChange) ([]UserChange, error) {
+func addIdsToChanges(ctx context.Context, d *schema.ResourceData, meta interface{}, changes []UserChange) ([]UserChange, error) {
 	client, err := OAPIGlobalClient(meta)
 	if err != nil {
 		return nil, err
 	}
 	gUserMap := make(map[string]int64)
-	gUsers, err := getAllUsers(client)
+	gUsers, err := getAllUsers(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -355,7 +356,7 @@ func addIdsToChanges(d *schema.ResourceData, meta interface{}, changes []UserCha
 			return nil, fmt.Errorf("error adding user %s. User does not exist in Grafana", change.User.Email)
 		}
 		if !ok && create {
-			id, err = createUser(meta, change.User.Email)
+			id, err = createUser(ctx, meta, change.User.Email)
 			if err != nil {
 				return nil, err
 			}
@@ -366,7 +367,7 @@ func addIdsToChanges(d *schema.ResourceData, meta interface{}, changes []UserCha
 	return output, nil
 }
 
-func createUser(meta interface{}, user string) (int64, error) {
+func createUser(ctx context.Context, meta interface{}, user string) (int64, error) {
 	client, err := OAPIGlobalClient(meta)
 	if err != nil {
 		return 0, err
@@ -383,14 +384,14 @@ func createUser(meta interface{}, user string) (int64, error) {
 		Email:    user,
 		Password: models.Password(pass),
 	}
-	resp, err := client.AdminUsers.AdminCreateUser(&u)
+	resp, err := client.AdminUsers.AdminCreateUserWithParams(admin_users.NewAdminCreateUserParams().WithBody(&u).WithContext(ctx))
 	if err != nil {
 		return 0, err
 	}
 	return resp.Payload.ID, err
 }
 
-func applyChanges(meta interface{}, orgID int64, changes []UserChange) error {
+func applyChanges(ctx context.Context, meta interface{}, orgID int64, changes []UserChange) error {
 	client, err := OAPIGlobalClient(meta)
 	if err != nil {
 		return err
@@ -399,12 +400,12 @@ func applyChanges(meta interface{}, orgID int64, changes []UserChange) error {
 		u := change.User
 		switch change.Type {
 		case Add:
-			_, err = client.Orgs.AddOrgUser(orgID, &models.AddOrgUserCommand{LoginOrEmail: u.Email, Role: u.Role})
+			_, err = client.Orgs.AddOrgUserWithParams(orgs.NewAddOrgUserParams().WithOrgID(orgID).WithBody(&models.AddOrgUserCommand{LoginOrEmail: u.Email, Role: u.Role}).WithContext(ctx))
 		case Update:
-			params := orgs.NewUpdateOrgUserParams().WithOrgID(orgID).WithUserID(u.ID).WithBody(&models.UpdateOrgUserCommand{Role: u.Role})
+			params := orgs.NewUpdateOrgUserParams().WithOrgID(orgID).WithUserID(u.ID).WithBody(&models.UpdateOrgUserCommand{Role: u.Role}).WithContext(ctx)
 			_, err = client.Orgs.UpdateOrgUser(params)
 		case Remove:
-			_, err = client.Orgs.RemoveOrgUser(u.ID, orgID)
+			_, err = client.Orgs.RemoveOrgUserWithParams(orgs.NewRemoveOrgUserParams().WithUserID(u.ID).WithOrgID(orgID).WithContext(ctx))
 		}
 		if err != nil && !strings.Contains(err.Error(), "409") {
 			return err
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/grafana/grafana-openapi-client-go/client/org_preferences"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -66,12 +67,12 @@ func resourceOrganizationPreferences() *common.Resource {
 func CreateOrganizationPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 
-	_, err := client.OrgPreferences.UpdateOrgPreferences(&models.UpdatePrefsCmd{
+	_, err := client.OrgPreferences.UpdateOrgPreferencesWithParams(org_preferences.NewUpdateOrgPreferencesParams().WithBody(&models.UpdatePrefsCmd{
 		Theme:            d.Get("theme").(string),
 		HomeDashboardUID: d.Get("home_dashboard_uid").(string),
 		Timezone:         d.Get("timezone").(string),
 		WeekStart:        d.Get("week_start").(string),
-	})
+	}).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -85,7 +86,7 @@ func ReadOrganizationPreferences(ctx context.Context, d *schema.ResourceData, me
 	id := d.Id() + ":" // Ensure the ID is in the <orgID>:<resourceID> format. A bit hacky but won't survive the migration to plugin framework
 	client, _, _ := OAPIClientFromExistingOrgResource(meta, id)
 
-	resp, err := client.OrgPreferences.GetOrgPreferences()
+	resp, err := client.OrgPreferences.GetOrgPreferencesWithParams(org_preferences.NewGetOrgPreferencesParams().WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("organization preferences", d, err); shouldReturn {
 		return err
 	}
@@ -108,7 +109,7 @@ func DeleteOrganizationPreferences(ctx context.Context, d *schema.ResourceData,
 	id := d.Id() + ":" // Ensure the ID is in the <orgID>:<resourceID> format. A bit hacky but won't survive the migration to plugin framework
 	client, _, _ := OAPIClientFromExistingOrgResource(meta, id)
 
-	if _, err := client.OrgPreferences.UpdateOrgPreferences(&models.UpdatePrefsCmd{}); err != nil {
+	if _, err := client.OrgPreferences.UpdateOrgPreferencesWithParams(org_preferences.NewUpdateOrgPreferencesParams().WithBody(&models.UpdatePrefsCmd{}).WithContext(ctx)); err != nil {
 		return diag.FromErr(err)
 	}
 
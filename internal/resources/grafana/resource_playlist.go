@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strconv"
 
+	"github.com/grafana/grafana-openapi-client-go/client/playlists"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -86,7 +87,7 @@ func CreatePlaylist(ctx context.Context, d *schema.ResourceData, meta interface{
 		Items:    expandPlaylistItems(d.Get("item").(*schema.Set).List()),
 	}
 
-	resp, err := client.Playlists.CreatePlaylist(&playlist)
+	resp, err := client.Playlists.CreatePlaylistWithParams(playlists.NewCreatePlaylistParams().WithBody(&playlist).WithContext(ctx))
 
 	if err != nil {
 		return diag.Errorf("error creating Playlist: %v", err)
@@ -104,7 +105,7 @@ func CreatePlaylist(ctx context.Context, d *schema.ResourceData, meta interface{
 func ReadPlaylist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID, id := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	resp, err := client.Playlists.GetPlaylist(id)
+	resp, err := client.Playlists.GetPlaylistWithParams(playlists.NewGetPlaylistParams().WithUID(id).WithContext(ctx))
 	// In Grafana 9.0+, if the playlist doesn't exist, the API returns an empty playlist but not a notfound error
 	if resp != nil && resp.GetPayload().ID == 0 && resp.GetPayload().UID == "" {
 		err = errors.New(common.NotFoundError)
@@ -114,7 +115,7 @@ func ReadPlaylist(ctx context.Context, d *schema.ResourceData, meta interface{})
 	}
 
 	playlist := resp.Payload
-	itemsResp, err := client.Playlists.GetPlaylistItems(id)
+	itemsResp, err := client.Playlists.GetPlaylistItemsWithParams(playlists.NewGetPlaylistItemsParams().WithUID(id).WithContext(ctx))
 	if err != nil {
 		return diag.Errorf("error getting playlist items: %v", err)
 	}
@@ -139,7 +140,7 @@ func UpdatePlaylist(ctx context.Context, d *schema.ResourceData, meta interface{
 		Items:    expandPlaylistItems(d.Get("item").(*schema.Set).List()),
 	}
 
-	_, err := client.Playlists.UpdatePlaylist(id, &playlist)
+	_, err := client.Playlists.UpdatePlaylistWithParams(playlists.NewUpdatePlaylistParams().WithUID(id).WithBody(&playlist).WithContext(ctx))
 	if err != nil {
 		return diag.Errorf("error updating Playlist (%s): %v", id, err)
 	}
@@ -149,7 +150,7 @@ func UpdatePlaylist(ctx context.Context, d *schema.ResourceData, meta interface{
 
 func DeletePlaylist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, id := OAPIClientFromExistingOrgResource(meta, d.Id())
-	_, err := client.Playlists.DeletePlaylist(id)
+	_, err := client.Playlists.DeletePlaylistWithParams(playlists.NewDeletePlaylistParams().WithUID(id).WithContext(ctx))
 	diag, _ := common.CheckReadError("playlist", d, err)
 	return diag
 }
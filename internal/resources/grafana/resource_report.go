@@ -11,6 +11,7 @@ import (
 	_ "time/tzdata"
 
 	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/client/reports"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -247,7 +248,7 @@ func resourceReport() *common.Resource {
 		"grafana_report",
 		orgResourceIDInt("id"),
 		schema,
-	)
+	).WithGrafanaRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 func CreateReport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -258,7 +259,7 @@ func CreateReport(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag.FromErr(err)
 	}
 
-	res, err := client.Reports.CreateReport(&report)
+	res, err := client.Reports.CreateReportWithParams(reports.NewCreateReportParams().WithBody(&report).WithContext(ctx))
 	if err != nil {
 		data, _ := json.Marshal(report)
 		return diag.Errorf("error creating the following report:\n%s\n%v", string(data), err)
@@ -275,7 +276,7 @@ func ReadReport(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		return diag.FromErr(err)
 	}
 
-	r, err := client.Reports.GetReport(id)
+	r, err := client.Reports.GetReportWithParams(reports.NewGetReportParams().WithID(id).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("report", d, err); shouldReturn {
 		return err
 	}
@@ -353,7 +354,7 @@ func UpdateReport(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag.FromErr(err)
 	}
 
-	if _, err := client.Reports.UpdateReport(id, &report); err != nil {
+	if _, err := client.Reports.UpdateReportWithParams(reports.NewUpdateReportParams().WithID(id).WithBody(&report).WithContext(ctx)); err != nil {
 		data, _ := json.Marshal(report)
 		return diag.Errorf("error updating the following report:\n%s\n%v", string(data), err)
 	}
@@ -367,7 +368,7 @@ func DeleteReport(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag.FromErr(err)
 	}
 
-	_, err = client.Reports.DeleteReport(id)
+	_, err = client.Reports.DeleteReportWithParams(reports.NewDeleteReportParams().WithID(id).WithContext(ctx))
 	diag, _ := common.CheckReadError("report", d, err)
 	return diag
 }
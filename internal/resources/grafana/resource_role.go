@@ -111,14 +111,14 @@ func resourceRole() *common.Resource {
 		"grafana_role",
 		orgResourceIDString("uid"),
 		schema,
-	)
+	).WithGrafanaRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 func CreateRole(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 	if d.Get("global").(bool) {
 		orgID = 0
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 
 	var version int
@@ -140,7 +140,7 @@ func CreateRole(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		Permissions: permissions(d),
 	}
 
-	resp, err := client.AccessControl.CreateRole(&role)
+	resp, err := client.AccessControl.CreateRoleWithParams(access_control.NewCreateRoleParams().WithBody(&role).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -171,13 +171,13 @@ func ReadRole(ctx context.Context, d *schema.ResourceData, meta interface{}) dia
 	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
 	if d.Get("global").(bool) {
 		var orgID int64 = 0
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
-	return readRoleFromUID(client, uid, d)
+	return readRoleFromUID(ctx, client, uid, d)
 }
 
-func readRoleFromUID(client *goapi.GrafanaHTTPAPI, uid string, d *schema.ResourceData) diag.Diagnostics {
-	resp, err := client.AccessControl.GetRole(uid)
+func readRoleFromUID(ctx context.Context, client *goapi.GrafanaHTTPAPI, uid string, d *schema.ResourceData) diag.Diagnostics {
+	resp, err := client.AccessControl.GetRoleWithParams(access_control.NewGetRoleParams().WithRoleUID(uid).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("role", d, err); shouldReturn {
 		return err
 	}
@@ -235,7 +235,7 @@ func UpdateRole(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
 	if d.Get("global").(bool) {
 		var orgID int64 = 0
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 
 	if d.HasChange("version") || d.HasChange("name") || d.HasChange("description") || d.HasChange("permissions") ||
@@ -255,7 +255,7 @@ func UpdateRole(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 			Version:     int64(version),
 			Permissions: permissions(d),
 		}
-		if _, err := client.AccessControl.UpdateRole(uid, &r); err != nil {
+		if _, err := client.AccessControl.UpdateRoleWithParams(access_control.NewUpdateRoleParams().WithRoleUID(uid).WithBody(&r).WithContext(ctx)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -268,9 +268,9 @@ func DeleteRole(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	global := d.Get("global").(bool)
 	if global {
 		var orgID int64 = 0
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
-	_, err := client.AccessControl.DeleteRole(access_control.NewDeleteRoleParams().WithRoleUID(uid).WithGlobal(&global), nil)
+	_, err := client.AccessControl.DeleteRole(access_control.NewDeleteRoleParams().WithRoleUID(uid).WithGlobal(&global).WithContext(ctx), nil)
 	diag, _ := common.CheckReadError("role", d, err)
 	return diag
 }
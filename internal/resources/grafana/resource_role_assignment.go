@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/grafana/grafana-openapi-client-go/client/access_control"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -77,12 +78,12 @@ Manages the entire set of assignments for a role. Assignments that aren't specif
 		"grafana_role_assignment",
 		orgResourceIDString("roleUID"),
 		schema,
-	)
+	).WithGrafanaRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 func ReadRoleAssignments(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
-	resp, err := client.AccessControl.GetRoleAssignments(uid)
+	resp, err := client.AccessControl.GetRoleAssignmentsWithParams(access_control.NewGetRoleAssignmentsParams().WithRoleUID(uid).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("role assignments", d, err); shouldReturn {
 		return err
 	}
@@ -103,7 +104,7 @@ func UpdateRoleAssignments(ctx context.Context, d *schema.ResourceData, meta int
 		Teams:           collectRoleAssignents(d.Get("teams"), true),
 		ServiceAccounts: collectRoleAssignents(d.Get("service_accounts"), true),
 	}
-	if _, err := client.AccessControl.SetRoleAssignments(uid, &ra); err != nil {
+	if _, err := client.AccessControl.SetRoleAssignmentsWithParams(access_control.NewSetRoleAssignmentsParams().WithRoleUID(uid).WithBody(&ra).WithContext(ctx)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -114,11 +115,11 @@ func UpdateRoleAssignments(ctx context.Context, d *schema.ResourceData, meta int
 func DeleteRoleAssignments(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	_, err := client.AccessControl.SetRoleAssignments(uid, &models.SetRoleAssignmentsCommand{
+	_, err := client.AccessControl.SetRoleAssignmentsWithParams(access_control.NewSetRoleAssignmentsParams().WithRoleUID(uid).WithBody(&models.SetRoleAssignmentsCommand{
 		ServiceAccounts: []int64{},
 		Teams:           []int64{},
 		Users:           []int64{},
-	})
+	}).WithContext(ctx))
 	return diag.FromErr(err)
 }
 
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"sync"
 
+	"github.com/grafana/grafana-openapi-client-go/client/access_control"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -28,16 +29,17 @@ var (
 )
 
 func makeResourceRoleAssignmentItem() *common.Resource {
-	return common.NewResource(resourceRoleAssignmentItemName, resourceRoleAssignmentItemID, &resourceRoleAssignmentItem{})
+	return common.NewResource(resourceRoleAssignmentItemName, resourceRoleAssignmentItemID, &resourceRoleAssignmentItem{}).WithGrafanaRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 type resourceRoleAssignmentItemModel struct {
-	ID               types.String `tfsdk:"id"`
-	OrgID            types.String `tfsdk:"org_id"`
-	RoleUID          types.String `tfsdk:"role_uid"`
-	TeamID           types.String `tfsdk:"team_id"`
-	UserID           types.String `tfsdk:"user_id"`
-	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	ID               types.String             `tfsdk:"id"`
+	OrgID            types.String             `tfsdk:"org_id"`
+	RoleUID          types.String             `tfsdk:"role_uid"`
+	TeamID           types.String             `tfsdk:"team_id"`
+	UserID           types.String             `tfsdk:"user_id"`
+	ServiceAccountID types.String             `tfsdk:"service_account_id"`
+	Timeouts         *common.ResourceTimeouts `tfsdk:"timeouts"`
 }
 
 type resourceRoleAssignmentItem struct {
@@ -105,13 +107,16 @@ func (r *resourceRoleAssignmentItem) Schema(ctx context.Context, req resource.Sc
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(),
+		},
 	}
 }
 
 func (r *resourceRoleAssignmentItem) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resourceRoleAssignmentMutex.RLock()
 	defer resourceRoleAssignmentMutex.RUnlock()
-	data, diags := r.read(req.ID)
+	data, diags := r.read(ctx, req.ID)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -141,9 +146,9 @@ func (r *resourceRoleAssignmentItem) Create(ctx context.Context, req resource.Cr
 	// Get existing role assignments
 	resourceRoleAssignmentMutex.Lock()
 	defer resourceRoleAssignmentMutex.Unlock()
-	getResp, err := client.AccessControl.GetRoleAssignments(data.RoleUID.ValueString())
+	getResp, err := client.AccessControl.GetRoleAssignmentsWithParams(access_control.NewGetRoleAssignmentsParams().WithRoleUID(data.RoleUID.ValueString()).WithContext(ctx))
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to get role assignments", err.Error())
+		resp.Diagnostics.Append(common.APIErrorDiagnosticsPluginFramework("Failed to get role assignments", err)...)
 		return
 	}
 	roleAssignments := getResp.Payload
@@ -182,13 +187,13 @@ func (r *resourceRoleAssignmentItem) Create(ctx context.Context, req resource.Cr
 		resourceID = serviceAccountIDStr
 	}
 
-	_, err = client.AccessControl.SetRoleAssignments(data.RoleUID.ValueString(), &models.SetRoleAssignmentsCommand{
+	_, err = client.AccessControl.SetRoleAssignmentsWithParams(access_control.NewSetRoleAssignmentsParams().WithRoleUID(data.RoleUID.ValueString()).WithBody(&models.SetRoleAssignmentsCommand{
 		Teams:           roleAssignments.Teams,
 		Users:           roleAssignments.Users,
 		ServiceAccounts: roleAssignments.ServiceAccounts,
-	})
+	}).WithContext(ctx))
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to set role assignments", err.Error())
+		resp.Diagnostics.Append(common.APIErrorDiagnosticsPluginFramework("Failed to set role assignments", err)...)
 		return
 	}
 
@@ -206,23 +211,29 @@ func (r *resourceRoleAssignmentItem) Read(ctx context.Context, req resource.Read
 	// Read from API
 	resourceRoleAssignmentMutex.RLock()
 	defer resourceRoleAssignmentMutex.RUnlock()
-	readData, diags := r.read(data.ID.ValueString())
+	readData, diags := r.read(ctx, data.ID.ValueString())
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
 	if readData == nil {
-		resp.State.RemoveResource(ctx)
+		common.WarnMissingPluginFramework(ctx, "role assignment item", data.ID.ValueString(), resp)
 		return
 	}
 
 	// Save data into Terraform state
+	readData.Timeouts = data.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, readData)...)
 }
 
 func (r *resourceRoleAssignmentItem) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Update shouldn't happen as all attributes require replacement
-	resp.Diagnostics.AddError("Update not supported", "Update not supported")
+	// All attributes require replacement, only the timeouts can be updated
+	var data resourceRoleAssignmentItemModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
 
 func (r *resourceRoleAssignmentItem) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -240,9 +251,9 @@ func (r *resourceRoleAssignmentItem) Delete(ctx context.Context, req resource.De
 	// Get existing role assignments
 	resourceRoleAssignmentMutex.Lock()
 	defer resourceRoleAssignmentMutex.Unlock()
-	getResp, err := client.AccessControl.GetRoleAssignments(roleUID)
+	getResp, err := client.AccessControl.GetRoleAssignmentsWithParams(access_control.NewGetRoleAssignmentsParams().WithRoleUID(roleUID).WithContext(ctx))
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to get role assignments", err.Error())
+		resp.Diagnostics.Append(common.APIErrorDiagnosticsPluginFramework("Failed to get role assignments", err)...)
 		return
 	}
 	roleAssignments := getResp.Payload
@@ -276,18 +287,18 @@ func (r *resourceRoleAssignmentItem) Delete(ctx context.Context, req resource.De
 		return
 	}
 
-	_, err = client.AccessControl.SetRoleAssignments(roleUID, &models.SetRoleAssignmentsCommand{
+	_, err = client.AccessControl.SetRoleAssignmentsWithParams(access_control.NewSetRoleAssignmentsParams().WithRoleUID(roleUID).WithBody(&models.SetRoleAssignmentsCommand{
 		Teams:           roleAssignments.Teams,
 		Users:           roleAssignments.Users,
 		ServiceAccounts: roleAssignments.ServiceAccounts,
-	})
+	}).WithContext(ctx))
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to set role assignments", err.Error())
+		resp.Diagnostics.Append(common.APIErrorDiagnosticsPluginFramework("Failed to set role assignments", err)...)
 		return
 	}
 }
 
-func (r *resourceRoleAssignmentItem) read(id string) (*resourceRoleAssignmentItemModel, diag.Diagnostics) {
+func (r *resourceRoleAssignmentItem) read(ctx context.Context, id string) (*resourceRoleAssignmentItemModel, diag.Diagnostics) {
 	client, orgID, idFields, err := r.clientFromExistingOrgResource(resourceRoleAssignmentItemID, id)
 	if err != nil {
 		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get client", err.Error())}
@@ -295,7 +306,7 @@ func (r *resourceRoleAssignmentItem) read(id string) (*resourceRoleAssignmentIte
 	roleUID, assignmentType, identifier := idFields[0].(string), idFields[1].(string), idFields[2].(string)
 
 	// Try to get the role
-	_, err = client.AccessControl.GetRole(roleUID)
+	_, err = client.AccessControl.GetRoleWithParams(access_control.NewGetRoleParams().WithRoleUID(roleUID).WithContext(ctx))
 	if err != nil {
 		if common.IsNotFoundError(err) {
 			return nil, nil
@@ -304,7 +315,7 @@ func (r *resourceRoleAssignmentItem) read(id string) (*resourceRoleAssignmentIte
 	}
 
 	// Get existing role assignments
-	getResp, err := client.AccessControl.GetRoleAssignments(roleUID)
+	getResp, err := client.AccessControl.GetRoleAssignmentsWithParams(access_control.NewGetRoleAssignmentsParams().WithRoleUID(roleUID).WithContext(ctx))
 	if err != nil {
 		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get role assignments", err.Error())}
 	}
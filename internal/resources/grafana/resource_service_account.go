@@ -77,7 +77,7 @@ func CreateServiceAccount(ctx context.Context, d *schema.ResourceData, meta inte
 
 	var sa *models.ServiceAccountDTO
 	err := retry.RetryContext(ctx, 10*time.Second, func() *retry.RetryError {
-		params := service_accounts.NewCreateServiceAccountParams().WithBody(&req)
+		params := service_accounts.NewCreateServiceAccountParams().WithBody(&req).WithContext(ctx)
 		resp, err := client.ServiceAccounts.CreateServiceAccount(params)
 		if err == nil {
 			sa = resp.Payload
@@ -87,7 +87,7 @@ func CreateServiceAccount(ctx context.Context, d *schema.ResourceData, meta inte
 		if err, ok := err.(*service_accounts.CreateServiceAccountInternalServerError); ok {
 			// Sometimes on 500s, the service account is created but the response is not returned.
 			// If we just retry, it will conflict because the SA was actually created.
-			foundSa, readErr := findServiceAccountByName(client, req.Name)
+			foundSa, readErr := findServiceAccountByName(ctx, client, req.Name)
 			if readErr != nil {
 				return retry.RetryableError(err)
 			}
@@ -111,7 +111,7 @@ func ReadServiceAccount(ctx context.Context, d *schema.ResourceData, meta interf
 		return diag.FromErr(err)
 	}
 
-	resp, err := client.ServiceAccounts.RetrieveServiceAccount(id)
+	resp, err := client.ServiceAccounts.RetrieveServiceAccountWithParams(service_accounts.NewRetrieveServiceAccountParams().WithServiceAccountID(id).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("service account", d, err); shouldReturn {
 		return err
 	}
@@ -140,7 +140,8 @@ func UpdateServiceAccount(ctx context.Context, d *schema.ResourceData, meta inte
 
 	params := service_accounts.NewUpdateServiceAccountParams().
 		WithBody(&updateRequest).
-		WithServiceAccountID(id)
+		WithServiceAccountID(id).
+		WithContext(ctx)
 	if _, err := client.ServiceAccounts.UpdateServiceAccount(params); err != nil {
 		return diag.FromErr(err)
 	}
@@ -155,7 +156,7 @@ func DeleteServiceAccount(ctx context.Context, d *schema.ResourceData, meta inte
 		return diag.FromErr(err)
 	}
 
-	_, err = client.ServiceAccounts.DeleteServiceAccount(id)
+	_, err = client.ServiceAccounts.DeleteServiceAccountWithParams(service_accounts.NewDeleteServiceAccountParams().WithServiceAccountID(id).WithContext(ctx))
 	diag, _ := common.CheckReadError("service account", d, err)
 	return diag
 }
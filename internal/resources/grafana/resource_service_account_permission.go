@@ -1,8 +1,10 @@
 package grafana
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -52,7 +54,7 @@ Manages the entire set of permissions for a service account. Permissions that ar
 	)
 }
 
-func resourceServiceAccountPermissionGet(d *schema.ResourceData, meta interface{}) (string, error) {
+func resourceServiceAccountPermissionGet(ctx context.Context, d *schema.ResourceData, meta interface{}) (string, error) {
 	client, _ := OAPIClientFromNewOrgResource(meta, d)
 	_, id := SplitServiceAccountID(d.Get("service_account_id").(string))
 	if d.Id() != "" {
@@ -62,7 +64,7 @@ func resourceServiceAccountPermissionGet(d *schema.ResourceData, meta interface{
 	if err != nil {
 		return "", err
 	}
-	resp, err := client.ServiceAccounts.RetrieveServiceAccount(idInt)
+	resp, err := client.ServiceAccounts.RetrieveServiceAccountWithParams(service_accounts.NewRetrieveServiceAccountParams().WithServiceAccountID(idInt).WithContext(ctx))
 	if err != nil {
 		return "", err
 	}
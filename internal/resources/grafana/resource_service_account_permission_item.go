@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -34,12 +35,13 @@ func makeResourceServiceAccountPermissionItem() *common.Resource {
 }
 
 type resourceServiceAccountPermissionItemModel struct {
-	ID               types.String `tfsdk:"id"`
-	OrgID            types.String `tfsdk:"org_id"`
-	Team             types.String `tfsdk:"team"`
-	User             types.String `tfsdk:"user"`
-	Permission       types.String `tfsdk:"permission"`
-	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	ID               types.String             `tfsdk:"id"`
+	OrgID            types.String             `tfsdk:"org_id"`
+	Team             types.String             `tfsdk:"team"`
+	User             types.String             `tfsdk:"user"`
+	Permission       types.String             `tfsdk:"permission"`
+	ServiceAccountID types.String             `tfsdk:"service_account_id"`
+	Timeouts         *common.ResourceTimeouts `tfsdk:"timeouts"`
 }
 
 // Framework doesn't support embedding a base struct: https://github.com/hashicorp/terraform-plugin-framework/issues/242
@@ -82,6 +84,9 @@ func (r *resourceServiceAccountPermissionItem) Schema(ctx context.Context, req r
 				},
 			},
 		}),
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(),
+		},
 	}
 
 	// Role is not supported for service account permissions
@@ -100,7 +105,7 @@ func (r *resourceServiceAccountPermissionItem) Schema(ctx context.Context, req r
 }
 
 func (r *resourceServiceAccountPermissionItem) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	readData, diags := r.readItem(req.ID, r.serviceAccountQuery)
+	readData, diags := r.readItem(ctx, req.ID, r.serviceAccountQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -123,7 +128,7 @@ func (r *resourceServiceAccountPermissionItem) Create(ctx context.Context, req r
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.ServiceAccountID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.ServiceAccountID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -139,13 +144,13 @@ func (r *resourceServiceAccountPermissionItem) Read(ctx context.Context, req res
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
 	// Read from API
-	readData, diags := r.readItem(data.ID.ValueString(), r.serviceAccountQuery)
+	readData, diags := r.readItem(ctx, data.ID.ValueString(), r.serviceAccountQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
 	if readData == nil {
-		resp.State.RemoveResource(ctx)
+		common.WarnMissingPluginFramework(ctx, "service account permission item", data.ID.ValueString(), resp)
 		return
 	}
 	readData.ResourceID = data.ServiceAccountID
@@ -163,7 +168,7 @@ func (r *resourceServiceAccountPermissionItem) Update(ctx context.Context, req r
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.ServiceAccountID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.ServiceAccountID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -179,16 +184,16 @@ func (r *resourceServiceAccountPermissionItem) Delete(ctx context.Context, req r
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	data.Permission = types.StringValue("")
 
-	if diags := r.writeItem(data.ServiceAccountID.ValueString(), data.ToBase()); diags != nil {
+	if diags := r.writeItem(ctx, data.ServiceAccountID.ValueString(), data.ToBase()); diags != nil {
 		resp.Diagnostics = diags
 	}
 }
 
-func (r *resourceServiceAccountPermissionItem) serviceAccountQuery(client *client.GrafanaHTTPAPI, serviceAccountID string) error {
+func (r *resourceServiceAccountPermissionItem) serviceAccountQuery(ctx context.Context, client *client.GrafanaHTTPAPI, serviceAccountID string) error {
 	idNumerical, err := strconv.ParseInt(serviceAccountID, 10, 64)
 	if err != nil {
 		return err
 	}
-	_, err = client.ServiceAccounts.RetrieveServiceAccount(idNumerical)
+	_, err = client.ServiceAccounts.RetrieveServiceAccountWithParams(service_accounts.NewRetrieveServiceAccountParams().WithServiceAccountID(idNumerical).WithContext(ctx))
 	return err
 }
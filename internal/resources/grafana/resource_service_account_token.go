@@ -71,7 +71,7 @@ func resourceServiceAccountToken() *common.Resource {
 
 func serviceAccountTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	orgID, serviceAccountIDStr := SplitOrgResourceID(d.Get("service_account_id").(string))
-	c := m.(*common.Client).GrafanaAPI.Clone().WithOrgID(orgID)
+	c := common.GrafanaAPIWithOrgID(m.(*common.Client).GrafanaAPI, orgID)
 	serviceAccountID, err := strconv.ParseInt(serviceAccountIDStr, 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
@@ -84,7 +84,7 @@ func serviceAccountTokenCreate(ctx context.Context, d *schema.ResourceData, m in
 		Name:          name,
 		SecondsToLive: int64(ttl),
 	}
-	params := service_accounts.NewCreateTokenParams().WithServiceAccountID(serviceAccountID).WithBody(&request)
+	params := service_accounts.NewCreateTokenParams().WithServiceAccountID(serviceAccountID).WithBody(&request).WithContext(ctx)
 	response, err := c.ServiceAccounts.CreateToken(params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -103,13 +103,13 @@ func serviceAccountTokenCreate(ctx context.Context, d *schema.ResourceData, m in
 
 func serviceAccountTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	orgID, serviceAccountIDStr := SplitOrgResourceID(d.Get("service_account_id").(string))
-	c := m.(*common.Client).GrafanaAPI.Clone().WithOrgID(orgID)
+	c := common.GrafanaAPIWithOrgID(m.(*common.Client).GrafanaAPI, orgID)
 	serviceAccountID, err := strconv.ParseInt(serviceAccountIDStr, 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	response, err := c.ServiceAccounts.ListTokens(serviceAccountID)
+	response, err := c.ServiceAccounts.ListTokensWithParams(service_accounts.NewListTokensParams().WithServiceAccountID(serviceAccountID).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -145,7 +145,7 @@ func serviceAccountTokenRead(ctx context.Context, d *schema.ResourceData, m inte
 
 func serviceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	orgID, serviceAccountIDStr := SplitOrgResourceID(d.Get("service_account_id").(string))
-	c := m.(*common.Client).GrafanaAPI.Clone().WithOrgID(orgID)
+	c := common.GrafanaAPIWithOrgID(m.(*common.Client).GrafanaAPI, orgID)
 	serviceAccountID, err := strconv.ParseInt(serviceAccountIDStr, 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
@@ -156,7 +156,7 @@ func serviceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
-	_, err = c.ServiceAccounts.DeleteToken(id, serviceAccountID)
+	_, err = c.ServiceAccounts.DeleteTokenWithParams(service_accounts.NewDeleteTokenParams().WithTokenID(id).WithServiceAccountID(serviceAccountID).WithContext(ctx))
 
 	return diag.FromErr(err)
 }
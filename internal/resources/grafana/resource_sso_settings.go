@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/grafana/grafana-openapi-client-go/client/sso_settings"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -451,7 +452,7 @@ func ReadSSOSettings(ctx context.Context, d *schema.ResourceData, meta interface
 		return diag.FromErr(err)
 	}
 
-	resp, err := client.SsoSettings.GetProviderSettings(provider)
+	resp, err := client.SsoSettings.GetProviderSettingsWithParams(sso_settings.NewGetProviderSettingsParams().WithKey(provider).WithContext(ctx))
 	if err != nil {
 		return diag.Errorf("failed to get the SSO settings for provider %s: %v", provider, err)
 	}
@@ -548,7 +549,7 @@ func UpdateSSOSettings(ctx context.Context, d *schema.ResourceData, meta interfa
 		Settings: settings,
 	}
 
-	_, err = client.SsoSettings.UpdateProviderSettings(provider, &ssoSettings)
+	_, err = client.SsoSettings.UpdateProviderSettingsWithParams(sso_settings.NewUpdateProviderSettingsParams().WithKey(provider).WithBody(&ssoSettings).WithContext(ctx))
 	if err != nil {
 		return diag.Errorf("failed to create the SSO settings for provider %s: %v", provider, err)
 	}
@@ -563,7 +564,7 @@ func DeleteSSOSettings(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	provider := d.Get(providerKey).(string)
 
-	if _, err := client.SsoSettings.RemoveProviderSettings(provider); err != nil {
+	if _, err := client.SsoSettings.RemoveProviderSettingsWithParams(sso_settings.NewRemoveProviderSettingsParams().WithKey(provider).WithContext(ctx)); err != nil {
 		return diag.Errorf("failed to remove the SSO settings for provider %s: %v", provider, err)
 	}
 
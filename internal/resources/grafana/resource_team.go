@@ -7,6 +7,9 @@ import (
 	"strconv"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/org"
+	"github.com/grafana/grafana-openapi-client-go/client/sync_team_groups"
+	"github.com/grafana/grafana-openapi-client-go/client/teams"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -162,7 +165,7 @@ func CreateTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		Name:  d.Get("name").(string),
 		Email: d.Get("email").(string),
 	}
-	resp, err := client.Teams.CreateTeam(&body)
+	resp, err := client.Teams.CreateTeamWithParams(teams.NewCreateTeamParams().WithBody(&body).WithContext(ctx))
 	if err != nil {
 		return diag.Errorf("error creating team: %s", err)
 	}
@@ -170,16 +173,16 @@ func CreateTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 
 	d.SetId(MakeOrgResourceID(orgID, teamID))
 	d.Set("team_id", teamID)
-	if err = UpdateMembers(client, d); err != nil {
+	if err = UpdateMembers(ctx, client, d); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := updateTeamPreferences(client, teamID, d); err != nil {
+	if err := updateTeamPreferences(ctx, client, teamID, d); err != nil {
 		return err
 	}
 
 	if _, ok := d.GetOk("team_sync"); ok {
-		if err := manageTeamExternalGroup(client, teamID, d, "team_sync.0.groups"); err != nil {
+		if err := manageTeamExternalGroup(ctx, client, teamID, d, "team_sync.0.groups"); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -191,12 +194,12 @@ func ReadTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) dia
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 	teamID, _ := strconv.ParseInt(idStr, 10, 64)
 	_, readTeamSync := d.GetOk("team_sync")
-	return readTeamFromID(client, teamID, d, readTeamSync)
+	return readTeamFromID(ctx, client, teamID, d, readTeamSync)
 }
 
-func readTeamFromID(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.ResourceData, readTeamSync bool) diag.Diagnostics {
+func readTeamFromID(ctx context.Context, client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.ResourceData, readTeamSync bool) diag.Diagnostics {
 	teamIDStr := strconv.FormatInt(teamID, 10)
-	team, err := getTeamByID(client, teamID)
+	team, err := getTeamByID(ctx, client, teamID)
 	if err, shouldReturn := common.CheckReadError("team", d, err); shouldReturn {
 		return err
 	}
@@ -209,14 +212,14 @@ func readTeamFromID(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.Resour
 		d.Set("email", team.Email)
 	}
 
-	resp, err := client.Teams.GetTeamPreferences(teamIDStr)
+	resp, err := client.Teams.GetTeamPreferencesWithParams(teams.NewGetTeamPreferencesParams().WithTeamID(teamIDStr).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	preferences := resp.GetPayload()
 
 	if readTeamSync {
-		resp, err := client.SyncTeamGroups.GetTeamGroupsAPI(teamID)
+		resp, err := client.SyncTeamGroups.GetTeamGroupsAPIWithParams(sync_team_groups.NewGetTeamGroupsAPIParams().WithTeamID(teamID).WithContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -244,7 +247,7 @@ func readTeamFromID(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.Resour
 		})
 	}
 
-	return readTeamMembers(client, d)
+	return readTeamMembers(ctx, client, d)
 }
 
 func UpdateTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -257,20 +260,20 @@ func UpdateTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 			Name:  name,
 			Email: email,
 		}
-		if _, err := client.Teams.UpdateTeam(idStr, &body); err != nil {
+		if _, err := client.Teams.UpdateTeamWithParams(teams.NewUpdateTeamParams().WithTeamID(idStr).WithBody(&body).WithContext(ctx)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
-	if err := UpdateMembers(client, d); err != nil {
+	if err := UpdateMembers(ctx, client, d); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := updateTeamPreferences(client, teamID, d); err != nil {
+	if err := updateTeamPreferences(ctx, client, teamID, d); err != nil {
 		return err
 	}
 
 	if _, ok := d.GetOk("team_sync"); ok {
-		if err := manageTeamExternalGroup(client, teamID, d, "team_sync.0.groups"); err != nil {
+		if err := manageTeamExternalGroup(ctx, client, teamID, d, "team_sync.0.groups"); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -280,12 +283,12 @@ func UpdateTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 
 func DeleteTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
-	_, err := client.Teams.DeleteTeamByID(idStr)
+	_, err := client.Teams.DeleteTeamByIDWithParams(teams.NewDeleteTeamByIDParams().WithTeamID(idStr).WithContext(ctx))
 	diag, _ := common.CheckReadError("team", d, err)
 	return diag
 }
 
-func updateTeamPreferences(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.ResourceData) diag.Diagnostics {
+func updateTeamPreferences(ctx context.Context, client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.ResourceData) diag.Diagnostics {
 	if d.IsNewResource() || d.HasChanges("preferences.0.theme", "preferences.0.home_dashboard_uid", "preferences.0.timezone", "preferences.0.week_start") {
 		body := models.UpdatePrefsCmd{
 			Theme:            d.Get("preferences.0.theme").(string),
@@ -293,15 +296,15 @@ func updateTeamPreferences(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema
 			Timezone:         d.Get("preferences.0.timezone").(string),
 			WeekStart:        d.Get("preferences.0.week_start").(string),
 		}
-		_, err := client.Teams.UpdateTeamPreferences(strconv.FormatInt(teamID, 10), &body)
+		_, err := client.Teams.UpdateTeamPreferencesWithParams(teams.NewUpdateTeamPreferencesParams().WithTeamID(strconv.FormatInt(teamID, 10)).WithBody(&body).WithContext(ctx))
 		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func readTeamMembers(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) diag.Diagnostics {
-	resp, err := client.Teams.GetTeamMembers(strconv.Itoa(d.Get("team_id").(int)))
+func readTeamMembers(ctx context.Context, client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) diag.Diagnostics {
+	resp, err := client.Teams.GetTeamMembersWithParams(teams.NewGetTeamMembersParams().WithTeamID(strconv.Itoa(d.Get("team_id").(int))).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -326,7 +329,7 @@ func readTeamMembers(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) diag.
 	return nil
 }
 
-func UpdateMembers(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) error {
+func UpdateMembers(ctx context.Context, client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) error {
 	stateMembers, configMembers, err := collectMembers(d)
 	if err != nil {
 		return err
@@ -334,12 +337,12 @@ func UpdateMembers(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) error {
 	// compile the list of differences between current state and config
 	changes := memberChanges(stateMembers, configMembers)
 	// retrieves the corresponding user IDs based on the email provided
-	changes, err = addMemberIdsToChanges(client, changes)
+	changes, err = addMemberIdsToChanges(ctx, client, changes)
 	if err != nil {
 		return err
 	}
 	// now we can make the corresponding updates so current state matches config
-	return applyMemberChanges(client, int64(d.Get("team_id").(int)), changes)
+	return applyMemberChanges(ctx, client, int64(d.Get("team_id").(int)), changes)
 }
 
 func collectMembers(d *schema.ResourceData) (map[string]TeamMember, map[string]TeamMember, error) {
@@ -387,10 +390,10 @@ func memberChanges(stateMembers, configMembers map[string]TeamMember) []MemberCh
 	return changes
 }
 
-func addMemberIdsToChanges(client *goapi.GrafanaHTTPAPI, changes []MemberChange) ([]MemberChange, error) {
+func addMemberIdsToChanges(ctx context.Context, client *goapi.GrafanaHTTPAPI, changes []MemberChange) ([]MemberChange, error) {
 	gUserMap := make(map[string]int64)
 
-	resp, err := client.Org.GetOrgUsersForCurrentOrg()
+	resp, err := client.Org.GetOrgUsersForCurrentOrgWithParams(org.NewGetOrgUsersForCurrentOrgParams().WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -417,15 +420,15 @@ func addMemberIdsToChanges(client *goapi.GrafanaHTTPAPI, changes []MemberChange)
 	return output, nil
 }
 
-func applyMemberChanges(client *goapi.GrafanaHTTPAPI, teamID int64, changes []MemberChange) error {
+func applyMemberChanges(ctx context.Context, client *goapi.GrafanaHTTPAPI, teamID int64, changes []MemberChange) error {
 	var err error
 	for _, change := range changes {
 		u := change.Member
 		switch change.Type {
 		case AddMember:
-			_, err = client.Teams.AddTeamMember(strconv.FormatInt(teamID, 10), &models.AddTeamMemberCommand{UserID: u.ID})
+			_, err = client.Teams.AddTeamMemberWithParams(teams.NewAddTeamMemberParams().WithTeamID(strconv.FormatInt(teamID, 10)).WithBody(&models.AddTeamMemberCommand{UserID: u.ID}).WithContext(ctx))
 		case RemoveMember:
-			_, err = client.Teams.RemoveTeamMember(u.ID, strconv.FormatInt(teamID, 10))
+			_, err = client.Teams.RemoveTeamMemberWithParams(teams.NewRemoveTeamMemberParams().WithUserID(u.ID).WithTeamID(strconv.FormatInt(teamID, 10)).WithContext(ctx))
 		}
 		if err != nil {
 			return err
@@ -434,8 +437,8 @@ func applyMemberChanges(client *goapi.GrafanaHTTPAPI, teamID int64, changes []Me
 	return nil
 }
 
-func getTeamByID(client *goapi.GrafanaHTTPAPI, teamID int64) (*models.TeamDTO, error) {
-	resp, err := client.Teams.GetTeamByID(strconv.FormatInt(teamID, 10))
+func getTeamByID(ctx context.Context, client *goapi.GrafanaHTTPAPI, teamID int64) (*models.TeamDTO, error) {
+	resp, err := client.Teams.GetTeamByIDWithParams(teams.NewGetTeamByIDParams().WithTeamID(strconv.FormatInt(teamID, 10)).WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
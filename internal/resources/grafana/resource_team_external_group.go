@@ -53,7 +53,7 @@ func resourceTeamExternalGroup() *common.Resource {
 		"grafana_team_external_group",
 		orgResourceIDInt("teamID"),
 		schema,
-	)
+	).WithGrafanaRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 func CreateTeamExternalGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -62,7 +62,7 @@ func CreateTeamExternalGroup(ctx context.Context, d *schema.ResourceData, meta i
 	d.SetId(MakeOrgResourceID(orgID, teamID))
 	client, _, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	if err := manageTeamExternalGroup(client, teamID, d, "groups"); err != nil {
+	if err := manageTeamExternalGroup(ctx, client, teamID, d, "groups"); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -73,7 +73,7 @@ func ReadTeamExternalGroup(ctx context.Context, d *schema.ResourceData, meta int
 	client, orgID, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 	teamID, _ := strconv.ParseInt(idStr, 10, 64)
 
-	resp, err := client.SyncTeamGroups.GetTeamGroupsAPI(teamID)
+	resp, err := client.SyncTeamGroups.GetTeamGroupsAPIWithParams(teamsSync.NewGetTeamGroupsAPIParams().WithTeamID(teamID).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("team groups", d, err); shouldReturn {
 		return err
 	}
@@ -94,7 +94,7 @@ func UpdateTeamExternalGroup(ctx context.Context, d *schema.ResourceData, meta i
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 	teamID, _ := strconv.ParseInt(idStr, 10, 64)
 
-	if err := manageTeamExternalGroup(client, teamID, d, "groups"); err != nil {
+	if err := manageTeamExternalGroup(ctx, client, teamID, d, "groups"); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -104,30 +104,30 @@ func UpdateTeamExternalGroup(ctx context.Context, d *schema.ResourceData, meta i
 func DeleteTeamExternalGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 	teamID, _ := strconv.ParseInt(idStr, 10, 64)
-	if err := applyTeamExternalGroup(client, teamID, nil, common.SetToStringSlice(d.Get("groups").(*schema.Set))); err != nil {
+	if err := applyTeamExternalGroup(ctx, client, teamID, nil, common.SetToStringSlice(d.Get("groups").(*schema.Set))); err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func manageTeamExternalGroup(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.ResourceData, groupsAttr string) error {
+func manageTeamExternalGroup(ctx context.Context, client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.ResourceData, groupsAttr string) error {
 	addGroups, removeGroups := groupChangesTeamExternalGroup(d, groupsAttr)
-	return applyTeamExternalGroup(client, teamID, addGroups, removeGroups)
+	return applyTeamExternalGroup(ctx, client, teamID, addGroups, removeGroups)
 }
 
-func applyTeamExternalGroup(client *goapi.GrafanaHTTPAPI, teamID int64, addGroups, removeGroups []string) error {
+func applyTeamExternalGroup(ctx context.Context, client *goapi.GrafanaHTTPAPI, teamID int64, addGroups, removeGroups []string) error {
 	for _, group := range addGroups {
 		body := models.TeamGroupMapping{
 			GroupID: group,
 		}
-		if _, err := client.SyncTeamGroups.AddTeamGroupAPI(teamID, &body); err != nil {
+		if _, err := client.SyncTeamGroups.AddTeamGroupAPIWithParams(teamsSync.NewAddTeamGroupAPIParams().WithTeamID(teamID).WithBody(&body).WithContext(ctx)); err != nil {
 			return fmt.Errorf("error adding group %s to team %d: %w", group, teamID, err)
 		}
 	}
 
 	for _, group := range removeGroups {
 		group := group
-		params := teamsSync.NewRemoveTeamGroupAPIQueryParams().WithTeamID(teamID).WithGroupID(&group)
+		params := teamsSync.NewRemoveTeamGroupAPIQueryParams().WithTeamID(teamID).WithGroupID(&group).WithContext(ctx)
 		if _, err := client.SyncTeamGroups.RemoveTeamGroupAPIQuery(params); err != nil {
 			return fmt.Errorf("error removing group %s from team %d: %w", group, teamID, err)
 		}
@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/grafana/grafana-openapi-client-go/client/admin_users"
+	"github.com/grafana/grafana-openapi-client-go/client/users"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -88,13 +90,13 @@ func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		Login:    d.Get("login").(string),
 		Password: models.Password(d.Get("password").(string)),
 	}
-	resp, err := client.AdminUsers.AdminCreateUser(&user)
+	resp, err := client.AdminUsers.AdminCreateUserWithParams(admin_users.NewAdminCreateUserParams().WithBody(&user).WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	if d.HasChange("is_admin") {
 		perm := models.AdminUpdateUserPermissionsForm{IsGrafanaAdmin: d.Get("is_admin").(bool)}
-		if _, err = client.AdminUsers.AdminUpdateUserPermissions(resp.Payload.ID, &perm); err != nil {
+		if _, err = client.AdminUsers.AdminUpdateUserPermissionsWithParams(admin_users.NewAdminUpdateUserPermissionsParams().WithUserID(resp.Payload.ID).WithBody(&perm).WithContext(ctx)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -111,7 +113,7 @@ func ReadUser(ctx context.Context, d *schema.ResourceData, meta interface{}) dia
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	resp, err := client.Users.GetUserByID(id)
+	resp, err := client.Users.GetUserByIDWithParams(users.NewGetUserByIDParams().WithUserID(id).WithContext(ctx))
 	if err, shouldReturn := common.CheckReadError("user", d, err); shouldReturn {
 		return err
 	}
@@ -139,18 +141,18 @@ func UpdateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		Name:  d.Get("name").(string),
 		Login: d.Get("login").(string),
 	}
-	if _, err = client.Users.UpdateUser(id, &u); err != nil {
+	if _, err = client.Users.UpdateUserWithParams(users.NewUpdateUserParams().WithUserID(id).WithBody(&u).WithContext(ctx)); err != nil {
 		return diag.FromErr(err)
 	}
 	if d.HasChange("password") {
 		f := models.AdminUpdateUserPasswordForm{Password: models.Password(d.Get("password").(string))}
-		if _, err = client.AdminUsers.AdminUpdateUserPassword(id, &f); err != nil {
+		if _, err = client.AdminUsers.AdminUpdateUserPasswordWithParams(admin_users.NewAdminUpdateUserPasswordParams().WithUserID(id).WithBody(&f).WithContext(ctx)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 	if d.HasChange("is_admin") {
 		f := models.AdminUpdateUserPermissionsForm{IsGrafanaAdmin: d.Get("is_admin").(bool)}
-		if _, err = client.AdminUsers.AdminUpdateUserPermissions(id, &f); err != nil {
+		if _, err = client.AdminUsers.AdminUpdateUserPermissionsWithParams(admin_users.NewAdminUpdateUserPermissionsParams().WithUserID(id).WithBody(&f).WithContext(ctx)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -166,7 +168,7 @@ func DeleteUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	_, err = client.AdminUsers.AdminDeleteUser(id)
+	_, err = client.AdminUsers.AdminDeleteUserWithParams(admin_users.NewAdminDeleteUserParams().WithUserID(id).WithContext(ctx))
 	diag, _ := common.CheckReadError("user", d, err)
 	return diag
 }
@@ -86,10 +86,15 @@ func addValidationToList(resources []*common.Resource) []*common.Resource {
 
 var DatasourcesMap = addValidationToMap(map[string]*schema.Resource{
 	"grafana_dashboard":                datasourceDashboard(),
+	"grafana_dashboard_model":          datasourceDashboardModel(),
 	"grafana_dashboards":               datasourceDashboards(),
+	"grafana_dashboard_snapshots":      datasourceDashboardSnapshots(),
+	"grafana_dashboard_versions":       datasourceDashboardVersions(),
 	"grafana_data_source":              datasourceDatasource(),
 	"grafana_folder":                   datasourceFolder(),
 	"grafana_folders":                  datasourceFolders(),
+	"grafana_folder_tree":              datasourceFolderTree(),
+	"grafana_instance_info":            datasourceInstanceInfo(),
 	"grafana_library_panel":            datasourceLibraryPanel(),
 	"grafana_user":                     datasourceUser(),
 	"grafana_users":                    datasourceUsers(),
@@ -109,6 +114,9 @@ var Resources = addValidationToList([]*common.Resource{
 	resourceAnnotation(),
 	resourceContactPoint(),
 	resourceDashboard(),
+	resourceDashboardBundle(),
+	resourceDashboardRollback(),
+	resourceDashboardSnapshot(),
 	resourcePublicDashboard(),
 	resourceDashboardPermission(),
 	resourceDataSource(),
@@ -116,7 +124,7 @@ var Resources = addValidationToList([]*common.Resource{
 	resourceDatasourcePermission(),
 	resourceFolder(),
 	resourceFolderPermission(),
-	resourceLibraryPanel(),
+	makeResourceLibraryPanel(),
 	resourceMessageTemplate(),
 	resourceMuteTiming(),
 	resourceNotificationPolicy(),
@@ -9,27 +9,26 @@ import (
 	"github.com/grafana/machine-learning-go-client/mlapi"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceHoliday(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	randomName := acctest.RandomWithPrefix("holiday")
+	randomName := cassette.RandomWithPrefix("holiday")
 
 	var holiday mlapi.Holiday
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccMLHolidayCheckDestroy(&holiday),
+	cassette.Test(t, resource.TestCase{
+		CheckDestroy: testAccMLHolidayCheckDestroy(client, &holiday),
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_machine_learning_holiday/ical_holiday.tf", map[string]string{
 					"My iCal holiday": randomName,
 				}),
 				Check: resource.ComposeTestCheckFunc(
-					testAccMLHolidayCheckExists("grafana_machine_learning_holiday.ical", &holiday),
+					testAccMLHolidayCheckExists(client, "grafana_machine_learning_holiday.ical", &holiday),
 					resource.TestCheckResourceAttrSet("grafana_machine_learning_holiday.ical", "id"),
 					resource.TestCheckResourceAttr("grafana_machine_learning_holiday.ical", "name", randomName),
 				),
@@ -39,7 +38,7 @@ func TestAccResourceHoliday(t *testing.T) {
 					"My custom periods holiday": randomName + " custom periods",
 				}),
 				Check: resource.ComposeTestCheckFunc(
-					testAccMLHolidayCheckExists("grafana_machine_learning_holiday.custom_periods", &holiday),
+					testAccMLHolidayCheckExists(client, "grafana_machine_learning_holiday.custom_periods", &holiday),
 					resource.TestCheckResourceAttrSet("grafana_machine_learning_holiday.custom_periods", "id"),
 					resource.TestCheckResourceAttr("grafana_machine_learning_holiday.custom_periods", "name", randomName+" custom periods"),
 				),
@@ -48,7 +47,7 @@ func TestAccResourceHoliday(t *testing.T) {
 	})
 }
 
-func testAccMLHolidayCheckExists(rn string, holiday *mlapi.Holiday) resource.TestCheckFunc {
+func testAccMLHolidayCheckExists(client *common.Client, rn string, holiday *mlapi.Holiday) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
 		if !ok {
@@ -59,8 +58,7 @@ func testAccMLHolidayCheckExists(rn string, holiday *mlapi.Holiday) resource.Tes
 			return fmt.Errorf("resource id not set")
 		}
 
-		client := testutils.Provider.Meta().(*common.Client).MLAPI
-		gotHoliday, err := client.Holiday(context.Background(), rs.Primary.ID)
+		gotHoliday, err := client.MLAPI.Holiday(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return fmt.Errorf("error getting holiday: %s", err)
 		}
@@ -71,15 +69,14 @@ func testAccMLHolidayCheckExists(rn string, holiday *mlapi.Holiday) resource.Tes
 	}
 }
 
-func testAccMLHolidayCheckDestroy(holiday *mlapi.Holiday) resource.TestCheckFunc {
+func testAccMLHolidayCheckDestroy(client *common.Client, holiday *mlapi.Holiday) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// This check is to make sure that no pointer conversions are incorrect
 		// while mutating holiday.
 		if holiday.ID == "" {
 			return fmt.Errorf("checking deletion of empty id")
 		}
-		client := testutils.Provider.Meta().(*common.Client).MLAPI
-		_, err := client.Holiday(context.Background(), holiday.ID)
+		_, err := client.MLAPI.Holiday(context.Background(), holiday.ID)
 		if err == nil {
 			return fmt.Errorf("holiday still exists on server")
 		}
@@ -112,10 +109,9 @@ resource "grafana_machine_learning_holiday" "invalid" {
 `
 
 func TestAccResourceInvalidMachineLearningHoliday(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config:      machineLearningHolidayInvalid,
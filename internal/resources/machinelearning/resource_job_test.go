@@ -9,22 +9,21 @@ import (
 	"github.com/grafana/machine-learning-go-client/mlapi"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceJob(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	randomName := acctest.RandomWithPrefix("Test Job")
+	randomName := cassette.RandomWithPrefix("Test Job")
 
 	var job mlapi.Job
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		CheckDestroy: resource.ComposeTestCheckFunc(
-			testAccMLJobCheckDestroy(&job),
-			testAccDatasourceCheckDestroy(),
+			testAccMLJobCheckDestroy(client, &job),
+			testAccDatasourceCheckDestroy(client),
 		),
 		Steps: []resource.TestStep{
 			{
@@ -33,7 +32,7 @@ func TestAccResourceJob(t *testing.T) {
 					"Test Job": randomName,
 				}),
 				Check: resource.ComposeTestCheckFunc(
-					testAccMLJobCheckExists("grafana_machine_learning_job.test_job", &job),
+					testAccMLJobCheckExists(client, "grafana_machine_learning_job.test_job", &job),
 					resource.TestCheckResourceAttrSet("grafana_machine_learning_job.test_job", "id"),
 					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "name", randomName),
 					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "metric", "tf_test_job"),
@@ -82,7 +81,7 @@ func TestAccResourceJob(t *testing.T) {
 	})
 }
 
-func testAccMLJobCheckExists(rn string, job *mlapi.Job) resource.TestCheckFunc {
+func testAccMLJobCheckExists(client *common.Client, rn string, job *mlapi.Job) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
 		if !ok {
@@ -93,8 +92,7 @@ func testAccMLJobCheckExists(rn string, job *mlapi.Job) resource.TestCheckFunc {
 			return fmt.Errorf("resource id not set")
 		}
 
-		client := testutils.Provider.Meta().(*common.Client).MLAPI
-		gotJob, err := client.Job(context.Background(), rs.Primary.ID)
+		gotJob, err := client.MLAPI.Job(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return fmt.Errorf("error getting job: %s", err)
 		}
@@ -105,15 +103,14 @@ func testAccMLJobCheckExists(rn string, job *mlapi.Job) resource.TestCheckFunc {
 	}
 }
 
-func testAccMLJobCheckDestroy(job *mlapi.Job) resource.TestCheckFunc {
+func testAccMLJobCheckDestroy(client *common.Client, job *mlapi.Job) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// This check is to make sure that no pointer conversions are incorrect
 		// while mutating job.
 		if job.ID == "" {
 			return fmt.Errorf("checking deletion of empty id")
 		}
-		client := testutils.Provider.Meta().(*common.Client).MLAPI
-		_, err := client.Job(context.Background(), job.ID)
+		_, err := client.MLAPI.Job(context.Background(), job.ID)
 		if err == nil {
 			return fmt.Errorf("job still exists on server")
 		}
@@ -121,12 +118,11 @@ func testAccMLJobCheckDestroy(job *mlapi.Job) resource.TestCheckFunc {
 	}
 }
 
-func testAccDatasourceCheckDestroy() resource.TestCheckFunc {
+func testAccDatasourceCheckDestroy(client *common.Client) resource.TestCheckFunc {
 	// Check the `machinelearningDatasource` has been destroyed
 	return func(s *terraform.State) error {
 		var orgID int64 = 1
-		client := testutils.Provider.Meta().(*common.Client).GrafanaAPI.WithOrgID(orgID)
-		ds, err := client.Datasources.GetDataSourceByName("prometheus-ds-test")
+		ds, err := client.GrafanaAPI.WithOrgID(orgID).Datasources.GetDataSourceByName("prometheus-ds-test")
 		if err == nil {
 			return fmt.Errorf("Datasource `%s` still exists after destroy", ds.Payload.Name)
 		}
@@ -147,10 +143,9 @@ resource "grafana_machine_learning_job" "invalid" {
 `
 
 func TestAccResourceInvalidMachineLearningJob(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config:      machineLearningJobInvalid,
@@ -9,27 +9,26 @@ import (
 	"github.com/grafana/machine-learning-go-client/mlapi"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceOutlierDetector(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	randomName := acctest.RandomWithPrefix("Outlier Detector")
+	randomName := cassette.RandomWithPrefix("Outlier Detector")
 
 	var outlier mlapi.OutlierDetector
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccMLOutlierCheckDestroy(&outlier),
+	cassette.Test(t, resource.TestCase{
+		CheckDestroy: testAccMLOutlierCheckDestroy(client, &outlier),
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_machine_learning_outlier_detector/mad.tf", map[string]string{
 					"My MAD outlier detector": "MAD " + randomName,
 				}),
 				Check: resource.ComposeTestCheckFunc(
-					testAccMLOutlierCheckExists("grafana_machine_learning_outlier_detector.my_mad_outlier_detector", &outlier),
+					testAccMLOutlierCheckExists(client, "grafana_machine_learning_outlier_detector.my_mad_outlier_detector", &outlier),
 					resource.TestCheckResourceAttrSet("grafana_machine_learning_outlier_detector.my_mad_outlier_detector", "id"),
 					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.my_mad_outlier_detector", "name", "MAD "+randomName),
 					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.my_mad_outlier_detector", "metric", "tf_test_mad_job"),
@@ -62,7 +61,7 @@ func TestAccResourceOutlierDetector(t *testing.T) {
 	})
 }
 
-func testAccMLOutlierCheckExists(rn string, outlier *mlapi.OutlierDetector) resource.TestCheckFunc {
+func testAccMLOutlierCheckExists(client *common.Client, rn string, outlier *mlapi.OutlierDetector) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
 		if !ok {
@@ -73,8 +72,7 @@ func testAccMLOutlierCheckExists(rn string, outlier *mlapi.OutlierDetector) reso
 			return fmt.Errorf("resource id not set")
 		}
 
-		client := testutils.Provider.Meta().(*common.Client).MLAPI
-		gotOutlier, err := client.OutlierDetector(context.Background(), rs.Primary.ID)
+		gotOutlier, err := client.MLAPI.OutlierDetector(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return fmt.Errorf("error getting outlier: %s", err)
 		}
@@ -85,15 +83,14 @@ func testAccMLOutlierCheckExists(rn string, outlier *mlapi.OutlierDetector) reso
 	}
 }
 
-func testAccMLOutlierCheckDestroy(outlier *mlapi.OutlierDetector) resource.TestCheckFunc {
+func testAccMLOutlierCheckDestroy(client *common.Client, outlier *mlapi.OutlierDetector) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// This check is to make sure that no pointer conversions are incorrect
 		// while mutating the outlier.
 		if outlier.ID == "" {
 			return fmt.Errorf("checking deletion of empty id")
 		}
-		client := testutils.Provider.Meta().(*common.Client).MLAPI
-		_, err := client.OutlierDetector(context.Background(), outlier.ID)
+		_, err := client.MLAPI.OutlierDetector(context.Background(), outlier.ID)
 		if err == nil {
 			return fmt.Errorf("outlier still exists on server")
 		}
@@ -169,10 +166,9 @@ resource "grafana_machine_learning_outlier_detector" "invalid" {
 `
 
 func TestAccResourceInvalidMachineLearningOutlierDetector(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config:      machineLearningOutlierDetectorInvalid,
@@ -2,9 +2,9 @@ package oncall
 
 import (
 	"context"
-	"strings"
 
 	onCallAPI "github.com/grafana/amixr-api-go-client"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -36,7 +36,7 @@ func dataSourceIntegrationRead(ctx context.Context, d *schema.ResourceData, clie
 
 	integrationResponse, _, err := client.Integrations.GetIntegration(integrationID, options)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "status: 404") {
+		if common.IsNotFoundError(err) {
 			return diag.Errorf("no integration exists with ID %q", integrationID)
 		}
 		return diag.FromErr(err)
@@ -5,26 +5,25 @@ import (
 	"testing"
 
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccDataSourceIntegration_Basic(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	randomName := fmt.Sprintf("test-name-%s", acctest.RandString(10))
+	randomName := fmt.Sprintf("test-name-%s", cassette.RandString(10))
 
 	integrationPath := "grafana_oncall_integration.test_integration"
 	dataSourcePath := "data.grafana_oncall_integration.test_integration_ds"
 
-	resource.Test(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccCheckOnCallIntegrationResourceDestroy,
+	cassette.Test(t, resource.TestCase{
+		CheckDestroy: testAccCheckOnCallIntegrationResourceDestroy(client),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceIntegration(randomName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallIntegrationResourceExists(integrationPath),
+					testAccCheckOnCallIntegrationResourceExists(client, integrationPath),
 					resource.TestCheckResourceAttr(integrationPath, "name", randomName),
 					resource.TestCheckResourceAttrPair(
 						integrationPath, "id",
@@ -6,17 +6,15 @@ import (
 	"testing"
 
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccDataSourceSchedule_Basic(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
-	scheduleName := fmt.Sprintf("test-acc-%s", acctest.RandString(8))
+	scheduleName := fmt.Sprintf("test-acc-%s", cassette.RandString(8))
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccDataSourceScheduleConfig(scheduleName),
@@ -3,8 +3,6 @@ package oncall
 import (
 	"context"
 	"fmt"
-	"log"
-	"net/http"
 	"strings"
 
 	onCallAPI "github.com/grafana/amixr-api-go-client"
@@ -341,14 +339,9 @@ func resourceEscalationCreate(ctx context.Context, d *schema.ResourceData, clien
 }
 
 func resourceEscalationRead(ctx context.Context, d *schema.ResourceData, client *onCallAPI.Client) diag.Diagnostics {
-	escalation, r, err := client.Escalations.GetEscalation(d.Id(), &onCallAPI.GetEscalationOptions{})
-	if err != nil {
-		if r != nil && r.StatusCode == http.StatusNotFound {
-			log.Printf("[WARN] removing escalation %s from state because it no longer exists", d.Id())
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
+	escalation, _, err := client.Escalations.GetEscalation(d.Id(), &onCallAPI.GetEscalationOptions{})
+	if err, shouldReturn := common.CheckReadError("escalation", d, err); shouldReturn {
+		return err
 	}
 
 	d.Set("escalation_chain_id", escalation.EscalationChainId)
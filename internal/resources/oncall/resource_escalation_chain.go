@@ -2,8 +2,6 @@ package oncall
 
 import (
 	"context"
-	"log"
-	"net/http"
 
 	onCallAPI "github.com/grafana/amixr-api-go-client"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
@@ -65,14 +63,9 @@ func resourceEscalationChainCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceEscalationChainRead(ctx context.Context, d *schema.ResourceData, client *onCallAPI.Client) diag.Diagnostics {
-	escalationChain, r, err := client.EscalationChains.GetEscalationChain(d.Id(), &onCallAPI.GetEscalationChainOptions{})
-	if err != nil {
-		if r != nil && r.StatusCode == http.StatusNotFound {
-			log.Printf("[WARN] removing escalation chain %s from state because it no longer exists", d.Get("name").(string))
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
+	escalationChain, _, err := client.EscalationChains.GetEscalationChain(d.Id(), &onCallAPI.GetEscalationChainOptions{})
+	if err, shouldReturn := common.CheckReadError("escalation chain", d, err); shouldReturn {
+		return err
 	}
 
 	d.Set("name", escalationChain.Name)
@@ -7,34 +7,33 @@ import (
 	onCallAPI "github.com/grafana/amixr-api-go-client"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccOnCallEscalation_basic(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	riName := fmt.Sprintf("test-acc-%s", acctest.RandString(8))
+	riName := fmt.Sprintf("test-acc-%s", cassette.RandString(8))
 	reType := "wait"
 	reDuration := 300
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccCheckOnCallEscalationResourceDestroy,
+	cassette.Test(t, resource.TestCase{
+		CheckDestroy: testAccCheckOnCallEscalationResourceDestroy(client),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccOnCallEscalationConfig(riName, reType, reDuration),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallEscalationResourceExists("grafana_oncall_escalation.test-acc-escalation"),
+					testAccCheckOnCallEscalationResourceExists(client, "grafana_oncall_escalation.test-acc-escalation"),
 					resource.TestCheckResourceAttr("grafana_oncall_escalation.test-acc-escalation", "type", "wait"),
 					resource.TestCheckResourceAttr("grafana_oncall_escalation.test-acc-escalation", "position", "0"),
 
-					testAccCheckOnCallEscalationResourceExists("grafana_oncall_escalation.test-acc-escalation-repeat"),
+					testAccCheckOnCallEscalationResourceExists(client, "grafana_oncall_escalation.test-acc-escalation-repeat"),
 					resource.TestCheckResourceAttr("grafana_oncall_escalation.test-acc-escalation-repeat", "type", "repeat_escalation"),
 					resource.TestCheckResourceAttr("grafana_oncall_escalation.test-acc-escalation-repeat", "position", "1"),
 
-					testAccCheckOnCallEscalationResourceExists("grafana_oncall_escalation.test-acc-escalation-policy-team"),
+					testAccCheckOnCallEscalationResourceExists(client, "grafana_oncall_escalation.test-acc-escalation-policy-team"),
 					resource.TestCheckResourceAttr("grafana_oncall_escalation.test-acc-escalation-policy-team", "type", "notify_team_members"),
 					resource.TestCheckResourceAttr("grafana_oncall_escalation.test-acc-escalation-policy-team", "position", "2"),
 					resource.TestCheckResourceAttrSet("grafana_oncall_escalation.test-acc-escalation-policy-team", "notify_to_team_members"),
@@ -44,18 +43,19 @@ func TestAccOnCallEscalation_basic(t *testing.T) {
 	})
 }
 
-func testAccCheckOnCallEscalationResourceDestroy(s *terraform.State) error {
-	client := testutils.Provider.Meta().(*common.Client).OnCallClient
-	for _, r := range s.RootModule().Resources {
-		if r.Type != "grafana_oncall_escalation" {
-			continue
-		}
-
-		if _, _, err := client.Escalations.GetEscalation(r.Primary.ID, &onCallAPI.GetEscalationOptions{}); err == nil {
-			return fmt.Errorf("Escalation still exists")
+func testAccCheckOnCallEscalationResourceDestroy(client *common.Client) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "grafana_oncall_escalation" {
+				continue
+			}
+
+			if _, _, err := client.OnCallClient.Escalations.GetEscalation(r.Primary.ID, &onCallAPI.GetEscalationOptions{}); err == nil {
+				return fmt.Errorf("Escalation still exists")
+			}
 		}
+		return nil
 	}
-	return nil
 }
 
 func testAccOnCallEscalationConfig(riName string, reType string, reDuration int) string {
@@ -102,7 +102,7 @@ resource "grafana_oncall_escalation" "test-acc-escalation-policy-team" {
 `, riName, reType, reDuration)
 }
 
-func testAccCheckOnCallEscalationResourceExists(name string) resource.TestCheckFunc {
+func testAccCheckOnCallEscalationResourceExists(client *common.Client, name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
@@ -112,9 +112,7 @@ func testAccCheckOnCallEscalationResourceExists(name string) resource.TestCheckF
 			return fmt.Errorf("No Escalation ID is set")
 		}
 
-		client := testutils.Provider.Meta().(*common.Client).OnCallClient
-
-		found, _, err := client.Escalations.GetEscalation(rs.Primary.ID, &onCallAPI.GetEscalationOptions{})
+		found, _, err := client.OnCallClient.Escalations.GetEscalation(rs.Primary.ID, &onCallAPI.GetEscalationOptions{})
 		if err != nil {
 			return err
 		}
@@ -3,8 +3,6 @@ package oncall
 import (
 	"context"
 	"fmt"
-	"log"
-	"net/http"
 	"strings"
 
 	onCallAPI "github.com/grafana/amixr-api-go-client"
@@ -330,14 +328,9 @@ func resourceIntegrationUpdate(ctx context.Context, d *schema.ResourceData, clie
 
 func resourceIntegrationRead(ctx context.Context, d *schema.ResourceData, client *onCallAPI.Client) diag.Diagnostics {
 	options := &onCallAPI.GetIntegrationOptions{}
-	integration, r, err := client.Integrations.GetIntegration(d.Id(), options)
-	if err != nil {
-		if r != nil && r.StatusCode == http.StatusNotFound {
-			log.Printf("[WARN] removing integreation %s from state because it no longer exists", d.Get("name").(string))
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
+	integration, _, err := client.Integrations.GetIntegration(d.Id(), options)
+	if err, shouldReturn := common.CheckReadError("integration", d, err); shouldReturn {
+		return err
 	}
 
 	d.Set("team_id", integration.TeamId)
@@ -7,25 +7,24 @@ import (
 	onCallAPI "github.com/grafana/amixr-api-go-client"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccOnCallIntegration_basic(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	rName := fmt.Sprintf("test-acc-%s", acctest.RandString(8))
+	rName := fmt.Sprintf("test-acc-%s", cassette.RandString(8))
 	rType := "grafana"
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccCheckOnCallIntegrationResourceDestroy,
+	cassette.Test(t, resource.TestCase{
+		CheckDestroy: testAccCheckOnCallIntegrationResourceDestroy(client),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccOnCallIntegrationConfig(rName, rType, ``),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallIntegrationResourceExists("grafana_oncall_integration.test-acc-integration"),
+					testAccCheckOnCallIntegrationResourceExists(client, "grafana_oncall_integration.test-acc-integration"),
 					resource.TestCheckResourceAttr("grafana_oncall_integration.test-acc-integration", "name", rName),
 					resource.TestCheckResourceAttr("grafana_oncall_integration.test-acc-integration", "type", rType),
 					resource.TestCheckResourceAttrSet("grafana_oncall_integration.test-acc-integration", "link"),
@@ -35,7 +34,7 @@ func TestAccOnCallIntegration_basic(t *testing.T) {
 			{
 				Config: testAccOnCallIntegrationConfig(rName, rType, `templates {}`),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallIntegrationResourceExists("grafana_oncall_integration.test-acc-integration"),
+					testAccCheckOnCallIntegrationResourceExists(client, "grafana_oncall_integration.test-acc-integration"),
 					resource.TestCheckResourceAttr("grafana_oncall_integration.test-acc-integration", "name", rName),
 					resource.TestCheckResourceAttr("grafana_oncall_integration.test-acc-integration", "type", rType),
 					resource.TestCheckResourceAttrSet("grafana_oncall_integration.test-acc-integration", "link"),
@@ -47,7 +46,7 @@ func TestAccOnCallIntegration_basic(t *testing.T) {
 					grouping_key = "test"
 				}`),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallIntegrationResourceExists("grafana_oncall_integration.test-acc-integration"),
+					testAccCheckOnCallIntegrationResourceExists(client, "grafana_oncall_integration.test-acc-integration"),
 					resource.TestCheckResourceAttr("grafana_oncall_integration.test-acc-integration", "name", rName),
 					resource.TestCheckResourceAttr("grafana_oncall_integration.test-acc-integration", "type", rType),
 					resource.TestCheckResourceAttrSet("grafana_oncall_integration.test-acc-integration", "link"),
@@ -59,7 +58,7 @@ func TestAccOnCallIntegration_basic(t *testing.T) {
 			{
 				Config: testAccOnCallIntegrationConfig(rName, rType, ``),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallIntegrationResourceExists("grafana_oncall_integration.test-acc-integration"),
+					testAccCheckOnCallIntegrationResourceExists(client, "grafana_oncall_integration.test-acc-integration"),
 					resource.TestCheckResourceAttr("grafana_oncall_integration.test-acc-integration", "name", rName),
 					resource.TestCheckResourceAttr("grafana_oncall_integration.test-acc-integration", "type", rType),
 					resource.TestCheckResourceAttrSet("grafana_oncall_integration.test-acc-integration", "link"),
@@ -70,18 +69,19 @@ func TestAccOnCallIntegration_basic(t *testing.T) {
 	})
 }
 
-func testAccCheckOnCallIntegrationResourceDestroy(s *terraform.State) error {
-	client := testutils.Provider.Meta().(*common.Client).OnCallClient
-	for _, r := range s.RootModule().Resources {
-		if r.Type != "grafana_oncall_integration" {
-			continue
-		}
+func testAccCheckOnCallIntegrationResourceDestroy(client *common.Client) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "grafana_oncall_integration" {
+				continue
+			}
 
-		if _, _, err := client.Integrations.GetIntegration(r.Primary.ID, &onCallAPI.GetIntegrationOptions{}); err == nil {
-			return fmt.Errorf("integration still exists")
+			if _, _, err := client.OnCallClient.Integrations.GetIntegration(r.Primary.ID, &onCallAPI.GetIntegrationOptions{}); err == nil {
+				return fmt.Errorf("integration still exists")
+			}
 		}
+		return nil
 	}
-	return nil
 }
 
 func testAccOnCallIntegrationConfig(rName, rType, additionalConfigs string) string {
@@ -103,7 +103,7 @@ resource "grafana_oncall_integration" "test-acc-integration" {
 `, rName, rType, additionalConfigs)
 }
 
-func testAccCheckOnCallIntegrationResourceExists(name string) resource.TestCheckFunc {
+func testAccCheckOnCallIntegrationResourceExists(client *common.Client, name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
@@ -113,9 +113,7 @@ func testAccCheckOnCallIntegrationResourceExists(name string) resource.TestCheck
 			return fmt.Errorf("No Integration ID is set")
 		}
 
-		client := testutils.Provider.Meta().(*common.Client).OnCallClient
-
-		found, _, err := client.Integrations.GetIntegration(rs.Primary.ID, &onCallAPI.GetIntegrationOptions{})
+		found, _, err := client.OnCallClient.Integrations.GetIntegration(rs.Primary.ID, &onCallAPI.GetIntegrationOptions{})
 		if err != nil {
 			return err
 		}
@@ -2,8 +2,6 @@ package oncall
 
 import (
 	"context"
-	"log"
-	"net/http"
 
 	onCallAPI "github.com/grafana/amixr-api-go-client"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
@@ -190,14 +188,9 @@ func resourceOutgoingWebhookCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceOutgoingWebhookRead(ctx context.Context, d *schema.ResourceData, client *onCallAPI.Client) diag.Diagnostics {
-	outgoingWebhook, r, err := client.Webhooks.GetWebhook(d.Id(), &onCallAPI.GetWebhookOptions{})
-	if err != nil {
-		if r != nil && r.StatusCode == http.StatusNotFound {
-			log.Printf("[WARN] removing outgoingWebhook %s from state because it no longer exists", d.Get("name").(string))
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
+	outgoingWebhook, _, err := client.Webhooks.GetWebhook(d.Id(), &onCallAPI.GetWebhookOptions{})
+	if err, shouldReturn := common.CheckReadError("outgoing webhook", d, err); shouldReturn {
+		return err
 	}
 
 	d.Set("name", outgoingWebhook.Name)
@@ -7,42 +7,42 @@ import (
 	onCallAPI "github.com/grafana/amixr-api-go-client"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccOnCallOutgoingWebhook_basic(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	webhookName := fmt.Sprintf("name-%s", acctest.RandString(8))
+	webhookName := fmt.Sprintf("name-%s", cassette.RandString(8))
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccCheckOnCallOutgoingWebhookResourceDestroy,
+	cassette.Test(t, resource.TestCase{
+		CheckDestroy: testAccCheckOnCallOutgoingWebhookResourceDestroy(client),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccOnCallOutgoingWebhookConfig(webhookName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallOutgoingWebhookResourceExists("grafana_oncall_outgoing_webhook.test-acc-outgoing_webhook"),
+					testAccCheckOnCallOutgoingWebhookResourceExists(client, "grafana_oncall_outgoing_webhook.test-acc-outgoing_webhook"),
 				),
 			},
 		},
 	})
 }
 
-func testAccCheckOnCallOutgoingWebhookResourceDestroy(s *terraform.State) error {
-	client := testutils.Provider.Meta().(*common.Client).OnCallClient
-	for _, r := range s.RootModule().Resources {
-		if r.Type != "grafana_oncall_outgoing_webhook" {
-			continue
-		}
+func testAccCheckOnCallOutgoingWebhookResourceDestroy(client *common.Client) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "grafana_oncall_outgoing_webhook" {
+				continue
+			}
 
-		if _, _, err := client.Webhooks.GetWebhook(r.Primary.ID, &onCallAPI.GetWebhookOptions{}); err == nil {
-			return fmt.Errorf("OutgoingWebhook still exists")
+			if _, _, err := client.OnCallClient.Webhooks.GetWebhook(r.Primary.ID, &onCallAPI.GetWebhookOptions{}); err == nil {
+				return fmt.Errorf("OutgoingWebhook still exists")
+			}
 		}
+		return nil
 	}
-	return nil
 }
 
 func testAccOnCallOutgoingWebhookConfig(webhookName string) string {
@@ -65,7 +65,7 @@ resource "grafana_oncall_outgoing_webhook" "test-acc-outgoing_webhook" {
 `, webhookName)
 }
 
-func testAccCheckOnCallOutgoingWebhookResourceExists(name string) resource.TestCheckFunc {
+func testAccCheckOnCallOutgoingWebhookResourceExists(client *common.Client, name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
@@ -75,9 +75,7 @@ func testAccCheckOnCallOutgoingWebhookResourceExists(name string) resource.TestC
 			return fmt.Errorf("No OutgoingWebhook ID is set")
 		}
 
-		client := testutils.Provider.Meta().(*common.Client).OnCallClient
-
-		found, _, err := client.Webhooks.GetWebhook(rs.Primary.ID, &onCallAPI.GetWebhookOptions{})
+		found, _, err := client.OnCallClient.Webhooks.GetWebhook(rs.Primary.ID, &onCallAPI.GetWebhookOptions{})
 		if err != nil {
 			return err
 		}
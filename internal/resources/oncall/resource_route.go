@@ -3,8 +3,6 @@ package oncall
 import (
 	"context"
 	"fmt"
-	"log"
-	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -170,14 +168,9 @@ func resourceRouteCreate(ctx context.Context, d *schema.ResourceData, client *on
 }
 
 func resourceRouteRead(ctx context.Context, d *schema.ResourceData, client *onCallAPI.Client) diag.Diagnostics {
-	route, r, err := client.Routes.GetRoute(d.Id(), &onCallAPI.GetRouteOptions{})
-	if err != nil {
-		if r != nil && r.StatusCode == http.StatusNotFound {
-			log.Printf("[WARN] removing route %s from state because it no longer exists", d.Id())
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
+	route, _, err := client.Routes.GetRoute(d.Id(), &onCallAPI.GetRouteOptions{})
+	if err, shouldReturn := common.CheckReadError("route", d, err); shouldReturn {
+		return err
 	}
 
 	d.Set("integration_id", route.IntegrationId)
@@ -7,44 +7,44 @@ import (
 	onCallAPI "github.com/grafana/amixr-api-go-client"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccOnCallRoute_basic(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	riName := fmt.Sprintf("integration-%s", acctest.RandString(8))
-	rrRegex := fmt.Sprintf("regex-%s", acctest.RandString(8))
+	riName := fmt.Sprintf("integration-%s", cassette.RandString(8))
+	rrRegex := fmt.Sprintf("regex-%s", cassette.RandString(8))
 
 	// TODO: Make parallelizable
-	resource.Test(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccCheckOnCallRouteResourceDestroy,
+	cassette.Test(t, resource.TestCase{
+		CheckDestroy: testAccCheckOnCallRouteResourceDestroy(client),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccOnCallRouteConfig(riName, rrRegex),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallRouteResourceExists("grafana_oncall_route.test-acc-route"),
+					testAccCheckOnCallRouteResourceExists(client, "grafana_oncall_route.test-acc-route"),
 				),
 			},
 		},
 	})
 }
 
-func testAccCheckOnCallRouteResourceDestroy(s *terraform.State) error {
-	client := testutils.Provider.Meta().(*common.Client).OnCallClient
-	for _, r := range s.RootModule().Resources {
-		if r.Type != "grafana_oncall_route" {
-			continue
-		}
+func testAccCheckOnCallRouteResourceDestroy(client *common.Client) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "grafana_oncall_route" {
+				continue
+			}
 
-		if _, _, err := client.Routes.GetRoute(r.Primary.ID, &onCallAPI.GetRouteOptions{}); err == nil {
-			return fmt.Errorf("Route still exists")
+			if _, _, err := client.OnCallClient.Routes.GetRoute(r.Primary.ID, &onCallAPI.GetRouteOptions{}); err == nil {
+				return fmt.Errorf("Route still exists")
+			}
 		}
+		return nil
 	}
-	return nil
 }
 
 func testAccOnCallRouteConfig(riName string, rrRegex string) string {
@@ -81,7 +81,7 @@ resource "grafana_oncall_route" "test-acc-route" {
 `, riName, rrRegex)
 }
 
-func testAccCheckOnCallRouteResourceExists(name string) resource.TestCheckFunc {
+func testAccCheckOnCallRouteResourceExists(client *common.Client, name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
@@ -91,9 +91,7 @@ func testAccCheckOnCallRouteResourceExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("No Route ID is set")
 		}
 
-		client := testutils.Provider.Meta().(*common.Client).OnCallClient
-
-		found, _, err := client.Routes.GetRoute(rs.Primary.ID, &onCallAPI.GetRouteOptions{})
+		found, _, err := client.OnCallClient.Routes.GetRoute(rs.Primary.ID, &onCallAPI.GetRouteOptions{})
 		if err != nil {
 			return err
 		}
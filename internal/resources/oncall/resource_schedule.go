@@ -2,8 +2,6 @@ package oncall
 
 import (
 	"context"
-	"log"
-	"net/http"
 	"strings"
 
 	onCallAPI "github.com/grafana/amixr-api-go-client"
@@ -237,14 +235,9 @@ func resourceScheduleUpdate(ctx context.Context, d *schema.ResourceData, client
 
 func resourceScheduleRead(ctx context.Context, d *schema.ResourceData, client *onCallAPI.Client) diag.Diagnostics {
 	options := &onCallAPI.GetScheduleOptions{}
-	schedule, r, err := client.Schedules.GetSchedule(d.Id(), options)
-	if err != nil {
-		if r != nil && r.StatusCode == http.StatusNotFound {
-			log.Printf("[WARN] removing schedule %s from state because it no longer exists", d.Get("name").(string))
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
+	schedule, _, err := client.Schedules.GetSchedule(d.Id(), options)
+	if err, shouldReturn := common.CheckReadError("schedule", d, err); shouldReturn {
+		return err
 	}
 
 	d.Set("name", schedule.Name)
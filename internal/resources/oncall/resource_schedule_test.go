@@ -7,38 +7,37 @@ import (
 	onCallAPI "github.com/grafana/amixr-api-go-client"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccOnCallSchedule_basic(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	scheduleName := fmt.Sprintf("schedule-%s", acctest.RandString(8))
+	scheduleName := fmt.Sprintf("schedule-%s", cassette.RandString(8))
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccCheckOnCallScheduleResourceDestroy,
+	cassette.Test(t, resource.TestCase{
+		CheckDestroy: testAccCheckOnCallScheduleResourceDestroy(client),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccOnCallScheduleConfig(scheduleName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallScheduleResourceExists("grafana_oncall_schedule.test-acc-schedule"),
+					testAccCheckOnCallScheduleResourceExists(client, "grafana_oncall_schedule.test-acc-schedule"),
 					resource.TestCheckResourceAttr("grafana_oncall_schedule.test-acc-schedule", "enable_web_overrides", "false"),
 				),
 			},
 			{
 				Config: testAccOnCallScheduleConfigOverrides(scheduleName, true),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallScheduleResourceExists("grafana_oncall_schedule.test-acc-schedule"),
+					testAccCheckOnCallScheduleResourceExists(client, "grafana_oncall_schedule.test-acc-schedule"),
 					resource.TestCheckResourceAttr("grafana_oncall_schedule.test-acc-schedule", "enable_web_overrides", "true"),
 				),
 			},
 			{
 				Config: testAccOnCallScheduleConfigOverrides(scheduleName, false),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallScheduleResourceExists("grafana_oncall_schedule.test-acc-schedule"),
+					testAccCheckOnCallScheduleResourceExists(client, "grafana_oncall_schedule.test-acc-schedule"),
 					resource.TestCheckResourceAttr("grafana_oncall_schedule.test-acc-schedule", "enable_web_overrides", "false"),
 				),
 			},
@@ -46,18 +45,19 @@ func TestAccOnCallSchedule_basic(t *testing.T) {
 	})
 }
 
-func testAccCheckOnCallScheduleResourceDestroy(s *terraform.State) error {
-	client := testutils.Provider.Meta().(*common.Client).OnCallClient
-	for _, r := range s.RootModule().Resources {
-		if r.Type != "grafana_oncall_schedule" {
-			continue
-		}
+func testAccCheckOnCallScheduleResourceDestroy(client *common.Client) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "grafana_oncall_schedule" {
+				continue
+			}
 
-		if _, _, err := client.Schedules.GetSchedule(r.Primary.ID, &onCallAPI.GetScheduleOptions{}); err == nil {
-			return fmt.Errorf("Schedule still exists")
+			if _, _, err := client.OnCallClient.Schedules.GetSchedule(r.Primary.ID, &onCallAPI.GetScheduleOptions{}); err == nil {
+				return fmt.Errorf("Schedule still exists")
+			}
 		}
+		return nil
 	}
-	return nil
 }
 
 func testAccOnCallScheduleConfig(scheduleName string) string {
@@ -81,7 +81,7 @@ resource "grafana_oncall_schedule" "test-acc-schedule" {
 `, scheduleName, enableWebOverrides)
 }
 
-func testAccCheckOnCallScheduleResourceExists(name string) resource.TestCheckFunc {
+func testAccCheckOnCallScheduleResourceExists(client *common.Client, name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
@@ -91,9 +91,7 @@ func testAccCheckOnCallScheduleResourceExists(name string) resource.TestCheckFun
 			return fmt.Errorf("No Schedule ID is set")
 		}
 
-		client := testutils.Provider.Meta().(*common.Client).OnCallClient
-
-		found, _, err := client.Schedules.GetSchedule(rs.Primary.ID, &onCallAPI.GetScheduleOptions{})
+		found, _, err := client.OnCallClient.Schedules.GetSchedule(rs.Primary.ID, &onCallAPI.GetScheduleOptions{})
 		if err != nil {
 			return err
 		}
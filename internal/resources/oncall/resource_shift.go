@@ -3,8 +3,6 @@ package oncall
 import (
 	"context"
 	"fmt"
-	"log"
-	"net/http"
 	"strings"
 
 	onCallAPI "github.com/grafana/amixr-api-go-client"
@@ -433,14 +431,9 @@ func resourceOnCallShiftUpdate(ctx context.Context, d *schema.ResourceData, clie
 
 func resourceOnCallShiftRead(ctx context.Context, d *schema.ResourceData, client *onCallAPI.Client) diag.Diagnostics {
 	options := &onCallAPI.GetOnCallShiftOptions{}
-	onCallShift, r, err := client.OnCallShifts.GetOnCallShift(d.Id(), options)
-	if err != nil {
-		if r != nil && r.StatusCode == http.StatusNotFound {
-			log.Printf("[WARN] removing on-call shift %s from state because it no longer exists", d.Id())
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
+	onCallShift, _, err := client.OnCallShifts.GetOnCallShift(d.Id(), options)
+	if err, shouldReturn := common.CheckReadError("on-call shift", d, err); shouldReturn {
+		return err
 	}
 
 	d.Set("team_id", onCallShift.TeamId)
@@ -7,25 +7,24 @@ import (
 	onCallAPI "github.com/grafana/amixr-api-go-client"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccOnCallOnCallShift_basic(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	scheduleName := fmt.Sprintf("schedule-%s", acctest.RandString(8))
-	shiftName := fmt.Sprintf("shift-%s", acctest.RandString(8))
+	scheduleName := fmt.Sprintf("schedule-%s", cassette.RandString(8))
+	shiftName := fmt.Sprintf("shift-%s", cassette.RandString(8))
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccCheckOnCallOnCallShiftResourceDestroy,
+	cassette.Test(t, resource.TestCase{
+		CheckDestroy: testAccCheckOnCallOnCallShiftResourceDestroy(client),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccOnCallOnCallShiftConfigWeekly(scheduleName, shiftName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallOnCallShiftResourceExists("grafana_oncall_on_call_shift.test-acc-on_call_shift"),
+					testAccCheckOnCallOnCallShiftResourceExists(client, "grafana_oncall_on_call_shift.test-acc-on_call_shift"),
 					resource.TestCheckResourceAttr("grafana_oncall_on_call_shift.test-acc-on_call_shift", "name", shiftName),
 					resource.TestCheckResourceAttr("grafana_oncall_on_call_shift.test-acc-on_call_shift", "type", "recurrent_event"),
 					resource.TestCheckResourceAttr("grafana_oncall_on_call_shift.test-acc-on_call_shift", "start", "2020-09-04T16:00:00"),
@@ -42,14 +41,14 @@ func TestAccOnCallOnCallShift_basic(t *testing.T) {
 			{
 				Config: testAccOnCallOnCallShiftConfigHourly(scheduleName, shiftName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallOnCallShiftResourceExists("grafana_oncall_on_call_shift.test-acc-on_call_shift"),
+					testAccCheckOnCallOnCallShiftResourceExists(client, "grafana_oncall_on_call_shift.test-acc-on_call_shift"),
 					resource.TestCheckResourceAttr("grafana_oncall_on_call_shift.test-acc-on_call_shift", "frequency", "hourly"),
 				),
 			},
 			{
 				Config: testAccOnCallOnCallShiftConfigSingle(scheduleName, shiftName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckOnCallOnCallShiftResourceExists("grafana_oncall_on_call_shift.test-acc-on_call_shift"),
+					testAccCheckOnCallOnCallShiftResourceExists(client, "grafana_oncall_on_call_shift.test-acc-on_call_shift"),
 					resource.TestCheckResourceAttr("grafana_oncall_on_call_shift.test-acc-on_call_shift", "name", shiftName),
 				),
 			},
@@ -57,18 +56,19 @@ func TestAccOnCallOnCallShift_basic(t *testing.T) {
 	})
 }
 
-func testAccCheckOnCallOnCallShiftResourceDestroy(s *terraform.State) error {
-	client := testutils.Provider.Meta().(*common.Client).OnCallClient
-	for _, r := range s.RootModule().Resources {
-		if r.Type != "grafana_oncall_on_call_shift" {
-			continue
-		}
-
-		if _, _, err := client.OnCallShifts.GetOnCallShift(r.Primary.ID, &onCallAPI.GetOnCallShiftOptions{}); err == nil {
-			return fmt.Errorf("OnCallShift still exists")
+func testAccCheckOnCallOnCallShiftResourceDestroy(client *common.Client) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "grafana_oncall_on_call_shift" {
+				continue
+			}
+
+			if _, _, err := client.OnCallClient.OnCallShifts.GetOnCallShift(r.Primary.ID, &onCallAPI.GetOnCallShiftOptions{}); err == nil {
+				return fmt.Errorf("OnCallShift still exists")
+			}
 		}
+		return nil
 	}
-	return nil
 }
 
 func testAccOnCallOnCallShiftConfigWeekly(scheduleName, shiftName string) string {
@@ -130,7 +130,7 @@ resource "grafana_oncall_on_call_shift" "test-acc-on_call_shift" {
 `, scheduleName, shiftName)
 }
 
-func testAccCheckOnCallOnCallShiftResourceExists(name string) resource.TestCheckFunc {
+func testAccCheckOnCallOnCallShiftResourceExists(client *common.Client, name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
@@ -140,9 +140,7 @@ func testAccCheckOnCallOnCallShiftResourceExists(name string) resource.TestCheck
 			return fmt.Errorf("No OnCallShift ID is set")
 		}
 
-		client := testutils.Provider.Meta().(*common.Client).OnCallClient
-
-		found, _, err := client.OnCallShifts.GetOnCallShift(rs.Primary.ID, &onCallAPI.GetOnCallShiftOptions{})
+		found, _, err := client.OnCallClient.OnCallShifts.GetOnCallShift(rs.Primary.ID, &onCallAPI.GetOnCallShiftOptions{})
 		if err != nil {
 			return err
 		}
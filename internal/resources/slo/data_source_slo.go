@@ -46,7 +46,7 @@ func datasourceSloRead(ctx context.Context, d *schema.ResourceData, client *slo.
 	req := client.DefaultAPI.V1SloGet(ctx)
 	apiSlos, _, err := req.Execute()
 	if err != nil {
-		return apiError("Could not retrieve SLOs", err)
+		return common.APIErrorDiagnostics("Could not retrieve SLOs", err)
 	}
 
 	terraformSlos := []interface{}{}
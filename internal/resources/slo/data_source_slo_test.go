@@ -5,19 +5,18 @@ import (
 
 	slo "github.com/grafana/slo-openapi-client/go"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccDataSourceSlo(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	randomName := acctest.RandomWithPrefix("SLO Terraform Testing")
+	randomName := cassette.RandomWithPrefix("SLO Terraform Testing")
 
 	var slo slo.Slo
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccSloCheckDestroy(&slo),
+	cassette.Test(t, resource.TestCase{
+		CheckDestroy: testAccSloCheckDestroy(client, &slo),
 		Steps: []resource.TestStep{
 			{
 				// Creates a SLO Resource
@@ -25,7 +24,7 @@ func TestAccDataSourceSlo(t *testing.T) {
 					"Terraform Testing": randomName,
 				}),
 				Check: resource.ComposeTestCheckFunc(
-					testAccSloCheckExists("grafana_slo.test", &slo),
+					testAccSloCheckExists(client, "grafana_slo.test", &slo),
 					resource.TestCheckResourceAttrSet("grafana_slo.test", "id"),
 					resource.TestCheckResourceAttr("grafana_slo.test", "name", randomName),
 					resource.TestCheckResourceAttr("grafana_slo.test", "description", "Terraform Description"),
@@ -279,7 +279,7 @@ func resourceSloCreate(ctx context.Context, d *schema.ResourceData, client *slo.
 	response, _, err := req.Execute()
 
 	if err != nil {
-		return apiError("Unable to create SLO - API", err)
+		return common.APIErrorDiagnostics("Unable to create SLO - API", err)
 	}
 
 	d.SetId(response.Uuid)
@@ -295,9 +295,8 @@ func resourceSloRead(ctx context.Context, d *schema.ResourceData, client *slo.AP
 
 	req := client.DefaultAPI.V1SloIdGet(ctx, sloID)
 	slo, _, err := req.Execute()
-
-	if err != nil {
-		return apiError("Unable to read SLO - API", err)
+	if err, shouldReturn := common.CheckReadError("SLO", d, err); shouldReturn {
+		return err
 	}
 
 	setTerraformState(d, *slo)
@@ -322,7 +321,7 @@ func resourceSloUpdate(ctx context.Context, d *schema.ResourceData, client *slo.
 
 		req := client.DefaultAPI.V1SloIdPut(ctx, sloID).Slo(slo)
 		if _, err := req.Execute(); err != nil {
-			return apiError("Unable to Update SLO - API", err)
+			return common.APIErrorDiagnostics("Unable to Update SLO - API", err)
 		}
 	}
 
@@ -335,7 +334,7 @@ func resourceSloDelete(ctx context.Context, d *schema.ResourceData, client *slo.
 	req := client.DefaultAPI.V1SloIdDelete(ctx, sloID)
 	_, err := req.Execute()
 
-	return apiError("Unable to Delete SLO - API", err)
+	return common.APIErrorDiagnostics("Unable to Delete SLO - API", err)
 }
 
 // Fetches all the Properties defined on the Terraform SLO State Object and converts it
@@ -573,20 +572,3 @@ func setTerraformState(d *schema.ResourceData, slo slo.Slo) {
 	retAlerting := unpackAlerting(slo.Alerting)
 	d.Set("alerting", retAlerting)
 }
-
-func apiError(action string, err error) diag.Diagnostics {
-	if err == nil {
-		return nil
-	}
-	detail := err.Error()
-	if err, ok := err.(*slo.GenericOpenAPIError); ok {
-		detail += "\n" + string(err.Body())
-	}
-	return diag.Diagnostics{
-		diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  action,
-			Detail:   detail,
-		},
-	}
-}
@@ -9,21 +9,20 @@ import (
 	slo "github.com/grafana/slo-openapi-client/go"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceSlo(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	randomName := acctest.RandomWithPrefix("SLO Terraform Testing")
+	randomName := cassette.RandomWithPrefix("SLO Terraform Testing")
 
 	var slo slo.Slo
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		// Implicitly tests destroy
-		CheckDestroy: testAccSloCheckDestroy(&slo),
+		CheckDestroy: testAccSloCheckDestroy(client, &slo),
 		Steps: []resource.TestStep{
 			{
 				// Tests Create
@@ -31,7 +30,7 @@ func TestAccResourceSlo(t *testing.T) {
 					"Terraform Testing": randomName,
 				}),
 				Check: resource.ComposeTestCheckFunc(
-					testAccSloCheckExists("grafana_slo.test", &slo),
+					testAccSloCheckExists(client, "grafana_slo.test", &slo),
 					resource.TestCheckResourceAttrSet("grafana_slo.test", "id"),
 					resource.TestCheckResourceAttr("grafana_slo.test", "name", randomName),
 					resource.TestCheckResourceAttr("grafana_slo.test", "description", "Terraform Description"),
@@ -47,7 +46,7 @@ func TestAccResourceSlo(t *testing.T) {
 					"Terraform Testing": randomName,
 				}),
 				Check: resource.ComposeTestCheckFunc(
-					testAccSloCheckExists("grafana_slo.update", &slo),
+					testAccSloCheckExists(client, "grafana_slo.update", &slo),
 					resource.TestCheckResourceAttrSet("grafana_slo.update", "id"),
 					resource.TestCheckResourceAttr("grafana_slo.update", "name", "Updated - "+randomName),
 					resource.TestCheckResourceAttr("grafana_slo.update", "description", "Updated - Terraform Description"),
@@ -61,8 +60,8 @@ func TestAccResourceSlo(t *testing.T) {
 				// Tests that No Alerting Rules are Generated when No Alerting Field is defined on the Terraform State File
 				Config: noAlert(randomName + " - No Alerting Check"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccSloCheckExists("grafana_slo.no_alert", &slo),
-					testAlertingExists(false, "grafana_slo.no_alert", &slo),
+					testAccSloCheckExists(client, "grafana_slo.no_alert", &slo),
+					testAlertingExists(client, false, "grafana_slo.no_alert", &slo),
 					resource.TestCheckResourceAttrSet("grafana_slo.no_alert", "id"),
 					resource.TestCheckResourceAttr("grafana_slo.no_alert", "name", randomName+" - No Alerting Check"),
 				),
@@ -71,8 +70,8 @@ func TestAccResourceSlo(t *testing.T) {
 				// Tests that Alerting Rules are Generated when an Empty Alerting Field is defined on the Terraform State File
 				Config: emptyAlert(randomName + " - Empty Alerting Check"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccSloCheckExists("grafana_slo.empty_alert", &slo),
-					testAlertingExists(true, "grafana_slo.empty_alert", &slo),
+					testAccSloCheckExists(client, "grafana_slo.empty_alert", &slo),
+					testAlertingExists(client, true, "grafana_slo.empty_alert", &slo),
 					resource.TestCheckResourceAttrSet("grafana_slo.empty_alert", "id"),
 					resource.TestCheckResourceAttr("grafana_slo.empty_alert", "name", randomName+" - Empty Alerting Check"),
 				),
@@ -81,7 +80,7 @@ func TestAccResourceSlo(t *testing.T) {
 				// Tests Create
 				Config: testutils.TestAccExample(t, "resources/grafana_slo/resource_ratio.tf"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccSloCheckExists("grafana_slo.ratio", &slo),
+					testAccSloCheckExists(client, "grafana_slo.ratio", &slo),
 					resource.TestCheckResourceAttrSet("grafana_slo.ratio", "id"),
 					resource.TestCheckResourceAttr("grafana_slo.ratio", "name", "Terraform Testing - Ratio Query"),
 					resource.TestCheckResourceAttr("grafana_slo.ratio", "description", "Terraform Description - Ratio Query"),
@@ -96,7 +95,7 @@ func TestAccResourceSlo(t *testing.T) {
 	})
 }
 
-func testAccSloCheckExists(rn string, slo *slo.Slo) resource.TestCheckFunc {
+func testAccSloCheckExists(client *common.Client, rn string, slo *slo.Slo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
 		if !ok {
@@ -107,8 +106,7 @@ func testAccSloCheckExists(rn string, slo *slo.Slo) resource.TestCheckFunc {
 			return fmt.Errorf("resource id not set")
 		}
 
-		client := testutils.Provider.Meta().(*common.Client).SLOClient
-		req := client.DefaultAPI.V1SloIdGet(context.Background(), rs.Primary.ID)
+		req := client.SLOClient.DefaultAPI.V1SloIdGet(context.Background(), rs.Primary.ID)
 		gotSlo, _, err := req.Execute()
 
 		if err != nil {
@@ -121,11 +119,10 @@ func testAccSloCheckExists(rn string, slo *slo.Slo) resource.TestCheckFunc {
 	}
 }
 
-func testAlertingExists(expectation bool, rn string, slo *slo.Slo) resource.TestCheckFunc {
+func testAlertingExists(client *common.Client, expectation bool, rn string, slo *slo.Slo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs := s.RootModule().Resources[rn]
-		client := testutils.Provider.Meta().(*common.Client).SLOClient
-		req := client.DefaultAPI.V1SloIdGet(context.Background(), rs.Primary.ID)
+		req := client.SLOClient.DefaultAPI.V1SloIdGet(context.Background(), rs.Primary.ID)
 		gotSlo, _, err := req.Execute()
 
 		if err != nil {
@@ -145,10 +142,9 @@ func testAlertingExists(expectation bool, rn string, slo *slo.Slo) resource.Test
 	}
 }
 
-func testAccSloCheckDestroy(slo *slo.Slo) resource.TestCheckFunc {
+func testAccSloCheckDestroy(client *common.Client, slo *slo.Slo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testutils.Provider.Meta().(*common.Client).SLOClient
-		req := client.DefaultAPI.V1SloIdGet(context.Background(), slo.Uuid)
+		req := client.SLOClient.DefaultAPI.V1SloIdGet(context.Background(), slo.Uuid)
 		gotSlo, resp, _ := req.Execute()
 		if resp.StatusCode == 404 {
 			return nil
@@ -227,10 +223,9 @@ resource "grafana_slo" "no_alert" {
 }
 
 func TestAccResourceInvalidSlo(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config:      sloObjectivesInvalid,
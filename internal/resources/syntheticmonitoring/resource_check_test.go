@@ -6,22 +6,19 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceCheck_dns(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
 	// Inject random job names to avoid conflicts with other tests
-	jobName := acctest.RandomWithPrefix("dns")
-	jobNameUpdated := acctest.RandomWithPrefix("dns")
+	jobName := cassette.RandomWithPrefix("dns")
+	jobNameUpdated := cassette.RandomWithPrefix("dns")
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_check/dns_basic.tf", map[string]string{
@@ -74,16 +71,15 @@ func TestAccResourceCheck_dns(t *testing.T) {
 }
 
 func TestAccResourceCheck_http(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
 	// Inject random job names to avoid conflicts with other tests
-	jobName := acctest.RandomWithPrefix("http")
+	jobName := cassette.RandomWithPrefix("http")
 	nameReplaceMap := map[string]string{
 		`"HTTP Defaults"`: strconv.Quote(jobName),
 	}
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_check/http_basic.tf", nameReplaceMap),
@@ -138,14 +134,13 @@ func TestAccResourceCheck_http(t *testing.T) {
 }
 
 func TestAccResourceCheck_ping(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
 	// Inject random job names to avoid conflicts with other tests
-	jobName := acctest.RandomWithPrefix("ping")
-	jobNameUpdated := acctest.RandomWithPrefix("ping")
+	jobName := cassette.RandomWithPrefix("ping")
+	jobNameUpdated := cassette.RandomWithPrefix("ping")
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_check/ping_basic.tf", map[string]string{
@@ -183,16 +178,15 @@ func TestAccResourceCheck_ping(t *testing.T) {
 }
 
 func TestAccResourceCheck_tcp(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
 	// Inject random job names to avoid conflicts with other tests
-	jobName := acctest.RandomWithPrefix("tcp")
+	jobName := cassette.RandomWithPrefix("tcp")
 	nameReplaceMap := map[string]string{
 		`"TCP Defaults"`: strconv.Quote(jobName),
 	}
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_check/tcp_basic.tf", nameReplaceMap),
@@ -234,14 +228,13 @@ func TestAccResourceCheck_tcp(t *testing.T) {
 }
 
 func TestAccResourceCheck_traceroute(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
 	// Inject random job names to avoid conflicts with other tests
-	jobName := acctest.RandomWithPrefix("traceroute")
-	jobNameUpdated := acctest.RandomWithPrefix("traceroute")
+	jobName := cassette.RandomWithPrefix("traceroute")
+	jobNameUpdated := cassette.RandomWithPrefix("traceroute")
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_check/traceroute_basic.tf", map[string]string{
@@ -281,14 +274,13 @@ func TestAccResourceCheck_traceroute(t *testing.T) {
 }
 
 func TestAccResourceCheck_multihttp(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
 	// Inject random job names to avoid conflicts with other tests
-	jobName := acctest.RandomWithPrefix("multihttp")
-	jobNameUpdated := acctest.RandomWithPrefix("multihttp")
+	jobName := cassette.RandomWithPrefix("multihttp")
+	jobNameUpdated := cassette.RandomWithPrefix("multihttp")
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_check/multihttp_basic.tf", map[string]string{
@@ -367,23 +359,23 @@ func TestAccResourceCheck_multihttp(t *testing.T) {
 
 // Test that a check is recreated if deleted outside the Terraform process
 func TestAccResourceCheck_recreate(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
 	// Inject random job names to avoid conflicts with other tests
-	jobName := acctest.RandomWithPrefix("http")
+	jobName := cassette.RandomWithPrefix("http")
 	nameReplaceMap := map[string]string{
 		`"HTTP Defaults"`: strconv.Quote(jobName),
 	}
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_check/http_basic.tf", nameReplaceMap),
 				Check: func(s *terraform.State) error {
 					rs := s.RootModule().Resources["grafana_synthetic_monitoring_check.http"]
 					id, _ := strconv.ParseInt(rs.Primary.ID, 10, 64)
-					return testutils.Provider.Meta().(*common.Client).SMAPI.DeleteCheck(context.Background(), id)
+					return client.SMAPI.DeleteCheck(context.Background(), id)
 				},
 				ExpectNonEmptyPlan: true,
 			},
@@ -406,10 +398,9 @@ func TestAccResourceCheck_recreate(t *testing.T) {
 }
 
 func TestAccResourceCheck_noSettings(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccResourceCheck_noSettings,
@@ -421,10 +412,9 @@ func TestAccResourceCheck_noSettings(t *testing.T) {
 }
 
 func TestAccResourceCheck_multiple(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccResourceCheck_multiple,
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 
@@ -151,13 +150,8 @@ func resourceProbeRead(ctx context.Context, d *schema.ResourceData, c *smapi.Cli
 		return diag.FromErr(err)
 	}
 	prb, err := c.GetProbe(ctx, id.(int64))
-	if err != nil {
-		if strings.Contains(err.Error(), "404 Not Found") {
-			log.Printf("[WARN] removing probe %s from state because it no longer exists", d.Id())
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
+	if err, shouldReturn := common.CheckReadError("probe", d, err); shouldReturn {
+		return err
 	}
 
 	d.Set("tenant_id", prb.TenantId)
@@ -7,20 +7,17 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceProbe(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
-	randomName := acctest.RandomWithPrefix("My Probe")
+	randomName := cassette.RandomWithPrefix("My Probe")
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_probe/resource.tf", map[string]string{
@@ -58,22 +55,22 @@ func TestAccResourceProbe(t *testing.T) {
 
 // Test that a probe is recreated if deleted outside the Terraform process
 func TestAccResourceProbe_recreate(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
+	client := cassette.Client()
 
-	randomName := acctest.RandomWithPrefix("My Probe")
+	randomName := cassette.RandomWithPrefix("My Probe")
 	config := testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_probe/resource.tf", map[string]string{
 		"Mount Everest": randomName,
 	})
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: config,
 				Check: func(s *terraform.State) error {
 					rs := s.RootModule().Resources["grafana_synthetic_monitoring_probe.main"]
 					id, _ := strconv.ParseInt(rs.Primary.ID, 10, 64)
-					return testutils.Provider.Meta().(*common.Client).SMAPI.DeleteProbe(context.Background(), id)
+					return client.SMAPI.DeleteProbe(context.Background(), id)
 				},
 				ExpectNonEmptyPlan: true,
 			},
@@ -96,12 +93,11 @@ func TestAccResourceProbe_recreate(t *testing.T) {
 
 // Test that a probe that is used in a check can be deleted or recreated
 func TestAccResourceProbe_recreateProbeUsedInCheck(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
-	randomName := acctest.RandomWithPrefix("tf")
+	randomName := cassette.RandomWithPrefix("tf")
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testSyntheticMonitoringProbeAndCheck(randomName, "test1"),
@@ -153,12 +149,11 @@ func TestAccResourceProbe_recreateProbeUsedInCheck(t *testing.T) {
 }
 
 func TestAccResourceProbe_Import(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
-	randomName := acctest.RandomWithPrefix("My Probe")
+	randomName := cassette.RandomWithPrefix("My Probe")
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+	cassette.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_probe/resource.tf", map[string]string{
@@ -219,7 +214,7 @@ func TestAccResourceProbe_Import(t *testing.T) {
 }
 
 func TestAccResourceProbe_InvalidLabels(t *testing.T) {
-	testutils.CheckCloudInstanceTestsEnabled(t)
+	cassette := testutils.NewCassette(t, testutils.CheckCloudInstanceTestsEnabled)
 
 	var steps []resource.TestStep
 	for _, tc := range []struct {
@@ -245,9 +240,8 @@ func TestAccResourceProbe_InvalidLabels(t *testing.T) {
 		})
 	}
 
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
-		Steps:                    steps,
+	cassette.Test(t, resource.TestCase{
+		Steps: steps,
 	})
 }
 
@@ -0,0 +1,512 @@
+package testutils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v2/pkg/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	// cassetteRecordEnvVar enables the recording of cassettes. The test must otherwise be able to run against the real services.
+	cassetteRecordEnvVar = "TF_ACC_RECORD"
+	cassetteRedacted     = "REDACTED"
+)
+
+var (
+	// cassetteEnvVars are the provider settings stored in cassettes, so that the replayed test configures the same clients
+	cassetteEnvVars = []string{
+		"GRAFANA_URL",
+		"GRAFANA_CLOUD_API_URL",
+		"GRAFANA_CLOUD_ORG",
+		"GRAFANA_CLOUD_PROVIDER_TEST_STACK",
+		"GRAFANA_SM_URL",
+		"GRAFANA_ONCALL_URL",
+		"GRAFANA_ML_URL",
+		"GRAFANA_SLO_URL",
+	}
+	// cassetteSecretEnvVars are the credentials scrubbed from cassettes. They are set to a placeholder when replaying.
+	cassetteSecretEnvVars = []string{
+		"GRAFANA_AUTH",
+		"GRAFANA_CLOUD_ACCESS_POLICY_TOKEN",
+		"GRAFANA_SM_ACCESS_TOKEN",
+		"GRAFANA_ONCALL_ACCESS_TOKEN",
+	}
+	// cassetteSecretFields are the JSON fields of response bodies whose values are scrubbed from cassettes
+	cassetteSecretFields = []string{"token", "key", "accessToken", "apiToken", "password", "secret", "clientSecret"}
+)
+
+// Cassette records the HTTP calls of an acceptance test to a file, so that the test can later be replayed without access to the real services.
+// It is meant for tests of Grafana Cloud services (Cloud API, Synthetic Monitoring, OnCall, SLO and ML) which require credentials.
+//
+// Cassettes are stored in testdata/cassettes/<test name>.json, relative to the package of the test:
+//   - With TF_ACC_RECORD=true, the test runs against the real services and the cassette is (re)written if the test passes.
+//   - Otherwise, if the cassette exists, the test is replayed from it, as a unit test.
+//   - Otherwise, the test runs as a regular acceptance test, with the given check.
+//
+// Credentials are never written to cassettes: request headers aren't recorded, and the values of the credential environment variables
+// and of secret-looking JSON fields (tokens, keys, passwords) are replaced by "REDACTED".
+type Cassette struct {
+	t         *testing.T
+	path      string
+	recording bool
+	replaying bool
+
+	mu      sync.Mutex
+	data    cassetteData
+	used    []bool
+	secrets []string
+}
+
+type cassetteData struct {
+	Env          map[string]string      `json:"env"`
+	Values       []string               `json:"values,omitempty"`
+	Interactions []*cassetteInteraction `json:"interactions"`
+}
+
+type cassetteInteraction struct {
+	Method       string `json:"method"`
+	URL          string `json:"url"`
+	RequestBody  string `json:"request_body,omitempty"`
+	StatusCode   int    `json:"status_code"`
+	ContentType  string `json:"content_type,omitempty"`
+	ResponseBody string `json:"response_body,omitempty"`
+}
+
+// NewCassette returns the cassette of the test. The check (ex: CheckCloudAPITestsEnabled) is only run when the test isn't replayed.
+// The test must then be run with Cassette.Test, and use the random functions of the cassette (ex: Cassette.RandString), so that the replayed calls match the recorded ones.
+// Cassettes can't be used in parallel tests, because environment variables are set while replaying.
+func NewCassette(t *testing.T, check func(t *testing.T)) *Cassette {
+	t.Helper()
+
+	c := &Cassette{
+		t:    t,
+		path: filepath.Join("testdata", "cassettes", strings.ReplaceAll(t.Name(), "/", "_")+".json"),
+		data: cassetteData{Env: map[string]string{}},
+	}
+
+	switch {
+	case AccTestsEnabled(cassetteRecordEnvVar):
+		check(t)
+		c.startRecording()
+	case fileExists(c.path):
+		c.startReplaying()
+	default:
+		check(t)
+	}
+
+	return c
+}
+
+func (c *Cassette) startRecording() {
+	c.recording = true
+	c.discoverOnCallURL()
+	for _, envVar := range cassetteEnvVars {
+		if v := os.Getenv(envVar); v != "" {
+			c.data.Env[envVar] = v
+		}
+	}
+	for _, envVar := range cassetteSecretEnvVars {
+		v := os.Getenv(envVar)
+		if v == "" {
+			continue
+		}
+		c.data.Env[envVar] = cassetteRedacted
+		c.addSecret(v)
+		// Basic auth is used as-is in URLs and headers, but the password may also appear alone
+		if _, password, ok := strings.Cut(v, ":"); ok {
+			c.addSecret(password)
+		}
+	}
+	c.proxyOnCall()
+
+	c.t.Cleanup(func() {
+		if c.t.Failed() || c.t.Skipped() {
+			return
+		}
+		if err := c.save(); err != nil {
+			c.t.Errorf("failed to save cassette %s: %v", c.path, err)
+		}
+	})
+}
+
+func (c *Cassette) startReplaying() {
+	c.replaying = true
+	content, err := os.ReadFile(c.path)
+	if err != nil {
+		c.t.Fatalf("failed to read cassette %s: %v", c.path, err)
+	}
+	if err := json.Unmarshal(content, &c.data); err != nil {
+		c.t.Fatalf("failed to parse cassette %s: %v", c.path, err)
+	}
+	c.used = make([]bool, len(c.data.Interactions))
+	for k, v := range c.data.Env {
+		c.t.Setenv(k, v)
+	}
+	c.proxyOnCall()
+
+	c.t.Cleanup(func() {
+		if c.t.Failed() {
+			return
+		}
+		for i, used := range c.used {
+			if !used {
+				interaction := c.data.Interactions[i]
+				c.t.Logf("recorded call to %s %s was not replayed", interaction.Method, interaction.URL)
+			}
+		}
+	})
+}
+
+// discoverOnCallURL sets GRAFANA_ONCALL_URL to the OnCall API URL that the provider would discover, if it isn't set.
+// The OnCall calls can then be proxied, and the URL is stored in the cassette so that the replayed test doesn't discover it again.
+func (c *Cassette) discoverOnCallURL() {
+	if os.Getenv("GRAFANA_ONCALL_URL") != "" || os.Getenv("GRAFANA_ONCALL_ACCESS_TOKEN") == "" {
+		return
+	}
+	var cfg provider.ProviderConfig
+	if err := cfg.SetDefaults(); err != nil {
+		c.t.Fatal(err)
+	}
+	// The OnCall client isn't needed, only the clients used by the discovery
+	cfg.OncallAccessToken = types.StringNull()
+	client, err := provider.CreateClients(context.Background(), cfg)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	c.t.Setenv("GRAFANA_ONCALL_URL", provider.ResolveOncallURL(context.Background(), client, cfg))
+}
+
+// proxyOnCall sends the calls of the OnCall client through a local server, because the OnCall client doesn't accept a custom transport
+func (c *Cassette) proxyOnCall() {
+	target := os.Getenv("GRAFANA_ONCALL_URL")
+	if target == "" {
+		return
+	}
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		c.t.Fatalf("failed to parse GRAFANA_ONCALL_URL: %v", err)
+	}
+	transport := c.wrapTransport(http.DefaultTransport)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := r.Clone(context.Background())
+		req.RequestURI = ""
+		req.URL.Scheme = targetURL.Scheme
+		req.URL.Host = targetURL.Host
+		req.URL.Path = strings.TrimSuffix(strings.TrimSuffix(targetURL.Path, "/"), "/api/v1") + r.URL.Path
+		req.Host = targetURL.Host
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+		for k, v := range resp.Header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(resp.StatusCode)
+		_, _ = io.Copy(w, resp.Body)
+	}))
+	c.t.Cleanup(server.Close)
+	c.t.Setenv("GRAFANA_ONCALL_URL", server.URL)
+}
+
+// Test runs the test case with providers that record or replay the API calls.
+// Replayed tests run as unit tests, so they don't require TF_ACC to be set.
+func (c *Cassette) Test(t *testing.T, testCase resource.TestCase) {
+	t.Helper()
+
+	testCase.ProtoV5ProviderFactories = c.ProviderFactories()
+	if c.replaying {
+		resource.UnitTest(t, testCase)
+		return
+	}
+	resource.Test(t, testCase)
+}
+
+// ProviderFactories returns provider factories like ProtoV5ProviderFactories, whose API calls go through the cassette
+func (c *Cassette) ProviderFactories() map[string]func() (tfprotov5.ProviderServer, error) {
+	if !c.recording && !c.replaying {
+		return ProtoV5ProviderFactories
+	}
+	return map[string]func() (tfprotov5.ProviderServer, error){
+		"grafana": func() (tfprotov5.ProviderServer, error) {
+			ctx := context.Background()
+			server, err := provider.MakeProviderServerWithTransport(ctx, "testacc", c.wrapTransport)
+			if err != nil {
+				return nil, err
+			}
+			return configureProviderServer(ctx, server)
+		},
+	}
+}
+
+// Client returns API clients whose calls go through the cassette, meant to check the state of the resources in tests (ex: in CheckDestroy functions)
+func (c *Cassette) Client() *common.Client {
+	c.t.Helper()
+
+	// All attributes are null, the configuration comes from environment variables
+	var cfg provider.ProviderConfig
+	if err := cfg.SetDefaults(); err != nil {
+		c.t.Fatal(err)
+	}
+	if c.recording || c.replaying {
+		cfg.WrapTransport = c.wrapTransport
+	}
+	client, err := provider.CreateClients(context.Background(), cfg)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return client
+}
+
+// RandString returns a random string, like acctest.RandString. The value is recorded in the cassette, so that it's the same when replaying.
+func (c *Cassette) RandString(length int) string {
+	return c.randValue(func() string { return acctest.RandString(length) })
+}
+
+// RandStringFromCharSet returns a random string, like acctest.RandStringFromCharSet. The value is recorded in the cassette, like RandString.
+func (c *Cassette) RandStringFromCharSet(length int, charSet string) string {
+	return c.randValue(func() string { return acctest.RandStringFromCharSet(length, charSet) })
+}
+
+// RandomWithPrefix returns a random name, like acctest.RandomWithPrefix. The value is recorded in the cassette, like RandString.
+func (c *Cassette) RandomWithPrefix(prefix string) string {
+	return c.randValue(func() string { return acctest.RandomWithPrefix(prefix) })
+}
+
+// Now returns the current time, like time.Now. The value is recorded in the cassette, like RandString, so that the replayed calls use the recorded time.
+func (c *Cassette) Now() time.Time {
+	v := c.randValue(func() string { return time.Now().UTC().Format(time.RFC3339Nano) })
+	now, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		c.t.Fatalf("cassette %s has an invalid recorded time %q: %v", c.path, v, err)
+	}
+	return now
+}
+
+func (c *Cassette) randValue(generate func() string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.replaying {
+		v := generate()
+		c.data.Values = append(c.data.Values, v)
+		return v
+	}
+	if len(c.data.Values) == 0 {
+		c.t.Fatalf("cassette %s has no more recorded random values, the test must be recorded again", c.path)
+	}
+	v := c.data.Values[0]
+	c.data.Values = c.data.Values[1:]
+	return v
+}
+
+func (c *Cassette) wrapTransport(transport http.RoundTripper) http.RoundTripper {
+	return cassetteTransport{cassette: c, transport: transport}
+}
+
+type cassetteTransport struct {
+	cassette  *Cassette
+	transport http.RoundTripper
+}
+
+func (t cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		if requestBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	if t.cassette.replaying {
+		return t.cassette.replay(req, requestBody)
+	}
+	return t.cassette.record(req, requestBody, t.transport)
+}
+
+func (c *Cassette) record(req *http.Request, requestBody []byte, transport http.RoundTripper) (*http.Response, error) {
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Secrets are scrubbed when the cassette is saved, once they're all known
+	c.addResponseSecrets(responseBody)
+	c.data.Interactions = append(c.data.Interactions, &cassetteInteraction{
+		Method:       req.Method,
+		URL:          req.URL.String(),
+		RequestBody:  string(requestBody),
+		StatusCode:   resp.StatusCode,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ResponseBody: string(responseBody),
+	})
+	return resp, nil
+}
+
+// replay returns the response of the first recorded call with the same method, URL and body that wasn't replayed yet.
+// If the body doesn't match any call (ex: it contains a timestamp), the first call with the same method and URL is used.
+func (c *Cassette) replay(req *http.Request, requestBody []byte) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	reqURL := req.URL.String()
+	match := -1
+	for i, interaction := range c.data.Interactions {
+		if c.used[i] || interaction.Method != req.Method || interaction.URL != reqURL {
+			continue
+		}
+		if bodiesEqual(interaction.RequestBody, string(requestBody)) {
+			match = i
+			break
+		}
+		if match == -1 {
+			match = i
+		}
+	}
+	if match == -1 {
+		return nil, fmt.Errorf("cassette %s has no recorded call to %s %s, the test must be recorded again", c.path, req.Method, reqURL)
+	}
+
+	c.used[match] = true
+	interaction := c.data.Interactions[match]
+	header := http.Header{}
+	if interaction.ContentType != "" {
+		header.Set("Content-Type", interaction.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+		ContentLength: int64(len(interaction.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+func (c *Cassette) addSecret(secret string) {
+	// Short values can't be told apart from the rest of the content (ex: the "admin" password of a local instance)
+	if len(secret) >= 8 && secret != cassetteRedacted {
+		c.secrets = append(c.secrets, secret, url.QueryEscape(secret))
+	}
+}
+
+// addResponseSecrets finds the values of secret fields in a JSON response. They're scrubbed from all the calls, as they may appear elsewhere (ex: clients may send them back).
+func (c *Cassette) addResponseSecrets(body []byte) {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return
+	}
+	var walk func(interface{})
+	walk = func(value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for k, field := range v {
+				if s, ok := field.(string); ok && isSecretField(k) {
+					c.addSecret(s)
+				}
+				walk(field)
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(value)
+}
+
+func (c *Cassette) scrub(s string) string {
+	for _, secret := range c.secrets {
+		s = strings.ReplaceAll(s, secret, cassetteRedacted)
+	}
+	return s
+}
+
+// save writes the cassette. The secrets are scrubbed from all the calls, including the ones recorded before a secret was found.
+func (c *Cassette) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := cassetteData{Env: map[string]string{}}
+	for k, v := range c.data.Env {
+		data.Env[k] = c.scrub(v)
+	}
+	for _, v := range c.data.Values {
+		data.Values = append(data.Values, c.scrub(v))
+	}
+	for _, interaction := range c.data.Interactions {
+		scrubbed := *interaction
+		scrubbed.URL = c.scrub(interaction.URL)
+		scrubbed.RequestBody = c.scrub(interaction.RequestBody)
+		scrubbed.ResponseBody = c.scrub(interaction.ResponseBody)
+		data.Interactions = append(data.Interactions, &scrubbed)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o750); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(content, '\n'), 0o600)
+}
+
+func isSecretField(name string) bool {
+	for _, field := range cassetteSecretFields {
+		if strings.EqualFold(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func bodiesEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var aValue, bValue interface{}
+	if json.Unmarshal([]byte(a), &aValue) != nil || json.Unmarshal([]byte(b), &bValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, os.ErrNotExist)
+}
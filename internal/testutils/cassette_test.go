@@ -0,0 +1,167 @@
+package testutils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	onCallAPI "github.com/grafana/amixr-api-go-client"
+	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	// Cassettes are written relative to the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	const (
+		auth           = "glsa_configured_secret"
+		oncallToken    = "oncall_configured_secret"
+		generatedToken = "glsa_generated_secret"
+	)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/plugins/grafana-oncall-app/settings":
+			w.Write([]byte(`{"enabled": true, "jsonData": {"onCallApiUrl": "` + server.URL + `/oncall"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/oncall/api/v1/teams":
+			w.Write([]byte(`{"count": 1, "results": [{"id": "T1", "name": "team"}]}`))
+		// The secret is only known to be one once the token is created
+		case r.Method == http.MethodGet && r.URL.Path == "/api/serviceaccounts/1":
+			w.Write([]byte(`{"id": 1, "name": "test", "description": "` + generatedToken + `"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/serviceaccounts/1/tokens":
+			w.Write([]byte(`{"id": 2, "name": "token", "key": "` + generatedToken + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+		}
+	}))
+
+	var recordedName string
+	run := func(t *testing.T) {
+		cassette := NewCassette(t, func(t *testing.T) {})
+		clients := cassette.Client()
+		client := clients.GrafanaAPI
+
+		sa, err := client.ServiceAccounts.RetrieveServiceAccount(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sa.Payload.Name != "test" {
+			t.Errorf("expected the service account to be named test, got %q", sa.Payload.Name)
+		}
+		name := cassette.RandString(8) + cassette.RandStringFromCharSet(4, "ab") + cassette.RandomWithPrefix("-sa") + cassette.Now().Format(time.RFC3339Nano)
+		token, err := client.ServiceAccounts.CreateToken(
+			service_accounts.NewCreateTokenParams().WithServiceAccountID(1).WithBody(&models.AddServiceAccountTokenCommand{Name: name}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.Payload.Key == "" {
+			t.Error("expected the token key to be returned")
+		}
+		if _, err := client.ServiceAccounts.RetrieveServiceAccount(2); err == nil {
+			t.Error("expected an error for a service account that doesn't exist")
+		}
+		// The OnCall URL is discovered from the plugin settings when recording
+		teams, _, err := clients.OnCallClient.Teams.ListTeams(&onCallAPI.ListTeamOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(teams.Teams) != 1 || teams.Teams[0].Name != "team" {
+			t.Errorf("expected the OnCall team to be listed, got %+v", teams.Teams)
+		}
+		if recordedName == "" {
+			recordedName = name
+		} else if name != recordedName {
+			t.Errorf("expected the replayed random value to be %q, got %q", recordedName, name)
+		}
+	}
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(cassetteRecordEnvVar, "true")
+		t.Setenv("GRAFANA_URL", server.URL)
+		t.Setenv("GRAFANA_AUTH", auth)
+		t.Setenv("GRAFANA_ONCALL_ACCESS_TOKEN", oncallToken)
+		run(t)
+	})
+	server.Close()
+
+	recorded := filepath.Join("testdata", "cassettes", "TestCassette_RecordAndReplay_record.json")
+	content, err := os.ReadFile(recorded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), server.URL+"/oncall/api/v1/teams") {
+		t.Errorf("expected the OnCall calls to be recorded:\n%s", content)
+	}
+	for _, secret := range []string{auth, oncallToken, generatedToken} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("expected %q to be scrubbed from the cassette:\n%s", secret, content)
+		}
+	}
+
+	// Replay the recorded calls, the server is stopped
+	if err := os.Rename(recorded, filepath.Join("testdata", "cassettes", "TestCassette_RecordAndReplay_replay.json")); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("replay", func(t *testing.T) {
+		t.Setenv("GRAFANA_URL", "")
+		run(t)
+		if got := os.Getenv("GRAFANA_AUTH"); got != cassetteRedacted {
+			t.Errorf("expected the credentials to be replaced by a placeholder, got %q", got)
+		}
+	})
+}
+
+func TestCassette_ReplayMismatch(t *testing.T) {
+	c := &Cassette{
+		t:         t,
+		path:      "test.json",
+		replaying: true,
+		data: cassetteData{Interactions: []*cassetteInteraction{
+			{Method: http.MethodPost, URL: "https://example.com/a", RequestBody: `{"a": 1, "b": 2}`, StatusCode: http.StatusOK},
+			{Method: http.MethodPost, URL: "https://example.com/a", RequestBody: `{"a": 2}`, StatusCode: http.StatusCreated},
+		}},
+		used: []bool{false, false},
+	}
+	transport := c.wrapTransport(nil)
+
+	// Bodies are compared as JSON
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com/a", strings.NewReader(`{"a":2}`))
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("expected the call with the same body to be replayed, got status %d", resp.StatusCode)
+	}
+
+	// Falls back to the method and URL
+	req, _ = http.NewRequest(http.MethodPost, "https://example.com/a", strings.NewReader(`{"a":3}`))
+	resp, err = transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the remaining call to be replayed, got status %d", resp.StatusCode)
+	}
+
+	// Calls are only replayed once
+	req, _ = http.NewRequest(http.MethodPost, "https://example.com/a", nil)
+	if _, err := transport.RoundTrip(req); err == nil {
+		t.Error("expected an error when all calls were replayed")
+	}
+}
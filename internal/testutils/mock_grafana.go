@@ -0,0 +1,338 @@
+package testutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+const (
+	// MockGrafanaVersion is the Grafana version reported by the mock Grafana server
+	MockGrafanaVersion = "10.3.1"
+	// MockGrafanaAuth is the basic auth of the admin user of the mock Grafana server
+	MockGrafanaAuth = "admin:admin"
+)
+
+// MockGrafana is an in-memory fake of the Grafana HTTP API, meant for fast resource lifecycle tests that don't need a running Grafana instance.
+// It supports the main API endpoints of dashboards, folders, data sources, library panels, snapshots, alerting provisioning, teams, users and service accounts.
+// Only the main org (ID 1) exists.
+//
+// Use NewMockGrafana to start a server and point the provider at it:
+//
+//	NewMockGrafana(t)
+//	resource.UnitTest(t, resource.TestCase{
+//		ProtoV5ProviderFactories: ProtoV5ProviderFactories,
+//		...
+//	})
+type MockGrafana struct {
+	Server *httptest.Server
+	// FeatureToggles are the feature toggles reported as enabled. The nestedFolders toggle is enabled by default.
+	FeatureToggles map[string]bool
+	// StaleLibraryPanelConnections are the UIDs of dashboards that don't exist, by library panel UID, that are still listed as connections of the library panels
+	// (ex: dashboards deleted after the connections were listed). They don't prevent the deletion of the library panels.
+	StaleLibraryPanelConnections map[string][]string
+
+	mu     sync.Mutex
+	lastID int64
+	users  map[int64]*mockUser
+	orgs   map[int64]*mockOrg
+}
+
+type mockUser struct {
+	profile  models.UserProfileDTO
+	password string
+}
+
+// mockOrg contains the org-scoped resources
+type mockOrg struct {
+	id   int64
+	name string
+
+	folders         map[string]*models.Folder
+	dashboards      map[string]*mockDashboard
+	dataSources     map[string]*mockDataSource
+	libraryPanels   map[string]*models.LibraryElementDTO
+	snapshots       map[string]*mockSnapshot
+	teams           map[int64]*mockTeam
+	serviceAccounts map[int64]*mockServiceAccount
+
+	policy        *models.Route
+	contactPoints map[string]*models.EmbeddedContactPoint
+	muteTimings   map[string]*models.MuteTimeInterval
+	templates     map[string]*models.NotificationTemplate
+	alertRules    map[string]*models.ProvisionedAlertRule
+	ruleGroups    map[string]int64 // Intervals of the rule groups, by folder UID and title
+}
+
+// NewMockGrafana starts a mock Grafana server that is stopped at the end of the test.
+// It sets the GRAFANA_URL, GRAFANA_AUTH and GRAFANA_VERSION environment variables, so the provider uses it.
+func NewMockGrafana(t *testing.T) *MockGrafana {
+	t.Helper()
+
+	m := &MockGrafana{
+		FeatureToggles: map[string]bool{"nestedFolders": true},
+		users:          map[int64]*mockUser{},
+		orgs:           map[int64]*mockOrg{},
+	}
+	admin := m.nextID()
+	m.users[admin] = &mockUser{
+		profile: models.UserProfileDTO{
+			ID:             admin,
+			UID:            m.uid(admin),
+			Login:          "admin",
+			Email:          "admin@localhost",
+			IsGrafanaAdmin: true,
+			OrgID:          1,
+		},
+		password: "admin",
+	}
+	m.orgs[1] = m.newOrg(1, "Main Org.")
+
+	m.Server = httptest.NewServer(m.handler())
+	t.Cleanup(m.Server.Close)
+
+	t.Setenv("GRAFANA_URL", m.Server.URL)
+	t.Setenv("GRAFANA_AUTH", MockGrafanaAuth)
+	t.Setenv("GRAFANA_VERSION", MockGrafanaVersion)
+
+	return m
+}
+
+// Client returns a Grafana API client for the mock server, authenticated as the admin user.
+// It is meant to check the state of the mock server in tests (ex: in CheckDestroy functions).
+func (m *MockGrafana) Client() *goapi.GrafanaHTTPAPI {
+	u, _ := url.Parse(m.Server.URL)
+	return goapi.NewHTTPClientWithConfig(strfmt.Default, &goapi.TransportConfig{
+		Host:      u.Host,
+		BasePath:  "/api",
+		Schemes:   []string{u.Scheme},
+		BasicAuth: url.UserPassword("admin", "admin"),
+		OrgID:     1,
+	})
+}
+
+func (m *MockGrafana) newOrg(id int64, name string) *mockOrg {
+	defaultContactPointType := "email"
+	defaultContactPoint := &models.EmbeddedContactPoint{
+		UID:      m.uid(m.nextID()),
+		Name:     "grafana-default-email",
+		Type:     &defaultContactPointType,
+		Settings: map[string]interface{}{"addresses": "<example@email.com>"},
+	}
+	return &mockOrg{
+		id:              id,
+		name:            name,
+		folders:         map[string]*models.Folder{},
+		dashboards:      map[string]*mockDashboard{},
+		dataSources:     map[string]*mockDataSource{},
+		libraryPanels:   map[string]*models.LibraryElementDTO{},
+		snapshots:       map[string]*mockSnapshot{},
+		teams:           map[int64]*mockTeam{},
+		serviceAccounts: map[int64]*mockServiceAccount{},
+		policy:          defaultNotificationPolicy(),
+		contactPoints:   map[string]*models.EmbeddedContactPoint{defaultContactPoint.UID: defaultContactPoint},
+		muteTimings:     map[string]*models.MuteTimeInterval{},
+		templates:       map[string]*models.NotificationTemplate{},
+		alertRules:      map[string]*models.ProvisionedAlertRule{},
+		ruleGroups:      map[string]int64{},
+	}
+}
+
+func (m *MockGrafana) nextID() int64 {
+	m.lastID++
+	return m.lastID
+}
+
+// uid returns a deterministic UID for generated resources
+func (m *MockGrafana) uid(id int64) string {
+	return fmt.Sprintf("mock%010d", id)
+}
+
+// mockHandlerFunc handles a request in the context of an org. The mock server is locked while it runs.
+type mockHandlerFunc func(w http.ResponseWriter, r *http.Request, org *mockOrg)
+
+func (m *MockGrafana) handler() http.Handler {
+	mux := http.NewServeMux()
+	handle := func(pattern string, f mockHandlerFunc) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			if !m.authenticated(r) {
+				writeMockError(w, http.StatusUnauthorized, "Unauthorized")
+				return
+			}
+
+			orgID := int64(1)
+			if header := r.Header.Get("X-Grafana-Org-Id"); header != "" {
+				var err error
+				if orgID, err = strconv.ParseInt(header, 10, 64); err != nil {
+					writeMockError(w, http.StatusBadRequest, "invalid org ID")
+					return
+				}
+			}
+			org, ok := m.orgs[orgID]
+			if !ok {
+				writeMockError(w, http.StatusUnauthorized, "user is not a member of the organization")
+				return
+			}
+			f(w, r, org)
+		})
+	}
+
+	handle("GET /api/health", m.getHealth)
+	handle("GET /api/frontend/settings", m.getFrontendSettings)
+	handle("GET /api/org", m.getCurrentOrg)
+	handle("GET /api/org/users", m.getOrgUsers)
+	handle("GET /api/orgs", m.searchOrgs)
+
+	m.registerFolderRoutes(handle)
+	m.registerDashboardRoutes(handle)
+	m.registerDataSourceRoutes(handle)
+	m.registerLibraryPanelRoutes(handle)
+	m.registerSnapshotRoutes(handle)
+	m.registerAlertingRoutes(handle)
+	m.registerTeamRoutes(handle)
+	m.registerUserRoutes(handle)
+	m.registerServiceAccountRoutes(handle)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeMockError(w, http.StatusNotFound, fmt.Sprintf("the mock Grafana server doesn't support %s %s", r.Method, r.URL.Path))
+	})
+
+	return mux
+}
+
+func (m *MockGrafana) authenticated(r *http.Request) bool {
+	if username, password, ok := r.BasicAuth(); ok {
+		for _, user := range m.users {
+			if user.profile.Login == username && user.password == password {
+				return true
+			}
+		}
+		return false
+	}
+	// Tokens aren't validated
+	return strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+func (m *MockGrafana) getHealth(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	writeMockJSON(w, http.StatusOK, map[string]string{
+		"database": "ok",
+		"version":  MockGrafanaVersion,
+	})
+}
+
+func (m *MockGrafana) getFrontendSettings(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{
+		"buildInfo": map[string]string{
+			"version": MockGrafanaVersion,
+			"edition": "Open Source",
+		},
+		"featureToggles": m.FeatureToggles,
+	})
+}
+
+func (m *MockGrafana) getCurrentOrg(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	writeMockJSON(w, http.StatusOK, models.OrgDetailsDTO{ID: org.id, Name: org.name})
+}
+
+// searchOrgs pages the orgs like Grafana does: pages start at 0 and hold 1000 orgs by default.
+func (m *MockGrafana) searchOrgs(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("perpage"))
+	if perPage <= 0 {
+		perPage = 1000
+	}
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+	orgs := []*models.OrgDTO{}
+	for i, id := range sortedKeys(m.orgs) {
+		if i/perPage == page {
+			orgs = append(orgs, &models.OrgDTO{ID: id, Name: m.orgs[id].name})
+		}
+	}
+	writeMockJSON(w, http.StatusOK, orgs)
+}
+
+// getOrgUsers lists the users of the main org. All users are members of the main org.
+func (m *MockGrafana) getOrgUsers(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	users := []*models.OrgUserDTO{}
+	for _, id := range sortedKeys(m.users) {
+		user := m.users[id].profile
+		role := "Viewer"
+		if user.IsGrafanaAdmin {
+			role = "Admin"
+		}
+		users = append(users, &models.OrgUserDTO{
+			UserID: user.ID,
+			Login:  user.Login,
+			Email:  user.Email,
+			Name:   user.Name,
+			OrgID:  1,
+			Role:   role,
+		})
+	}
+	writeMockJSON(w, http.StatusOK, users)
+}
+
+func writeMockJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func writeMockError(w http.ResponseWriter, status int, message string) {
+	writeMockJSON(w, status, map[string]string{"message": message})
+}
+
+func writeMockMessage(w http.ResponseWriter, status int, message string) {
+	writeMockJSON(w, status, models.SuccessResponseBody{Message: message})
+}
+
+// readMockJSON decodes the request body, and writes a 400 error if it's invalid
+func readMockJSON(w http.ResponseWriter, r *http.Request, body interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeMockError(w, http.StatusBadRequest, fmt.Sprintf("bad request data: %v", err))
+		return false
+	}
+	return true
+}
+
+// pathID parses a numerical path parameter, and writes a 404 error if it's invalid
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil {
+		writeMockError(w, http.StatusNotFound, fmt.Sprintf("invalid %s", name))
+		return 0, false
+	}
+	return id, true
+}
+
+var slugRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slugify(title string) string {
+	return strings.Trim(slugRegexp.ReplaceAllString(strings.ToLower(title), "-"), "-")
+}
+
+func sortedKeys[K int64 | string, V any](values map[K]V) []K {
+	keys := make([]K, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
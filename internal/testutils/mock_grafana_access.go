@@ -0,0 +1,571 @@
+package testutils
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+type mockTeam struct {
+	models.TeamDTO
+	members     []int64
+	preferences models.Preferences
+}
+
+type mockServiceAccount struct {
+	models.ServiceAccountDTO
+	tokens map[int64]*models.TokenDTO
+}
+
+func (m *MockGrafana) registerTeamRoutes(handle func(string, mockHandlerFunc)) {
+	handle("GET /api/teams/search", m.searchTeams)
+	handle("POST /api/teams", m.createTeam)
+	handle("GET /api/teams/{team_id}", m.getTeam)
+	handle("PUT /api/teams/{team_id}", m.updateTeam)
+	handle("DELETE /api/teams/{team_id}", m.deleteTeam)
+	handle("GET /api/teams/{team_id}/members", m.getTeamMembers)
+	handle("POST /api/teams/{team_id}/members", m.addTeamMember)
+	handle("DELETE /api/teams/{team_id}/members/{user_id}", m.removeTeamMember)
+	handle("GET /api/teams/{team_id}/preferences", m.getTeamPreferences)
+	handle("PUT /api/teams/{team_id}/preferences", m.updateTeamPreferences)
+	handle("GET /api/teams/{team_id}/groups", m.getTeamGroups)
+}
+
+func (m *MockGrafana) registerUserRoutes(handle func(string, mockHandlerFunc)) {
+	handle("POST /api/admin/users", m.createUser)
+	handle("DELETE /api/admin/users/{user_id}", m.deleteUser)
+	handle("PUT /api/admin/users/{user_id}/password", m.updateUserPassword)
+	handle("PUT /api/admin/users/{user_id}/permissions", m.updateUserPermissions)
+	handle("GET /api/users/lookup", m.lookupUser)
+	handle("GET /api/users/{user_id}", m.getUser)
+	handle("PUT /api/users/{user_id}", m.updateUser)
+}
+
+func (m *MockGrafana) registerServiceAccountRoutes(handle func(string, mockHandlerFunc)) {
+	handle("GET /api/serviceaccounts/search", m.searchServiceAccounts)
+	handle("POST /api/serviceaccounts", m.createServiceAccount)
+	handle("GET /api/serviceaccounts/{serviceAccountId}", m.getServiceAccount)
+	handle("PATCH /api/serviceaccounts/{serviceAccountId}", m.updateServiceAccount)
+	handle("DELETE /api/serviceaccounts/{serviceAccountId}", m.deleteServiceAccount)
+	handle("GET /api/serviceaccounts/{serviceAccountId}/tokens", m.listServiceAccountTokens)
+	handle("POST /api/serviceaccounts/{serviceAccountId}/tokens", m.createServiceAccountToken)
+	handle("DELETE /api/serviceaccounts/{serviceAccountId}/tokens/{tokenId}", m.deleteServiceAccountToken)
+}
+
+// pathTeam returns the team of the team_id path parameter, and writes a 404 error if it doesn't exist
+func pathTeam(w http.ResponseWriter, r *http.Request, org *mockOrg) (*mockTeam, bool) {
+	id, ok := pathID(w, r, "team_id")
+	if !ok {
+		return nil, false
+	}
+	team, ok := org.teams[id]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "Team not found")
+		return nil, false
+	}
+	return team, true
+}
+
+func (t *mockTeam) response() *models.TeamDTO {
+	team := t.TeamDTO
+	team.MemberCount = int64(len(t.members))
+	return &team
+}
+
+func (m *MockGrafana) searchTeams(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+	name := r.URL.Query().Get("name")
+	teams := []*models.TeamDTO{}
+	for _, id := range sortedKeys(org.teams) {
+		team := org.teams[id]
+		if (name != "" && team.Name != name) || !strings.Contains(strings.ToLower(team.Name), query) {
+			continue
+		}
+		teams = append(teams, team.response())
+	}
+	writeMockJSON(w, http.StatusOK, models.SearchTeamQueryResult{
+		Page:       1,
+		PerPage:    int64(len(teams)),
+		TotalCount: int64(len(teams)),
+		Teams:      teams,
+	})
+}
+
+func (m *MockGrafana) createTeam(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body models.CreateTeamCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeMockError(w, http.StatusBadRequest, "the team name is required")
+		return
+	}
+	for _, team := range org.teams {
+		if team.Name == body.Name {
+			writeMockError(w, http.StatusConflict, "Team name taken")
+			return
+		}
+	}
+	id := m.nextID()
+	org.teams[id] = &mockTeam{
+		TeamDTO: models.TeamDTO{
+			ID:    id,
+			UID:   m.uid(id),
+			OrgID: org.id,
+			Name:  body.Name,
+			Email: body.Email,
+		},
+	}
+	writeMockJSON(w, http.StatusOK, models.CreateTeamOKBody{Message: "Team created", TeamID: id})
+}
+
+func (m *MockGrafana) getTeam(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	if team, ok := pathTeam(w, r, org); ok {
+		writeMockJSON(w, http.StatusOK, team.response())
+	}
+}
+
+func (m *MockGrafana) updateTeam(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	team, ok := pathTeam(w, r, org)
+	if !ok {
+		return
+	}
+	var body models.UpdateTeamCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	for _, other := range org.teams {
+		if other != team && other.Name == body.Name {
+			writeMockError(w, http.StatusConflict, "Team name taken")
+			return
+		}
+	}
+	if body.Name != "" {
+		team.Name = body.Name
+	}
+	team.Email = body.Email
+	writeMockMessage(w, http.StatusOK, "Team updated")
+}
+
+func (m *MockGrafana) deleteTeam(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	if team, ok := pathTeam(w, r, org); ok {
+		delete(org.teams, team.ID)
+		writeMockMessage(w, http.StatusOK, "Team deleted")
+	}
+}
+
+func (m *MockGrafana) getTeamMembers(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	team, ok := pathTeam(w, r, org)
+	if !ok {
+		return
+	}
+	members := []*models.TeamMemberDTO{}
+	for _, userID := range team.members {
+		user := m.users[userID].profile
+		members = append(members, &models.TeamMemberDTO{
+			UserID: user.ID,
+			Login:  user.Login,
+			Email:  user.Email,
+			Name:   user.Name,
+			OrgID:  org.id,
+			TeamID: team.ID,
+			Labels: []string{},
+		})
+	}
+	writeMockJSON(w, http.StatusOK, members)
+}
+
+func (m *MockGrafana) addTeamMember(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	team, ok := pathTeam(w, r, org)
+	if !ok {
+		return
+	}
+	var body models.AddTeamMemberCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if _, ok := m.users[body.UserID]; !ok {
+		writeMockError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	for _, userID := range team.members {
+		if userID == body.UserID {
+			writeMockError(w, http.StatusBadRequest, "User is already added to this team")
+			return
+		}
+	}
+	team.members = append(team.members, body.UserID)
+	writeMockMessage(w, http.StatusOK, "Member added to Team")
+}
+
+func (m *MockGrafana) removeTeamMember(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	team, ok := pathTeam(w, r, org)
+	if !ok {
+		return
+	}
+	userID, ok := pathID(w, r, "user_id")
+	if !ok {
+		return
+	}
+	for i, member := range team.members {
+		if member == userID {
+			team.members = append(team.members[:i], team.members[i+1:]...)
+			writeMockMessage(w, http.StatusOK, "Team member removed")
+			return
+		}
+	}
+	writeMockError(w, http.StatusNotFound, "Team member not found")
+}
+
+func (m *MockGrafana) getTeamPreferences(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	if team, ok := pathTeam(w, r, org); ok {
+		writeMockJSON(w, http.StatusOK, team.preferences)
+	}
+}
+
+func (m *MockGrafana) updateTeamPreferences(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	team, ok := pathTeam(w, r, org)
+	if !ok {
+		return
+	}
+	var body models.UpdatePrefsCmd
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	team.preferences = models.Preferences{
+		Theme:            body.Theme,
+		Timezone:         body.Timezone,
+		HomeDashboardUID: body.HomeDashboardUID,
+		WeekStart:        body.WeekStart,
+	}
+	writeMockMessage(w, http.StatusOK, "Preferences updated")
+}
+
+// getTeamGroups returns no groups. Team sync is an Enterprise feature that the mock server doesn't support.
+func (m *MockGrafana) getTeamGroups(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	if _, ok := pathTeam(w, r, org); ok {
+		writeMockJSON(w, http.StatusOK, []*models.TeamGroupDTO{})
+	}
+}
+
+// pathUser returns the user of the user_id path parameter, and writes a 404 error if it doesn't exist
+func (m *MockGrafana) pathUser(w http.ResponseWriter, r *http.Request) (*mockUser, bool) {
+	id, ok := pathID(w, r, "user_id")
+	if !ok {
+		return nil, false
+	}
+	user, ok := m.users[id]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "user not found")
+		return nil, false
+	}
+	return user, true
+}
+
+// userExists returns true if another user has the same login or email
+func (m *MockGrafana) userExists(except *mockUser, login, email string) bool {
+	for _, user := range m.users {
+		if user == except {
+			continue
+		}
+		if (login != "" && user.profile.Login == login) || (email != "" && user.profile.Email == email) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockGrafana) createUser(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body models.AdminCreateUserForm
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Login == "" {
+		body.Login = body.Email
+	}
+	if body.Login == "" || body.Password == "" {
+		writeMockError(w, http.StatusBadRequest, "the login or email, and the password are required")
+		return
+	}
+	if m.userExists(nil, body.Login, body.Email) {
+		writeMockError(w, http.StatusPreconditionFailed, "user already exists")
+		return
+	}
+	id := m.nextID()
+	m.users[id] = &mockUser{
+		profile: models.UserProfileDTO{
+			ID:    id,
+			UID:   m.uid(id),
+			Login: body.Login,
+			Email: body.Email,
+			Name:  body.Name,
+			OrgID: 1,
+		},
+		password: string(body.Password),
+	}
+	writeMockJSON(w, http.StatusOK, models.AdminCreateUserResponse{ID: id, Message: "User created"})
+}
+
+func (m *MockGrafana) getUser(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	if user, ok := m.pathUser(w, r); ok {
+		writeMockJSON(w, http.StatusOK, user.profile)
+	}
+}
+
+func (m *MockGrafana) lookupUser(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	loginOrEmail := r.URL.Query().Get("loginOrEmail")
+	for _, id := range sortedKeys(m.users) {
+		user := m.users[id]
+		if user.profile.Login == loginOrEmail || user.profile.Email == loginOrEmail {
+			writeMockJSON(w, http.StatusOK, user.profile)
+			return
+		}
+	}
+	writeMockError(w, http.StatusNotFound, "user not found")
+}
+
+func (m *MockGrafana) updateUser(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	user, ok := m.pathUser(w, r)
+	if !ok {
+		return
+	}
+	var body models.UpdateUserCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if m.userExists(user, body.Login, body.Email) {
+		writeMockError(w, http.StatusConflict, "user with the same login or email already exists")
+		return
+	}
+	user.profile.Login = body.Login
+	user.profile.Email = body.Email
+	user.profile.Name = body.Name
+	writeMockMessage(w, http.StatusOK, "User updated")
+}
+
+func (m *MockGrafana) updateUserPassword(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	user, ok := m.pathUser(w, r)
+	if !ok {
+		return
+	}
+	var body models.AdminUpdateUserPasswordForm
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	user.password = string(body.Password)
+	writeMockMessage(w, http.StatusOK, "User password updated")
+}
+
+func (m *MockGrafana) updateUserPermissions(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	user, ok := m.pathUser(w, r)
+	if !ok {
+		return
+	}
+	var body models.AdminUpdateUserPermissionsForm
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	user.profile.IsGrafanaAdmin = body.IsGrafanaAdmin
+	writeMockMessage(w, http.StatusOK, "User permissions updated")
+}
+
+func (m *MockGrafana) deleteUser(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	user, ok := m.pathUser(w, r)
+	if !ok {
+		return
+	}
+	if user.profile.Login == "admin" {
+		writeMockError(w, http.StatusForbidden, "the admin user of the mock server can't be deleted")
+		return
+	}
+	delete(m.users, user.profile.ID)
+	for _, o := range m.orgs {
+		for _, team := range o.teams {
+			for i, member := range team.members {
+				if member == user.profile.ID {
+					team.members = append(team.members[:i], team.members[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	writeMockMessage(w, http.StatusOK, "User deleted")
+}
+
+// pathServiceAccount returns the service account of the serviceAccountId path parameter, and writes a 404 error if it doesn't exist
+func pathServiceAccount(w http.ResponseWriter, r *http.Request, org *mockOrg) (*mockServiceAccount, bool) {
+	id, ok := pathID(w, r, "serviceAccountId")
+	if !ok {
+		return nil, false
+	}
+	sa, ok := org.serviceAccounts[id]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "service account not found")
+		return nil, false
+	}
+	return sa, true
+}
+
+func (sa *mockServiceAccount) response() *models.ServiceAccountDTO {
+	dto := sa.ServiceAccountDTO
+	dto.Tokens = int64(len(sa.tokens))
+	return &dto
+}
+
+func (m *MockGrafana) searchServiceAccounts(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+	serviceAccounts := []*models.ServiceAccountDTO{}
+	for _, id := range sortedKeys(org.serviceAccounts) {
+		sa := org.serviceAccounts[id]
+		if strings.Contains(strings.ToLower(sa.Name), query) {
+			serviceAccounts = append(serviceAccounts, sa.response())
+		}
+	}
+	writeMockJSON(w, http.StatusOK, models.SearchOrgServiceAccountsResult{
+		Page:            1,
+		PerPage:         int64(len(serviceAccounts)),
+		TotalCount:      int64(len(serviceAccounts)),
+		ServiceAccounts: serviceAccounts,
+	})
+}
+
+func (m *MockGrafana) createServiceAccount(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body models.CreateServiceAccountForm
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeMockError(w, http.StatusBadRequest, "the service account name is required")
+		return
+	}
+	for _, sa := range org.serviceAccounts {
+		if sa.Name == body.Name {
+			writeMockError(w, http.StatusBadRequest, "service account already exists")
+			return
+		}
+	}
+	if body.Role == "" {
+		body.Role = "Viewer"
+	}
+	id := m.nextID()
+	sa := &mockServiceAccount{
+		ServiceAccountDTO: models.ServiceAccountDTO{
+			ID:         id,
+			OrgID:      org.id,
+			Name:       body.Name,
+			Login:      "sa-" + slugify(body.Name),
+			Role:       body.Role,
+			IsDisabled: body.IsDisabled,
+		},
+		tokens: map[int64]*models.TokenDTO{},
+	}
+	org.serviceAccounts[id] = sa
+	writeMockJSON(w, http.StatusCreated, sa.response())
+}
+
+func (m *MockGrafana) getServiceAccount(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	if sa, ok := pathServiceAccount(w, r, org); ok {
+		writeMockJSON(w, http.StatusOK, sa.response())
+	}
+}
+
+func (m *MockGrafana) updateServiceAccount(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	sa, ok := pathServiceAccount(w, r, org)
+	if !ok {
+		return
+	}
+	var body models.UpdateServiceAccountForm
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Name != "" {
+		sa.Name = body.Name
+	}
+	if body.Role != "" {
+		sa.Role = body.Role
+	}
+	sa.IsDisabled = body.IsDisabled
+	writeMockJSON(w, http.StatusOK, models.UpdateServiceAccountOKBody{
+		ID:      sa.ID,
+		Name:    sa.Name,
+		Message: "Service account updated",
+		Serviceaccount: &models.ServiceAccountProfileDTO{
+			ID:         sa.ID,
+			OrgID:      sa.OrgID,
+			Name:       sa.Name,
+			Login:      sa.Login,
+			Role:       sa.Role,
+			IsDisabled: sa.IsDisabled,
+			Tokens:     int64(len(sa.tokens)),
+		},
+	})
+}
+
+func (m *MockGrafana) deleteServiceAccount(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	if sa, ok := pathServiceAccount(w, r, org); ok {
+		delete(org.serviceAccounts, sa.ID)
+		writeMockMessage(w, http.StatusOK, "Service account deleted")
+	}
+}
+
+func (m *MockGrafana) listServiceAccountTokens(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	sa, ok := pathServiceAccount(w, r, org)
+	if !ok {
+		return
+	}
+	tokens := []*models.TokenDTO{}
+	for _, id := range sortedKeys(sa.tokens) {
+		token := *sa.tokens[id]
+		token.HasExpired = !time.Time(token.Expiration).IsZero() && time.Time(token.Expiration).Before(time.Now())
+		tokens = append(tokens, &token)
+	}
+	writeMockJSON(w, http.StatusOK, tokens)
+}
+
+func (m *MockGrafana) createServiceAccountToken(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	sa, ok := pathServiceAccount(w, r, org)
+	if !ok {
+		return
+	}
+	var body models.AddServiceAccountTokenCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	for _, token := range sa.tokens {
+		if token.Name == body.Name {
+			writeMockError(w, http.StatusConflict, "service account token with the same name already exists")
+			return
+		}
+	}
+	id := m.nextID()
+	token := &models.TokenDTO{
+		ID:      id,
+		Name:    body.Name,
+		Created: strfmt.DateTime(time.Now()),
+	}
+	if body.SecondsToLive > 0 {
+		token.Expiration = strfmt.DateTime(time.Now().Add(time.Duration(body.SecondsToLive) * time.Second))
+	}
+	sa.tokens[id] = token
+	writeMockJSON(w, http.StatusOK, models.NewAPIKeyResult{ID: id, Name: body.Name, Key: "glsa_" + m.uid(id)})
+}
+
+func (m *MockGrafana) deleteServiceAccountToken(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	sa, ok := pathServiceAccount(w, r, org)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r, "tokenId")
+	if !ok {
+		return
+	}
+	if _, ok := sa.tokens[id]; !ok {
+		writeMockError(w, http.StatusNotFound, "service account token not found")
+		return
+	}
+	delete(sa.tokens, id)
+	writeMockMessage(w, http.StatusOK, "Service account token deleted")
+}
@@ -0,0 +1,399 @@
+package testutils
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+func defaultNotificationPolicy() *models.Route {
+	return &models.Route{
+		Receiver: "grafana-default-email",
+		GroupBy:  []string{"grafana_folder", "alertname"},
+	}
+}
+
+// mockProvenance returns the provenance of an alerting resource provisioned through the API.
+// Resources are editable in the UI when the X-Disable-Provenance header is set.
+func mockProvenance(r *http.Request) string {
+	if r.Header.Get("X-Disable-Provenance") != "" {
+		return ""
+	}
+	return "api"
+}
+
+func ruleGroupKey(folderUID, title string) string {
+	return folderUID + "/" + title
+}
+
+func (m *MockGrafana) registerAlertingRoutes(handle func(string, mockHandlerFunc)) {
+	handle("GET /api/v1/provisioning/policies", m.getPolicyTree)
+	handle("PUT /api/v1/provisioning/policies", m.putPolicyTree)
+	handle("DELETE /api/v1/provisioning/policies", m.resetPolicyTree)
+
+	handle("GET /api/v1/provisioning/contact-points", m.getContactPoints)
+	handle("POST /api/v1/provisioning/contact-points", m.postContactPoint)
+	handle("PUT /api/v1/provisioning/contact-points/{UID}", m.putContactPoint)
+	handle("DELETE /api/v1/provisioning/contact-points/{UID}", m.deleteContactPoint)
+
+	handle("GET /api/v1/provisioning/mute-timings", m.getMuteTimings)
+	handle("POST /api/v1/provisioning/mute-timings", m.postMuteTiming)
+	handle("GET /api/v1/provisioning/mute-timings/{name}", m.getMuteTiming)
+	handle("PUT /api/v1/provisioning/mute-timings/{name}", m.putMuteTiming)
+	handle("DELETE /api/v1/provisioning/mute-timings/{name}", m.deleteMuteTiming)
+
+	handle("GET /api/v1/provisioning/templates", m.getTemplates)
+	handle("GET /api/v1/provisioning/templates/{name}", m.getTemplate)
+	handle("PUT /api/v1/provisioning/templates/{name}", m.putTemplate)
+	handle("DELETE /api/v1/provisioning/templates/{name}", m.deleteTemplate)
+
+	handle("GET /api/v1/provisioning/alert-rules", m.getAlertRules)
+	handle("GET /api/v1/provisioning/alert-rules/{UID}", m.getAlertRule)
+	handle("DELETE /api/v1/provisioning/alert-rules/{UID}", m.deleteAlertRule)
+	handle("GET /api/v1/provisioning/folder/{FolderUID}/rule-groups/{Group}", m.getAlertRuleGroup)
+	handle("PUT /api/v1/provisioning/folder/{FolderUID}/rule-groups/{Group}", m.putAlertRuleGroup)
+}
+
+func (m *MockGrafana) getPolicyTree(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	writeMockJSON(w, http.StatusOK, org.policy)
+}
+
+func (m *MockGrafana) putPolicyTree(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body models.Route
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Receiver == "" {
+		writeMockError(w, http.StatusBadRequest, "invalid object specification: root route must specify a default receiver")
+		return
+	}
+	if missing := org.missingPolicyReference(&body); missing != "" {
+		writeMockError(w, http.StatusBadRequest, "invalid object specification: "+missing)
+		return
+	}
+	body.Provenance = models.Provenance(mockProvenance(r))
+	org.policy = &body
+	writeMockJSON(w, http.StatusAccepted, map[string]string{"message": "policies updated"})
+}
+
+// missingPolicyReference returns an error message if the policy tree references a contact point or mute timing that doesn't exist
+func (o *mockOrg) missingPolicyReference(route *models.Route) string {
+	if route.Receiver != "" && o.contactPointsByName(route.Receiver) == nil {
+		return "receiver '" + route.Receiver + "' does not exist"
+	}
+	for _, muteTiming := range route.MuteTimeIntervals {
+		if _, ok := o.muteTimings[muteTiming]; !ok {
+			return "mute time interval '" + muteTiming + "' does not exist"
+		}
+	}
+	for _, child := range route.Routes {
+		if missing := o.missingPolicyReference(child); missing != "" {
+			return missing
+		}
+	}
+	return ""
+}
+
+func (m *MockGrafana) resetPolicyTree(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	org.policy = defaultNotificationPolicy()
+	writeMockJSON(w, http.StatusAccepted, map[string]string{"message": "policies reset"})
+}
+
+// policyReferences returns true if the policy tree references the given contact point or mute timing
+func policyReferences(route *models.Route, receiver, muteTiming string) bool {
+	if receiver != "" && route.Receiver == receiver {
+		return true
+	}
+	for _, name := range route.MuteTimeIntervals {
+		if muteTiming != "" && name == muteTiming {
+			return true
+		}
+	}
+	for _, child := range route.Routes {
+		if policyReferences(child, receiver, muteTiming) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *mockOrg) contactPointsByName(name string) []*models.EmbeddedContactPoint {
+	var contactPoints []*models.EmbeddedContactPoint
+	for _, uid := range sortedKeys(o.contactPoints) {
+		if name == "" || o.contactPoints[uid].Name == name {
+			contactPoints = append(contactPoints, o.contactPoints[uid])
+		}
+	}
+	return contactPoints
+}
+
+func (m *MockGrafana) getContactPoints(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	contactPoints := org.contactPointsByName(r.URL.Query().Get("name"))
+	if contactPoints == nil {
+		contactPoints = []*models.EmbeddedContactPoint{}
+	}
+	writeMockJSON(w, http.StatusOK, contactPoints)
+}
+
+func (m *MockGrafana) postContactPoint(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body models.EmbeddedContactPoint
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Name == "" || body.Type == nil || *body.Type == "" {
+		writeMockError(w, http.StatusBadRequest, "invalid object specification: the name and type of the contact point are required")
+		return
+	}
+	if body.UID == "" {
+		body.UID = m.uid(m.nextID())
+	} else if _, ok := org.contactPoints[body.UID]; ok {
+		writeMockError(w, http.StatusBadRequest, "contact point with the same uid already exists")
+		return
+	}
+	body.Provenance = mockProvenance(r)
+	org.contactPoints[body.UID] = &body
+	writeMockJSON(w, http.StatusAccepted, body)
+}
+
+func (m *MockGrafana) putContactPoint(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	existing, ok := org.contactPoints[r.PathValue("UID")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "contact point not found")
+		return
+	}
+	var body models.EmbeddedContactPoint
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Name != existing.Name && len(org.contactPointsByName(existing.Name)) == 1 && policyReferences(org.policy, existing.Name, "") {
+		writeMockError(w, http.StatusConflict, "contact point is referenced by the notification policy")
+		return
+	}
+	body.UID = existing.UID
+	body.Provenance = mockProvenance(r)
+	org.contactPoints[body.UID] = &body
+	writeMockJSON(w, http.StatusAccepted, map[string]string{"message": "contactpoint updated"})
+}
+
+func (m *MockGrafana) deleteContactPoint(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	existing, ok := org.contactPoints[r.PathValue("UID")]
+	if !ok {
+		// Grafana doesn't fail when deleting a contact point that doesn't exist
+		writeMockJSON(w, http.StatusAccepted, map[string]string{"message": "contactpoint deleted"})
+		return
+	}
+	if len(org.contactPointsByName(existing.Name)) == 1 && policyReferences(org.policy, existing.Name, "") {
+		writeMockError(w, http.StatusConflict, "contact point is referenced by the notification policy")
+		return
+	}
+	delete(org.contactPoints, existing.UID)
+	writeMockJSON(w, http.StatusAccepted, map[string]string{"message": "contactpoint deleted"})
+}
+
+func (m *MockGrafana) getMuteTimings(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	muteTimings := []*models.MuteTimeInterval{}
+	for _, name := range sortedKeys(org.muteTimings) {
+		muteTimings = append(muteTimings, org.muteTimings[name])
+	}
+	writeMockJSON(w, http.StatusOK, muteTimings)
+}
+
+func (m *MockGrafana) postMuteTiming(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body models.MuteTimeInterval
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeMockError(w, http.StatusBadRequest, "invalid object specification: the name of the mute timing is required")
+		return
+	}
+	if _, ok := org.muteTimings[body.Name]; ok {
+		writeMockError(w, http.StatusConflict, "a mute timing with the same name already exists")
+		return
+	}
+	org.muteTimings[body.Name] = &body
+	writeMockJSON(w, http.StatusCreated, body)
+}
+
+func (m *MockGrafana) getMuteTiming(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	muteTiming, ok := org.muteTimings[r.PathValue("name")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "mute timing not found")
+		return
+	}
+	writeMockJSON(w, http.StatusOK, muteTiming)
+}
+
+func (m *MockGrafana) putMuteTiming(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	name := r.PathValue("name")
+	if _, ok := org.muteTimings[name]; !ok {
+		writeMockError(w, http.StatusNotFound, "mute timing not found")
+		return
+	}
+	var body models.MuteTimeInterval
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	body.Name = name
+	org.muteTimings[name] = &body
+	writeMockJSON(w, http.StatusAccepted, body)
+}
+
+func (m *MockGrafana) deleteMuteTiming(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	name := r.PathValue("name")
+	if policyReferences(org.policy, "", name) {
+		writeMockError(w, http.StatusConflict, "mute timing is referenced by the notification policy")
+		return
+	}
+	delete(org.muteTimings, name)
+	writeMockJSON(w, http.StatusNoContent, nil)
+}
+
+func (m *MockGrafana) getTemplates(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	templates := []*models.NotificationTemplate{}
+	for _, name := range sortedKeys(org.templates) {
+		templates = append(templates, org.templates[name])
+	}
+	writeMockJSON(w, http.StatusOK, templates)
+}
+
+func (m *MockGrafana) getTemplate(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	template, ok := org.templates[r.PathValue("name")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "template not found")
+		return
+	}
+	writeMockJSON(w, http.StatusOK, template)
+}
+
+func (m *MockGrafana) putTemplate(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body models.NotificationTemplateContent
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	name := r.PathValue("name")
+	if !strings.Contains(body.Template, `define "`) {
+		writeMockError(w, http.StatusBadRequest, "invalid object specification: template must define at least one template")
+		return
+	}
+	template := &models.NotificationTemplate{
+		Name:       name,
+		Template:   body.Template,
+		Provenance: models.Provenance(mockProvenance(r)),
+	}
+	org.templates[name] = template
+	writeMockJSON(w, http.StatusAccepted, template)
+}
+
+func (m *MockGrafana) deleteTemplate(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	delete(org.templates, r.PathValue("name"))
+	writeMockJSON(w, http.StatusNoContent, nil)
+}
+
+func (m *MockGrafana) getAlertRules(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	rules := []*models.ProvisionedAlertRule{}
+	for _, uid := range sortedKeys(org.alertRules) {
+		rules = append(rules, org.alertRules[uid])
+	}
+	writeMockJSON(w, http.StatusOK, rules)
+}
+
+func (m *MockGrafana) getAlertRule(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	rule, ok := org.alertRules[r.PathValue("UID")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "alert rule not found")
+		return
+	}
+	writeMockJSON(w, http.StatusOK, rule)
+}
+
+func (m *MockGrafana) deleteAlertRule(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	delete(org.alertRules, r.PathValue("UID"))
+	writeMockJSON(w, http.StatusNoContent, nil)
+}
+
+func (o *mockOrg) alertRuleGroup(folderUID, title string) *models.AlertRuleGroup {
+	group := &models.AlertRuleGroup{
+		FolderUID: folderUID,
+		Title:     title,
+		Interval:  o.ruleGroups[ruleGroupKey(folderUID, title)],
+		Rules:     []*models.ProvisionedAlertRule{},
+	}
+	for _, uid := range sortedKeys(o.alertRules) {
+		rule := o.alertRules[uid]
+		if *rule.FolderUID == folderUID && *rule.RuleGroup == title {
+			group.Rules = append(group.Rules, rule)
+		}
+	}
+	return group
+}
+
+func (m *MockGrafana) getAlertRuleGroup(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	group := org.alertRuleGroup(r.PathValue("FolderUID"), r.PathValue("Group"))
+	if len(group.Rules) == 0 {
+		writeMockError(w, http.StatusNotFound, "rule group not found")
+		return
+	}
+	writeMockJSON(w, http.StatusOK, group)
+}
+
+// putAlertRuleGroup replaces the rules of a group. Rules of the group that aren't in the request are deleted.
+func (m *MockGrafana) putAlertRuleGroup(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	folderUID, title := r.PathValue("FolderUID"), r.PathValue("Group")
+	if _, ok := org.folders[folderUID]; !ok {
+		writeMockError(w, http.StatusNotFound, "folder does not exist")
+		return
+	}
+	var body models.AlertRuleGroup
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Interval <= 0 || body.Interval%10 != 0 {
+		writeMockError(w, http.StatusBadRequest, "invalid rule group interval: it must be a positive multiple of 10 seconds")
+		return
+	}
+
+	keep := map[string]bool{}
+	for _, rule := range body.Rules {
+		if rule.Title == nil || *rule.Title == "" || rule.Condition == nil || len(rule.Data) == 0 {
+			writeMockError(w, http.StatusBadRequest, "invalid alert rule: the title, condition and data are required")
+			return
+		}
+		if rule.UID != "" {
+			if existing, ok := org.alertRules[rule.UID]; ok && (*existing.FolderUID != folderUID || *existing.RuleGroup != title) {
+				writeMockError(w, http.StatusBadRequest, "alert rule "+rule.UID+" belongs to another rule group")
+				return
+			}
+			keep[rule.UID] = true
+		}
+	}
+
+	for uid, rule := range org.alertRules {
+		if *rule.FolderUID == folderUID && *rule.RuleGroup == title && !keep[uid] {
+			delete(org.alertRules, uid)
+		}
+	}
+	provenance := models.Provenance(mockProvenance(r))
+	for _, rule := range body.Rules {
+		if existing, ok := org.alertRules[rule.UID]; ok {
+			rule.ID = existing.ID
+		} else {
+			rule.ID = m.nextID()
+			if rule.UID == "" {
+				rule.UID = m.uid(rule.ID)
+			}
+		}
+		rule.OrgID = &org.id
+		rule.FolderUID = &folderUID
+		rule.RuleGroup = &title
+		rule.Provenance = provenance
+		rule.Updated = strfmt.DateTime(time.Now())
+		org.alertRules[rule.UID] = rule
+	}
+	org.ruleGroups[ruleGroupKey(folderUID, title)] = body.Interval
+
+	writeMockJSON(w, http.StatusOK, org.alertRuleGroup(folderUID, title))
+}
@@ -0,0 +1,552 @@
+package testutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+type mockDashboard struct {
+	model     map[string]interface{}
+	folderUID string
+	// versions is the history of the dashboard, from the oldest to the latest version. Each version holds a copy of the model.
+	versions []*models.DashboardVersionMeta
+}
+
+func (d *mockDashboard) title() string {
+	title, _ := d.model["title"].(string)
+	return title
+}
+
+func (d *mockDashboard) meta() *models.DashboardMeta {
+	uid := d.model["uid"].(string)
+	version := d.model["version"].(int64)
+	return &models.DashboardMeta{
+		FolderUID: d.folderUID,
+		Slug:      slugify(d.title()),
+		URL:       fmt.Sprintf("/d/%s/%s", uid, slugify(d.title())),
+		Version:   version,
+		CanEdit:   true,
+		CanSave:   true,
+	}
+}
+
+func (m *MockGrafana) registerFolderRoutes(handle func(string, mockHandlerFunc)) {
+	handle("GET /api/folders", m.getFolders)
+	handle("POST /api/folders", m.createFolder)
+	handle("GET /api/folders/{folder_uid}", m.getFolder)
+	handle("GET /api/folders/id/{folder_id}", m.getFolderByID)
+	handle("PUT /api/folders/{folder_uid}", m.updateFolder)
+	handle("POST /api/folders/{folder_uid}/move", m.moveFolder)
+	handle("DELETE /api/folders/{folder_uid}", m.deleteFolder)
+}
+
+func (m *MockGrafana) registerDashboardRoutes(handle func(string, mockHandlerFunc)) {
+	handle("POST /api/dashboards/db", m.postDashboard)
+	handle("GET /api/dashboards/uid/{uid}", m.getDashboard)
+	handle("DELETE /api/dashboards/uid/{uid}", m.deleteDashboard)
+	handle("GET /api/dashboards/uid/{uid}/versions", m.getDashboardVersions)
+	handle("GET /api/dashboards/uid/{uid}/versions/{version}", m.getDashboardVersion)
+	handle("POST /api/dashboards/uid/{uid}/restore", m.restoreDashboardVersion)
+	handle("GET /api/search", m.search)
+}
+
+// getFolders lists the subfolders of a folder like Grafana does: pages start at 1 and hold 1000 folders by default
+func (m *MockGrafana) getFolders(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	parentUID := r.URL.Query().Get("parentUid")
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 1000
+	}
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+
+	hits := []*models.FolderSearchHit{}
+	for _, uid := range sortedKeys(org.folders) {
+		folder := org.folders[uid]
+		if folder.ParentUID != parentUID {
+			continue
+		}
+		hits = append(hits, &models.FolderSearchHit{ID: folder.ID, UID: folder.UID, Title: folder.Title, ParentUID: folder.ParentUID})
+	}
+	start := min((page-1)*limit, len(hits))
+	writeMockJSON(w, http.StatusOK, hits[start:min(start+limit, len(hits))])
+}
+
+func (m *MockGrafana) createFolder(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body models.CreateFolderCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Title == "" {
+		writeMockError(w, http.StatusBadRequest, "folder title cannot be empty")
+		return
+	}
+	if body.UID != "" && (org.folders[body.UID] != nil || org.dashboards[body.UID] != nil) {
+		writeMockError(w, http.StatusConflict, "a folder with the same uid already exists")
+		return
+	}
+	if body.ParentUID != "" && org.folders[body.ParentUID] == nil {
+		writeMockError(w, http.StatusNotFound, "parent folder not found")
+		return
+	}
+	if org.folderTitleExists(body.Title, body.ParentUID, "") {
+		writeMockError(w, http.StatusConflict, "a folder or dashboard in the same folder with the same name already exists")
+		return
+	}
+
+	id := m.nextID()
+	if body.UID == "" {
+		body.UID = m.uid(id)
+	}
+	now := strfmt.DateTime(time.Now())
+	folder := &models.Folder{
+		ID:        id,
+		UID:       body.UID,
+		Title:     body.Title,
+		ParentUID: body.ParentUID,
+		URL:       fmt.Sprintf("/dashboards/f/%s/%s", body.UID, slugify(body.Title)),
+		Version:   1,
+		Created:   now,
+		Updated:   now,
+		CanAdmin:  true,
+		CanDelete: true,
+		CanEdit:   true,
+		CanSave:   true,
+	}
+	org.folders[folder.UID] = folder
+	writeMockJSON(w, http.StatusOK, folder)
+}
+
+func (m *MockGrafana) getFolder(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	folder, ok := org.folders[r.PathValue("folder_uid")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "folder not found")
+		return
+	}
+	writeMockJSON(w, http.StatusOK, org.folderWithParents(folder))
+}
+
+func (m *MockGrafana) getFolderByID(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	id, ok := pathID(w, r, "folder_id")
+	if !ok {
+		return
+	}
+	for _, folder := range org.folders {
+		if folder.ID == id {
+			writeMockJSON(w, http.StatusOK, folder)
+			return
+		}
+	}
+	writeMockError(w, http.StatusNotFound, "folder not found")
+}
+
+func (m *MockGrafana) updateFolder(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	folder, ok := org.folders[r.PathValue("folder_uid")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "folder not found")
+		return
+	}
+	var body models.UpdateFolderCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if !body.Overwrite && body.Version != folder.Version {
+		writeMockError(w, http.StatusPreconditionFailed, "the folder has been changed by someone else")
+		return
+	}
+	if body.Title != "" {
+		if org.folderTitleExists(body.Title, folder.ParentUID, folder.UID) {
+			writeMockError(w, http.StatusConflict, "a folder or dashboard in the same folder with the same name already exists")
+			return
+		}
+		folder.Title = body.Title
+		folder.URL = fmt.Sprintf("/dashboards/f/%s/%s", folder.UID, slugify(folder.Title))
+	}
+	folder.Version++
+	folder.Updated = strfmt.DateTime(time.Now())
+	writeMockJSON(w, http.StatusOK, folder)
+}
+
+// moveFolder moves a folder with its contents to another parent folder, or to the root folder if no parent is given
+func (m *MockGrafana) moveFolder(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	if !m.FeatureToggles["nestedFolders"] {
+		writeMockError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	folder, ok := org.folders[r.PathValue("folder_uid")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "folder not found")
+		return
+	}
+	var body models.MoveFolderCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.ParentUID != "" && org.folders[body.ParentUID] == nil {
+		writeMockError(w, http.StatusNotFound, "parent folder not found")
+		return
+	}
+	if org.folderDescendants(folder.UID)[body.ParentUID] {
+		writeMockError(w, http.StatusBadRequest, "folder cannot be moved into itself or one of its subfolders")
+		return
+	}
+	if org.folderTitleExists(folder.Title, body.ParentUID, folder.UID) {
+		writeMockError(w, http.StatusConflict, "a folder or dashboard in the same folder with the same name already exists")
+		return
+	}
+	folder.ParentUID = body.ParentUID
+	folder.Version++
+	folder.Updated = strfmt.DateTime(time.Now())
+	writeMockJSON(w, http.StatusOK, folder)
+}
+
+// deleteFolder deletes a folder with its subfolders, dashboards and alert rules
+func (m *MockGrafana) deleteFolder(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	folder, ok := org.folders[r.PathValue("folder_uid")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "folder not found")
+		return
+	}
+	forceDeleteRules := r.URL.Query().Get("forceDeleteRules") == "true"
+
+	toDelete := org.folderDescendants(folder.UID)
+	if !forceDeleteRules {
+		for _, rule := range org.alertRules {
+			if rule.FolderUID != nil && toDelete[*rule.FolderUID] {
+				writeMockError(w, http.StatusBadRequest, "folder cannot be deleted: folder contains alert rules")
+				return
+			}
+		}
+	}
+
+	for uid := range toDelete {
+		delete(org.folders, uid)
+	}
+	for uid, dashboard := range org.dashboards {
+		if toDelete[dashboard.folderUID] {
+			delete(org.dashboards, uid)
+		}
+	}
+	for uid, rule := range org.alertRules {
+		if rule.FolderUID != nil && toDelete[*rule.FolderUID] {
+			delete(org.alertRules, uid)
+		}
+	}
+
+	message := "Folder deleted"
+	writeMockJSON(w, http.StatusOK, models.DeleteFolderOKBody{ID: &folder.ID, Title: &folder.Title, Message: &message})
+}
+
+// folderWithParents returns a copy of a folder with its ancestors, from the root folder to the parent folder
+func (o *mockOrg) folderWithParents(folder *models.Folder) *models.Folder {
+	response := *folder
+	response.Parents = []*models.Folder{}
+	for parent := o.folders[folder.ParentUID]; parent != nil; parent = o.folders[parent.ParentUID] {
+		response.Parents = append([]*models.Folder{parent}, response.Parents...)
+	}
+	return &response
+}
+
+// folderDescendants returns the UIDs of a folder and of all its subfolders
+func (o *mockOrg) folderDescendants(uid string) map[string]bool {
+	descendants := map[string]bool{uid: true}
+	for changed := true; changed; {
+		changed = false
+		for _, folder := range o.folders {
+			if descendants[folder.ParentUID] && !descendants[folder.UID] {
+				descendants[folder.UID] = true
+				changed = true
+			}
+		}
+	}
+	return descendants
+}
+
+// folderTitleExists returns true if a folder or a dashboard other than the given one has the same title in the parent folder
+func (o *mockOrg) folderTitleExists(title, parentUID, exceptUID string) bool {
+	for _, folder := range o.folders {
+		if folder.UID != exceptUID && folder.ParentUID == parentUID && strings.EqualFold(folder.Title, title) {
+			return true
+		}
+	}
+	for uid, dashboard := range o.dashboards {
+		if uid != exceptUID && dashboard.folderUID == parentUID && strings.EqualFold(dashboard.title(), title) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockGrafana) postDashboard(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body models.SaveDashboardCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	m.saveDashboard(w, org, body, 0)
+}
+
+// saveDashboard creates or updates a dashboard and adds a version to its history
+func (m *MockGrafana) saveDashboard(w http.ResponseWriter, org *mockOrg, body models.SaveDashboardCommand, restoredFrom int64) {
+	model, ok := body.Dashboard.(map[string]interface{})
+	if !ok {
+		writeMockError(w, http.StatusBadRequest, "dashboard must be a JSON object")
+		return
+	}
+	title, _ := model["title"].(string)
+	if title == "" {
+		writeMockError(w, http.StatusBadRequest, "Dashboard title cannot be empty")
+		return
+	}
+	if body.FolderUID != "" && org.folders[body.FolderUID] == nil {
+		writeMockError(w, http.StatusBadRequest, "folder not found")
+		return
+	}
+
+	uid, _ := model["uid"].(string)
+	existing := org.dashboards[uid]
+	if existing != nil && !body.Overwrite {
+		if version, ok := model["version"].(float64); !ok || int64(version) != existing.model["version"].(int64) {
+			writeMockJSON(w, http.StatusPreconditionFailed, map[string]string{
+				"message": "The dashboard has been changed by someone else",
+				"status":  "version-mismatch",
+			})
+			return
+		}
+	}
+	if org.folderTitleExists(title, body.FolderUID, uid) && !body.Overwrite {
+		writeMockJSON(w, http.StatusPreconditionFailed, map[string]string{
+			"message": "A dashboard with the same name in the folder already exists",
+			"status":  "name-exists",
+		})
+		return
+	}
+
+	var id, version int64 = 0, 1
+	if existing != nil {
+		id = existing.model["id"].(int64)
+		version = existing.model["version"].(int64) + 1
+	} else {
+		id = m.nextID()
+		if uid == "" {
+			uid = m.uid(id)
+		}
+	}
+	model["id"] = id
+	model["uid"] = uid
+	model["version"] = version
+	dashboard := &mockDashboard{model: model, folderUID: body.FolderUID}
+	if existing != nil {
+		dashboard.versions = existing.versions
+	}
+	dashboard.versions = append(dashboard.versions, &models.DashboardVersionMeta{
+		ID:            m.nextID(),
+		DashboardID:   id,
+		UID:           uid,
+		Version:       version,
+		ParentVersion: version - 1,
+		RestoredFrom:  restoredFrom,
+		Created:       strfmt.DateTime(time.Now()),
+		CreatedBy:     "admin",
+		Message:       body.Message,
+		Data:          copyMockModel(model),
+	})
+	org.dashboards[uid] = dashboard
+
+	status := "success"
+	url := dashboard.meta().URL
+	writeMockJSON(w, http.StatusOK, models.PostDashboardOKBody{
+		ID:        &id,
+		UID:       &uid,
+		Title:     &title,
+		URL:       &url,
+		Status:    &status,
+		Version:   &version,
+		FolderUID: body.FolderUID,
+	})
+}
+
+func (m *MockGrafana) getDashboard(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	dashboard, ok := org.dashboards[r.PathValue("uid")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+	meta := dashboard.meta()
+	if folder, ok := org.folders[dashboard.folderUID]; ok {
+		meta.FolderID = folder.ID
+		meta.FolderTitle = folder.Title
+		meta.FolderURL = folder.URL
+	}
+	writeMockJSON(w, http.StatusOK, models.DashboardFullWithMeta{Dashboard: dashboard.model, Meta: meta})
+}
+
+func (m *MockGrafana) deleteDashboard(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	dashboard, ok := org.dashboards[r.PathValue("uid")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+	delete(org.dashboards, r.PathValue("uid"))
+
+	id := dashboard.model["id"].(int64)
+	title := dashboard.title()
+	message := fmt.Sprintf("Dashboard %s deleted", title)
+	writeMockJSON(w, http.StatusOK, models.DeleteDashboardByUIDOKBody{ID: &id, Title: &title, Message: &message})
+}
+
+// getDashboardVersions lists the versions of a dashboard from the latest one. It supports the `limit` and `start` parameters.
+func (m *MockGrafana) getDashboardVersions(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	dashboard, ok := org.dashboards[r.PathValue("uid")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 1000
+	}
+	start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+
+	versions := []*models.DashboardVersionMeta{}
+	for i := len(dashboard.versions) - 1 - start; i >= 0 && len(versions) < limit; i-- {
+		version := *dashboard.versions[i]
+		version.Data = nil
+		versions = append(versions, &version)
+	}
+	writeMockJSON(w, http.StatusOK, versions)
+}
+
+func (m *MockGrafana) getDashboardVersion(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	dashboard, ok := org.dashboards[r.PathValue("uid")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+	version, ok := pathID(w, r, "version")
+	if !ok {
+		return
+	}
+	if meta := dashboard.version(version); meta != nil {
+		writeMockJSON(w, http.StatusOK, meta)
+		return
+	}
+	writeMockError(w, http.StatusNotFound, "Dashboard version not found")
+}
+
+// restoreDashboardVersion saves the model of a previous version as a new version of the dashboard
+func (m *MockGrafana) restoreDashboardVersion(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	dashboard, ok := org.dashboards[r.PathValue("uid")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "Dashboard not found")
+		return
+	}
+	var body models.RestoreDashboardVersionCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	version := dashboard.version(body.Version)
+	if version == nil {
+		writeMockError(w, http.StatusNotFound, "Dashboard version not found")
+		return
+	}
+
+	model := copyMockModel(version.Data.(map[string]interface{}))
+	model["version"] = dashboard.model["version"]
+	m.saveDashboard(w, org, models.SaveDashboardCommand{
+		Dashboard: model,
+		FolderUID: dashboard.folderUID,
+		Message:   fmt.Sprintf("Restored from version %d", version.Version),
+		Overwrite: true,
+	}, version.Version)
+}
+
+// version returns the given version of the dashboard, or nil if it doesn't exist
+func (d *mockDashboard) version(version int64) *models.DashboardVersionMeta {
+	for _, meta := range d.versions {
+		if meta.Version == version {
+			return meta
+		}
+	}
+	return nil
+}
+
+// copyMockModel returns a deep copy of a dashboard model, so that versions aren't changed by later updates
+func copyMockModel(model map[string]interface{}) map[string]interface{} {
+	encoded, _ := json.Marshal(model)
+	var copied map[string]interface{}
+	_ = json.Unmarshal(encoded, &copied)
+	return copied
+}
+
+// search supports the `type`, `query`, `folderUIDs` and `dashboardUIDs` filters
+func (m *MockGrafana) search(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	params := r.URL.Query()
+	hitType := params.Get("type")
+	query := strings.ToLower(params.Get("query"))
+	folderUIDs := params["folderUIDs"]
+	dashboardUIDs := params["dashboardUIDs"]
+	matches := func(uid, title, folderUID string) bool {
+		if query != "" && !strings.Contains(strings.ToLower(title), query) {
+			return false
+		}
+		if len(folderUIDs) > 0 && !slices.Contains(folderUIDs, folderUID) {
+			return false
+		}
+		if len(dashboardUIDs) > 0 && !slices.Contains(dashboardUIDs, uid) {
+			return false
+		}
+		return true
+	}
+
+	hits := models.HitList{}
+	if hitType == "" || hitType == "dash-folder" {
+		for _, folder := range org.folders {
+			if matches(folder.UID, folder.Title, folder.ParentUID) {
+				hits = append(hits, &models.Hit{
+					ID:        folder.ID,
+					UID:       folder.UID,
+					Title:     folder.Title,
+					Type:      "dash-folder",
+					URL:       folder.URL,
+					FolderUID: folder.ParentUID,
+					Tags:      []string{},
+				})
+			}
+		}
+	}
+	if hitType == "" || hitType == "dash-db" {
+		for uid, dashboard := range org.dashboards {
+			if !matches(uid, dashboard.title(), dashboard.folderUID) {
+				continue
+			}
+			hit := &models.Hit{
+				ID:        dashboard.model["id"].(int64),
+				UID:       uid,
+				Title:     dashboard.title(),
+				Type:      "dash-db",
+				URL:       dashboard.meta().URL,
+				FolderUID: dashboard.folderUID,
+				Tags:      []string{},
+			}
+			if folder, ok := org.folders[dashboard.folderUID]; ok {
+				hit.FolderID = folder.ID
+				hit.FolderTitle = folder.Title
+				hit.FolderURL = folder.URL
+			}
+			hits = append(hits, hit)
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].Title < hits[j].Title })
+	writeMockJSON(w, http.StatusOK, hits)
+}
@@ -0,0 +1,220 @@
+package testutils
+
+import (
+	"net/http"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+type mockDataSource struct {
+	models.DataSource
+	secureJSONData map[string]string
+}
+
+func (d *mockDataSource) response() *models.DataSource {
+	dataSource := d.DataSource
+	dataSource.SecureJSONFields = map[string]bool{}
+	for k := range d.secureJSONData {
+		dataSource.SecureJSONFields[k] = true
+	}
+	return &dataSource
+}
+
+func (m *MockGrafana) registerDataSourceRoutes(handle func(string, mockHandlerFunc)) {
+	handle("GET /api/datasources", m.getDataSources)
+	handle("POST /api/datasources", m.addDataSource)
+	handle("GET /api/datasources/{id}", m.getDataSourceByID)
+	handle("GET /api/datasources/uid/{uid}", m.getDataSourceByUID)
+	handle("GET /api/datasources/name/{name}", m.getDataSourceByName)
+	handle("PUT /api/datasources/uid/{uid}", m.updateDataSource)
+	handle("DELETE /api/datasources/uid/{uid}", m.deleteDataSource)
+}
+
+func (m *MockGrafana) getDataSources(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	dataSources := []*models.DataSourceListItemDTO{}
+	for _, uid := range sortedKeys(org.dataSources) {
+		d := org.dataSources[uid]
+		dataSources = append(dataSources, &models.DataSourceListItemDTO{
+			ID:        d.ID,
+			UID:       d.UID,
+			OrgID:     d.OrgID,
+			Name:      d.Name,
+			Type:      d.Type,
+			Access:    d.Access,
+			URL:       d.URL,
+			User:      d.User,
+			Database:  d.Database,
+			BasicAuth: d.BasicAuth,
+			IsDefault: d.IsDefault,
+			JSONData:  d.JSONData,
+			ReadOnly:  d.ReadOnly,
+		})
+	}
+	writeMockJSON(w, http.StatusOK, dataSources)
+}
+
+func (m *MockGrafana) addDataSource(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body models.AddDataSourceCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Name == "" || body.Type == "" {
+		writeMockError(w, http.StatusBadRequest, "the data source name and type are required")
+		return
+	}
+	for _, d := range org.dataSources {
+		if d.Name == body.Name || d.UID == body.UID {
+			writeMockError(w, http.StatusConflict, "data source with the same name or uid already exists")
+			return
+		}
+	}
+
+	id := m.nextID()
+	if body.UID == "" {
+		body.UID = m.uid(id)
+	}
+	if body.Access == "" {
+		body.Access = "proxy"
+	}
+	dataSource := &mockDataSource{
+		DataSource: models.DataSource{
+			ID:              id,
+			UID:             body.UID,
+			OrgID:           org.id,
+			Name:            body.Name,
+			Type:            body.Type,
+			Access:          body.Access,
+			URL:             body.URL,
+			User:            body.User,
+			Database:        body.Database,
+			BasicAuth:       body.BasicAuth,
+			BasicAuthUser:   body.BasicAuthUser,
+			WithCredentials: body.WithCredentials,
+			JSONData:        mockJSONData(body.JSONData),
+			Version:         1,
+		},
+		secureJSONData: body.SecureJSONData,
+	}
+	org.dataSources[dataSource.UID] = dataSource
+	org.setDefaultDataSource(dataSource, body.IsDefault)
+
+	message := "Datasource added"
+	writeMockJSON(w, http.StatusOK, models.AddDataSourceOKBody{
+		ID:         &dataSource.ID,
+		Name:       &dataSource.Name,
+		Message:    &message,
+		Datasource: dataSource.response(),
+	})
+}
+
+func (m *MockGrafana) getDataSourceByID(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	for _, d := range org.dataSources {
+		if d.ID == id {
+			writeMockJSON(w, http.StatusOK, d.response())
+			return
+		}
+	}
+	writeMockError(w, http.StatusNotFound, "Data source not found")
+}
+
+func (m *MockGrafana) getDataSourceByUID(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	d, ok := org.dataSources[r.PathValue("uid")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "Data source not found")
+		return
+	}
+	writeMockJSON(w, http.StatusOK, d.response())
+}
+
+func (m *MockGrafana) getDataSourceByName(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	for _, d := range org.dataSources {
+		if d.Name == r.PathValue("name") {
+			writeMockJSON(w, http.StatusOK, d.response())
+			return
+		}
+	}
+	writeMockError(w, http.StatusNotFound, "Data source not found")
+}
+
+func (m *MockGrafana) updateDataSource(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	dataSource, ok := org.dataSources[r.PathValue("uid")]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "Data source not found")
+		return
+	}
+	var body models.UpdateDataSourceCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	for _, d := range org.dataSources {
+		if d != dataSource && d.Name == body.Name {
+			writeMockError(w, http.StatusConflict, "data source with the same name already exists")
+			return
+		}
+	}
+	if body.Access == "" {
+		body.Access = dataSource.Access
+	}
+
+	dataSource.Name = body.Name
+	dataSource.Type = body.Type
+	dataSource.Access = body.Access
+	dataSource.URL = body.URL
+	dataSource.User = body.User
+	dataSource.Database = body.Database
+	dataSource.BasicAuth = body.BasicAuth
+	dataSource.BasicAuthUser = body.BasicAuthUser
+	dataSource.WithCredentials = body.WithCredentials
+	dataSource.JSONData = mockJSONData(body.JSONData)
+	dataSource.Version++
+	// Secure fields that aren't sent are kept
+	if dataSource.secureJSONData == nil {
+		dataSource.secureJSONData = map[string]string{}
+	}
+	for k, v := range body.SecureJSONData {
+		dataSource.secureJSONData[k] = v
+	}
+	org.setDefaultDataSource(dataSource, body.IsDefault)
+
+	message := "Datasource updated"
+	writeMockJSON(w, http.StatusOK, models.UpdateDataSourceByUIDOKBody{
+		ID:         &dataSource.ID,
+		Name:       &dataSource.Name,
+		Message:    &message,
+		Datasource: dataSource.response(),
+	})
+}
+
+func (m *MockGrafana) deleteDataSource(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	if _, ok := org.dataSources[r.PathValue("uid")]; !ok {
+		writeMockError(w, http.StatusNotFound, "Data source not found")
+		return
+	}
+	delete(org.dataSources, r.PathValue("uid"))
+	writeMockMessage(w, http.StatusOK, "Data source deleted")
+}
+
+// setDefaultDataSource updates the default data source. There is only one default data source per org.
+func (o *mockOrg) setDefaultDataSource(dataSource *mockDataSource, isDefault bool) {
+	dataSource.IsDefault = isDefault
+	if !isDefault {
+		return
+	}
+	for _, d := range o.dataSources {
+		if d != dataSource {
+			d.IsDefault = false
+		}
+	}
+}
+
+// mockJSONData returns the JSON data of a data source. Grafana always returns an object.
+func mockJSONData(jsonData models.JSON) models.JSON {
+	if jsonData == nil {
+		return map[string]interface{}{}
+	}
+	return jsonData
+}
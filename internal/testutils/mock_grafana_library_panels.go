@@ -0,0 +1,184 @@
+package testutils
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+func (m *MockGrafana) registerLibraryPanelRoutes(handle func(string, mockHandlerFunc)) {
+	handle("POST /api/library-elements", m.createLibraryPanel)
+	handle("GET /api/library-elements/{uid}", m.getLibraryPanel)
+	handle("GET /api/library-elements/{uid}/connections/", m.getLibraryPanelConnections)
+	handle("PATCH /api/library-elements/{uid}", m.updateLibraryPanel)
+	handle("DELETE /api/library-elements/{uid}", m.deleteLibraryPanel)
+}
+
+func (m *MockGrafana) createLibraryPanel(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body models.CreateLibraryElementCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeMockError(w, http.StatusBadRequest, "library element name cannot be empty")
+		return
+	}
+	if body.UID != "" && org.libraryPanels[body.UID] != nil {
+		writeMockError(w, http.StatusBadRequest, "library element with that uid already exists")
+		return
+	}
+	if body.FolderUID != "" && org.folders[body.FolderUID] == nil {
+		writeMockError(w, http.StatusNotFound, "folder not found")
+		return
+	}
+
+	id := m.nextID()
+	if body.UID == "" {
+		body.UID = m.uid(id)
+	}
+	now := strfmt.DateTime(time.Now())
+	panel := &models.LibraryElementDTO{
+		ID:        id,
+		UID:       body.UID,
+		OrgID:     org.id,
+		FolderUID: body.FolderUID,
+		Kind:      1,
+		Name:      body.Name,
+		Version:   1,
+		Meta:      &models.LibraryElementDTOMeta{Created: now},
+	}
+	setMockLibraryPanelModel(panel, body.Model)
+	org.libraryPanels[panel.UID] = panel
+	writeMockJSON(w, http.StatusOK, org.libraryPanelResponse(panel))
+}
+
+func (m *MockGrafana) getLibraryPanel(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	panel := org.libraryPanels[r.PathValue("uid")]
+	if panel == nil {
+		writeMockError(w, http.StatusNotFound, "library element could not be found")
+		return
+	}
+	writeMockJSON(w, http.StatusOK, org.libraryPanelResponse(panel))
+}
+
+func (m *MockGrafana) getLibraryPanelConnections(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	panel := org.libraryPanels[r.PathValue("uid")]
+	if panel == nil {
+		writeMockError(w, http.StatusNotFound, "library element could not be found")
+		return
+	}
+	connections := org.libraryPanelConnections(panel)
+	for _, uid := range m.StaleLibraryPanelConnections[panel.UID] {
+		connections = append(connections, &models.LibraryElementConnectionDTO{
+			ID:            int64(len(connections) + 1),
+			Kind:          1,
+			ElementID:     panel.ID,
+			ConnectionUID: uid,
+		})
+	}
+	writeMockJSON(w, http.StatusOK, models.LibraryElementConnectionsResponse{Result: connections})
+}
+
+func (m *MockGrafana) updateLibraryPanel(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	panel := org.libraryPanels[r.PathValue("uid")]
+	if panel == nil {
+		writeMockError(w, http.StatusNotFound, "library element could not be found")
+		return
+	}
+	var body models.PatchLibraryElementCommand
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Version != panel.Version {
+		writeMockError(w, http.StatusPreconditionFailed, "the library element has been changed by someone else")
+		return
+	}
+	if body.FolderUID != "" && org.folders[body.FolderUID] == nil {
+		writeMockError(w, http.StatusNotFound, "folder not found")
+		return
+	}
+
+	if body.Name != "" {
+		panel.Name = body.Name
+	}
+	if body.Model != nil {
+		setMockLibraryPanelModel(panel, body.Model)
+	}
+	panel.FolderUID = body.FolderUID
+	panel.Version++
+	panel.Meta.Updated = strfmt.DateTime(time.Now())
+	writeMockJSON(w, http.StatusOK, org.libraryPanelResponse(panel))
+}
+
+func (m *MockGrafana) deleteLibraryPanel(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	panel := org.libraryPanels[r.PathValue("uid")]
+	if panel == nil {
+		writeMockError(w, http.StatusNotFound, "library element could not be found")
+		return
+	}
+	if len(org.libraryPanelConnections(panel)) > 0 {
+		writeMockError(w, http.StatusForbidden, "the library element has connections")
+		return
+	}
+	delete(org.libraryPanels, panel.UID)
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"message": "Library element deleted", "id": panel.ID})
+}
+
+// libraryPanelResponse returns a copy of a library panel with its computed metadata
+func (o *mockOrg) libraryPanelResponse(panel *models.LibraryElementDTO) *models.LibraryElementResponse {
+	response := *panel
+	meta := *panel.Meta
+	meta.FolderUID = panel.FolderUID
+	meta.FolderName = "General"
+	if folder := o.folders[panel.FolderUID]; folder != nil {
+		meta.FolderName = folder.Title
+	}
+	meta.ConnectedDashboards = int64(len(o.libraryPanelConnections(panel)))
+	response.Meta = &meta
+	return &models.LibraryElementResponse{Result: &response}
+}
+
+// libraryPanelConnections returns the dashboards with a panel that uses the library panel, including the panels of collapsed rows
+func (o *mockOrg) libraryPanelConnections(panel *models.LibraryElementDTO) []*models.LibraryElementConnectionDTO {
+	connections := []*models.LibraryElementConnectionDTO{}
+	for _, uid := range sortedKeys(o.dashboards) {
+		dashboard := o.dashboards[uid]
+		if !mockPanelsUseLibraryPanel(dashboard.model["panels"], panel.UID) {
+			continue
+		}
+		connections = append(connections, &models.LibraryElementConnectionDTO{
+			ID:            int64(len(connections) + 1),
+			Kind:          1,
+			ElementID:     panel.ID,
+			ConnectionID:  dashboard.model["id"].(int64),
+			ConnectionUID: uid,
+		})
+	}
+	return connections
+}
+
+func mockPanelsUseLibraryPanel(panels interface{}, uid string) bool {
+	list, _ := panels.([]interface{})
+	for _, p := range list {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if libraryPanel, ok := panel["libraryPanel"].(map[string]interface{}); ok && libraryPanel["uid"] == uid {
+			return true
+		}
+		if mockPanelsUseLibraryPanel(panel["panels"], uid) {
+			return true
+		}
+	}
+	return false
+}
+
+func setMockLibraryPanelModel(panel *models.LibraryElementDTO, model interface{}) {
+	panel.Model = model
+	modelMap, _ := model.(map[string]interface{})
+	panel.Type, _ = modelMap["type"].(string)
+	panel.Description, _ = modelMap["description"].(string)
+}
@@ -0,0 +1,155 @@
+package testutils
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+// mockExternalSnapshotURL is the URL of the external snapshot server of the mock server
+const mockExternalSnapshotURL = "https://snapshots.example.com"
+
+type mockSnapshot struct {
+	dto       models.DashboardSnapshotDTO
+	deleteKey string
+	dashboard map[string]interface{}
+}
+
+func (m *MockGrafana) registerSnapshotRoutes(handle func(string, mockHandlerFunc)) {
+	handle("POST /api/snapshots", m.createSnapshot)
+	handle("GET /api/snapshots/{key}", m.getSnapshot)
+	handle("DELETE /api/snapshots/{key}", m.deleteSnapshot)
+	handle("GET /api/dashboard/snapshots", m.searchSnapshots)
+}
+
+// createSnapshot stores the snapshot like Grafana does, the dashboard is sent as is rather than wrapped like the OpenAPI client model
+func (m *MockGrafana) createSnapshot(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	var body struct {
+		Dashboard map[string]interface{} `json:"dashboard"`
+		Name      string                 `json:"name"`
+		Expires   int64                  `json:"expires"`
+		External  bool                   `json:"external"`
+		Key       string                 `json:"key"`
+		DeleteKey string                 `json:"deleteKey"`
+	}
+	if !readMockJSON(w, r, &body) {
+		return
+	}
+	if body.Dashboard == nil {
+		writeMockError(w, http.StatusBadRequest, "dashboard is required")
+		return
+	}
+	if body.Key != "" && org.snapshots[body.Key] != nil {
+		writeMockError(w, http.StatusInternalServerError, "failed to create snapshot: the key already exists")
+		return
+	}
+
+	id := m.nextID()
+	if body.Key == "" {
+		body.Key = m.uid(id)
+	}
+	if body.DeleteKey == "" {
+		body.DeleteKey = m.uid(m.nextID())
+	}
+	if body.Name == "" {
+		body.Name = "Unnamed snapshot"
+	}
+	now := time.Now()
+	// Snapshots that never expire expire in 50 years
+	expires := now.AddDate(50, 0, 0)
+	if body.Expires > 0 {
+		expires = now.Add(time.Duration(body.Expires) * time.Second)
+	}
+
+	url := m.Server.URL + "/dashboard/snapshot/" + body.Key
+	deleteURL := m.Server.URL + "/api/snapshots-delete/" + body.DeleteKey
+	snapshot := &mockSnapshot{
+		dto: models.DashboardSnapshotDTO{
+			Key:      body.Key,
+			Name:     body.Name,
+			External: body.External,
+			Created:  strfmt.DateTime(now),
+			Updated:  strfmt.DateTime(now),
+			Expires:  strfmt.DateTime(expires),
+		},
+		deleteKey: body.DeleteKey,
+		dashboard: body.Dashboard,
+	}
+	if body.External {
+		url = mockExternalSnapshotURL + "/dashboard/snapshot/" + body.Key
+		deleteURL = mockExternalSnapshotURL + "/api/snapshots-delete/" + body.DeleteKey
+		snapshot.dto.ExternalURL = url
+	}
+	org.snapshots[body.Key] = snapshot
+
+	writeMockJSON(w, http.StatusOK, models.CreateDashboardSnapshotOKBody{
+		ID:        id,
+		Key:       body.Key,
+		DeleteKey: body.DeleteKey,
+		URL:       url,
+		DeleteURL: deleteURL,
+	})
+}
+
+func (m *MockGrafana) getSnapshot(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	snapshot := org.snapshot(r.PathValue("key"))
+	if snapshot == nil {
+		writeMockError(w, http.StatusNotFound, "Dashboard snapshot not found")
+		return
+	}
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{
+		"dashboard": snapshot.dashboard,
+		"meta": map[string]interface{}{
+			"isSnapshot": true,
+			"type":       "snapshot",
+			"created":    snapshot.dto.Created,
+			"expires":    snapshot.dto.Expires,
+		},
+	})
+}
+
+func (m *MockGrafana) deleteSnapshot(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	snapshot := org.snapshot(r.PathValue("key"))
+	if snapshot == nil {
+		writeMockError(w, http.StatusNotFound, "Dashboard snapshot not found")
+		return
+	}
+	delete(org.snapshots, snapshot.dto.Key)
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"message": "Snapshot deleted. It might take an hour before it's cleared from any CDN caches."})
+}
+
+// searchSnapshots lists the snapshots by name, filtered by the `query` substring of their name
+func (m *MockGrafana) searchSnapshots(w http.ResponseWriter, r *http.Request, org *mockOrg) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	snapshots := []*models.DashboardSnapshotDTO{}
+	for _, key := range sortedKeys(org.snapshots) {
+		if snapshot := org.snapshot(key); snapshot != nil && strings.Contains(strings.ToLower(snapshot.dto.Name), query) {
+			dto := snapshot.dto
+			snapshots = append(snapshots, &dto)
+		}
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].Name < snapshots[j].Name })
+	if len(snapshots) > limit {
+		snapshots = snapshots[:limit]
+	}
+	writeMockJSON(w, http.StatusOK, snapshots)
+}
+
+// snapshot returns a snapshot by key, or nil if it doesn't exist or has expired
+func (o *mockOrg) snapshot(key string) *mockSnapshot {
+	snapshot := o.snapshots[key]
+	if snapshot == nil || time.Time(snapshot.dto.Expires).Before(time.Now()) {
+		return nil
+	}
+	return snapshot
+}
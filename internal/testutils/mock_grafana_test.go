@@ -0,0 +1,315 @@
+package testutils
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/client/folders"
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
+	"github.com/grafana/grafana-openapi-client-go/client/search"
+	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
+	"github.com/grafana/grafana-openapi-client-go/client/snapshots"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+)
+
+func TestMockGrafana_Environment(t *testing.T) {
+	m := NewMockGrafana(t)
+
+	if got := os.Getenv("GRAFANA_URL"); got != m.Server.URL {
+		t.Errorf("expected GRAFANA_URL to be %q, got %q", m.Server.URL, got)
+	}
+	if got := os.Getenv("GRAFANA_AUTH"); got != MockGrafanaAuth {
+		t.Errorf("expected GRAFANA_AUTH to be %q, got %q", MockGrafanaAuth, got)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, m.Server.URL+"/api/org", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected unauthenticated requests to fail with 401, got %d", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, m.Server.URL+"/api/unknown", nil)
+	req.SetBasicAuth("admin", "admin")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected unsupported routes to fail with 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestMockGrafana_FoldersAndDashboards(t *testing.T) {
+	client := NewMockGrafana(t).Client()
+
+	folderResp, err := client.Folders.CreateFolder(&models.CreateFolderCommand{Title: "Test Folder"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	folder := folderResp.Payload
+	if folder.UID == "" || folder.Version != 1 {
+		t.Fatalf("expected a generated UID and version 1, got %+v", folder)
+	}
+	if _, err := client.Folders.CreateFolder(&models.CreateFolderCommand{Title: "Test Folder"}); err == nil {
+		t.Error("expected an error when creating a folder with the same title")
+	}
+
+	dashboard := models.SaveDashboardCommand{
+		FolderUID: folder.UID,
+		Dashboard: map[string]interface{}{"title": "Test Dashboard", "uid": "test-dashboard"},
+	}
+	dashboardResp, err := client.Dashboards.PostDashboard(&dashboard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *dashboardResp.Payload.UID != "test-dashboard" || *dashboardResp.Payload.Version != 1 {
+		t.Errorf("unexpected dashboard response: %+v", dashboardResp.Payload)
+	}
+	if _, err := client.Dashboards.PostDashboard(&dashboard); err == nil {
+		t.Error("expected an error when saving an outdated dashboard version without overwrite")
+	}
+
+	getResp, err := client.Dashboards.GetDashboardByUID("test-dashboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getResp.Payload.Meta.FolderUID != folder.UID {
+		t.Errorf("expected the dashboard to be in folder %q, got %q", folder.UID, getResp.Payload.Meta.FolderUID)
+	}
+
+	dashboardType := "dash-db"
+	searchResp, err := client.Search.Search(search.NewSearchParams().WithType(&dashboardType))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(searchResp.Payload) != 1 || searchResp.Payload[0].UID != "test-dashboard" {
+		t.Errorf("expected the search to return the dashboard, got %+v", searchResp.Payload)
+	}
+
+	if _, err := client.Folders.DeleteFolder(folders.NewDeleteFolderParams().WithFolderUID(folder.UID)); err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Dashboards.GetDashboardByUID("test-dashboard")
+	if !common.IsNotFoundError(err) {
+		t.Errorf("expected the dashboard to be deleted with its folder, got %v", err)
+	}
+}
+
+func TestMockGrafana_DataSources(t *testing.T) {
+	client := NewMockGrafana(t).Client()
+
+	_, err := client.Datasources.AddDataSource(&models.AddDataSourceCommand{
+		Name:           "prometheus",
+		Type:           "prometheus",
+		UID:            "prometheus-uid",
+		SecureJSONData: map[string]string{"httpHeaderValue1": "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Datasources.AddDataSource(&models.AddDataSourceCommand{Name: "prometheus", Type: "prometheus"}); err == nil {
+		t.Error("expected an error when creating a data source with the same name")
+	}
+
+	resp, err := client.Datasources.GetDataSourceByUID("prometheus-uid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataSource := resp.Payload
+	if dataSource.Access != "proxy" {
+		t.Errorf("expected the access to default to proxy, got %q", dataSource.Access)
+	}
+	if !dataSource.SecureJSONFields["httpHeaderValue1"] {
+		t.Errorf("expected the secure field to be reported as set, got %v", dataSource.SecureJSONFields)
+	}
+}
+
+func TestMockGrafana_LibraryPanels(t *testing.T) {
+	mock := NewMockGrafana(t)
+	client := mock.Client()
+
+	_, err := client.LibraryElements.CreateLibraryElement(&models.CreateLibraryElementCommand{
+		Name:  "Library Panel",
+		UID:   "library-panel",
+		Kind:  1,
+		Model: map[string]interface{}{"type": "stat", "title": "Library Panel"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Dashboards.PostDashboard(&models.SaveDashboardCommand{Dashboard: map[string]interface{}{
+		"uid":   "dashboard",
+		"title": "Dashboard",
+		"panels": []interface{}{
+			map[string]interface{}{"type": "row", "collapsed": true, "panels": []interface{}{
+				map[string]interface{}{"libraryPanel": map[string]interface{}{"uid": "library-panel"}},
+			}},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.LibraryElements.GetLibraryElementByUID("library-panel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if panel := resp.Payload.Result; panel.Type != "stat" || panel.Meta.ConnectedDashboards != 1 {
+		t.Errorf("expected a stat panel connected to 1 dashboard, got %+v", panel)
+	}
+	if _, err := client.LibraryElements.DeleteLibraryElementByUID("library-panel"); err == nil {
+		t.Error("expected an error when deleting a connected library panel")
+	}
+
+	if _, err := client.Dashboards.DeleteDashboardByUID("dashboard"); err != nil {
+		t.Fatal(err)
+	}
+	// Stale connections are listed, but don't prevent the deletion
+	mock.StaleLibraryPanelConnections = map[string][]string{"library-panel": {"deleted"}}
+	connections, err := client.LibraryElements.GetLibraryElementConnections("library-panel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connections.Payload.Result) != 1 || connections.Payload.Result[0].ConnectionUID != "deleted" {
+		t.Errorf("expected a stale connection to the deleted dashboard, got %+v", connections.Payload.Result)
+	}
+	if _, err := client.LibraryElements.DeleteLibraryElementByUID("library-panel"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.LibraryElements.GetLibraryElementByUID("library-panel"); !common.IsNotFoundError(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestMockGrafana_NotificationPolicy(t *testing.T) {
+	client := NewMockGrafana(t).Client()
+
+	resp, err := client.Provisioning.GetPolicyTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Payload.Receiver != "grafana-default-email" {
+		t.Errorf("expected the default policy to use the default contact point, got %q", resp.Payload.Receiver)
+	}
+
+	params := provisioning.NewPutPolicyTreeParams().WithBody(&models.Route{Receiver: "missing"})
+	if _, err := client.Provisioning.PutPolicyTree(params); err == nil {
+		t.Error("expected an error when referencing a contact point that doesn't exist")
+	}
+
+	params = provisioning.NewPutPolicyTreeParams().WithBody(&models.Route{Receiver: "grafana-default-email", GroupBy: []string{"..."}})
+	if _, err := client.Provisioning.PutPolicyTree(params); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = client.Provisioning.GetPolicyTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Payload.Provenance != "api" {
+		t.Errorf("expected the policy to be provisioned through the API, got provenance %q", resp.Payload.Provenance)
+	}
+
+	if _, err := client.Provisioning.ResetPolicyTree(); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = client.Provisioning.GetPolicyTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Payload.GroupBy) != 2 || resp.Payload.Provenance != "" {
+		t.Errorf("expected the policy to be reset, got %+v", resp.Payload)
+	}
+}
+
+func TestMockGrafana_TeamsAndServiceAccounts(t *testing.T) {
+	client := NewMockGrafana(t).Client()
+
+	teamResp, err := client.Teams.CreateTeam(&models.CreateTeamCommand{Name: "Test Team"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	teamID := teamResp.Payload.TeamID
+	if _, err := client.Teams.AddTeamMember(strconv.FormatInt(teamID, 10), &models.AddTeamMemberCommand{UserID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	membersResp, err := client.Teams.GetTeamMembers(strconv.FormatInt(teamID, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(membersResp.Payload) != 1 || membersResp.Payload[0].Email != "admin@localhost" {
+		t.Errorf("expected the admin user to be a member of the team, got %+v", membersResp.Payload)
+	}
+
+	saResp, err := client.ServiceAccounts.CreateServiceAccount(
+		service_accounts.NewCreateServiceAccountParams().WithBody(&models.CreateServiceAccountForm{Name: "Test SA", Role: "Editor"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saID := saResp.Payload.ID
+	tokenResp, err := client.ServiceAccounts.CreateToken(
+		service_accounts.NewCreateTokenParams().WithServiceAccountID(saID).WithBody(&models.AddServiceAccountTokenCommand{Name: "token"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokenResp.Payload.Key == "" {
+		t.Error("expected the token key to be returned on creation")
+	}
+	tokensResp, err := client.ServiceAccounts.ListTokens(saID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokensResp.Payload) != 1 || tokensResp.Payload[0].Name != "token" {
+		t.Errorf("expected the token to be listed, got %+v", tokensResp.Payload)
+	}
+}
+
+func TestMockGrafana_Snapshots(t *testing.T) {
+	mock := NewMockGrafana(t)
+	client := mock.Client()
+
+	var created models.CreateDashboardSnapshotOKBody
+	body := map[string]interface{}{"dashboard": map[string]interface{}{"title": "Incident"}, "name": "Postmortem", "expires": 3600}
+	if err := common.SubmitGrafanaJSON(context.Background(), client, nil, http.MethodPost, "/snapshots", body, &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Key == "" || created.DeleteKey == "" || created.URL != mock.Server.URL+"/dashboard/snapshot/"+created.Key {
+		t.Errorf("unexpected snapshot: %+v", created)
+	}
+
+	var snapshot struct {
+		Dashboard map[string]interface{} `json:"dashboard"`
+	}
+	if err := common.SubmitGrafanaJSON(context.Background(), client, nil, http.MethodGet, "/snapshots/"+created.Key, nil, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Dashboard["title"] != "Incident" {
+		t.Errorf("expected the dashboard to be returned as sent, got %v", snapshot.Dashboard)
+	}
+
+	query := "post"
+	resp, err := client.Snapshots.SearchDashboardSnapshots(snapshots.NewSearchDashboardSnapshotsParams().WithQuery(&query))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Payload) != 1 || resp.Payload[0].Name != "Postmortem" {
+		t.Errorf("expected to find the snapshot, got %+v", resp.Payload)
+	}
+
+	if _, err := client.Snapshots.DeleteDashboardSnapshot(created.Key); err != nil {
+		t.Fatal(err)
+	}
+	if err := common.SubmitGrafanaJSON(context.Background(), client, nil, http.MethodGet, "/snapshots/"+created.Key, nil, nil); !common.IsNotFoundError(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
@@ -30,31 +30,7 @@ var (
 				return nil, err
 			}
 
-			// Get the provider schema and create a provider configuration
-			// The config is empty because we'll use environment variables to configure the provider
-			schemaResp, err := server.GetProviderSchema(ctx, nil)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get provider schema: %v", err)
-			}
-			fields := map[string]tftypes.Value{}
-			for _, v := range schemaResp.Provider.Block.Attributes {
-				fields[v.Name] = tftypes.NewValue(v.Type, nil)
-			}
-			testValue := tftypes.NewValue(schemaResp.Provider.ValueType(), fields)
-			testDynamicValue, err := tfprotov5.NewDynamicValue(schemaResp.Provider.ValueType(), testValue)
-			if err != nil {
-				return nil, err
-			}
-
-			// Configure the provider
-			configureResp, err := server.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{Config: &testDynamicValue})
-			if err != nil || len(configureResp.Diagnostics) > 0 {
-				if err == nil {
-					err = fmt.Errorf("provider configuration failed: %v", configureResp.Diagnostics)
-				}
-				return nil, fmt.Errorf("failed to configure provider: %v", err)
-			}
-			return server, nil
+			return configureProviderServer(ctx, server)
 		},
 	}
 
@@ -68,6 +44,35 @@ var (
 	Provider *schema.Provider
 )
 
+// configureProviderServer configures the provider server with an empty configuration, so that environment variables are used
+func configureProviderServer(ctx context.Context, server tfprotov5.ProviderServer) (tfprotov5.ProviderServer, error) {
+	// Get the provider schema and create a provider configuration
+	// The config is empty because we'll use environment variables to configure the provider
+	schemaResp, err := server.GetProviderSchema(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider schema: %v", err)
+	}
+	fields := map[string]tftypes.Value{}
+	for _, v := range schemaResp.Provider.Block.Attributes {
+		fields[v.Name] = tftypes.NewValue(v.Type, nil)
+	}
+	testValue := tftypes.NewValue(schemaResp.Provider.ValueType(), fields)
+	testDynamicValue, err := tfprotov5.NewDynamicValue(schemaResp.Provider.ValueType(), testValue)
+	if err != nil {
+		return nil, err
+	}
+
+	// Configure the provider
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: &testDynamicValue})
+	if err != nil || len(configureResp.Diagnostics) > 0 {
+		if err == nil {
+			err = fmt.Errorf("provider configuration failed: %v", configureResp.Diagnostics)
+		}
+		return nil, fmt.Errorf("failed to configure provider: %v", err)
+	}
+	return server, nil
+}
+
 func init() {
 	Provider = provider.Provider("testacc")
 
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -8,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,37 +22,48 @@ import (
 
 	"github.com/go-openapi/strfmt"
 	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
-	"github.com/grafana/terraform-provider-grafana/v2/internal/resources/grafana"
+	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/http/httpproxy"
 )
 
-func CreateClients(providerConfig ProviderConfig) (*common.Client, error) {
+// CreateClients creates the API clients of the provider. The context is used for the API calls made at configuration time (ex: to discover API URLs).
+func CreateClients(ctx context.Context, providerConfig ProviderConfig) (*common.Client, error) {
 	var err error
 	c := &common.Client{}
+	httpTransport, err := createTransport(providerConfig)
+	if err != nil {
+		return nil, err
+	}
+	var transport http.RoundTripper = httpTransport
+	if providerConfig.WrapTransport != nil {
+		transport = providerConfig.WrapTransport(transport)
+	}
 	if !providerConfig.Auth.IsNull() && !providerConfig.URL.IsNull() {
-		if err = createGrafanaAPIClient(c, providerConfig); err != nil {
+		if err = createGrafanaAPIClient(c, providerConfig, transport, httpTransport.TLSClientConfig); err != nil {
 			return nil, err
 		}
-		if err = createMLClient(c, providerConfig); err != nil {
+		if err = createMLClient(c, providerConfig, transport); err != nil {
 			return nil, err
 		}
-		if err = createSLOClient(c, providerConfig); err != nil {
+		if err = createSLOClient(c, providerConfig, transport); err != nil {
 			return nil, err
 		}
 	}
 	if !providerConfig.CloudAccessPolicyToken.IsNull() {
-		if err := createCloudClient(c, providerConfig); err != nil {
+		if err := createCloudClient(c, providerConfig, transport); err != nil {
 			return nil, err
 		}
 	}
 	if !providerConfig.SMAccessToken.IsNull() {
-		c.SMAPI = SMAPI.NewClient(providerConfig.SMURL.ValueString(), providerConfig.SMAccessToken.ValueString(), getRetryClient(providerConfig))
+		smURL := resolveSMURL(ctx, c, providerConfig)
+		c.SMAPI = SMAPI.NewClient(smURL, providerConfig.SMAccessToken.ValueString(), getRetryClient(providerConfig, transport))
 	}
 	if !providerConfig.OncallAccessToken.IsNull() {
 		var onCallClient *onCallAPI.Client
-		onCallClient, err = createOnCallClient(providerConfig)
+		onCallClient, err = createOnCallClient(ResolveOncallURL(ctx, c, providerConfig), providerConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -58,17 +71,14 @@ func CreateClients(providerConfig ProviderConfig) (*common.Client, error) {
 		c.OnCallClient = onCallClient
 	}
 
-	grafana.StoreDashboardSHA256 = providerConfig.StoreDashboardSha256.ValueBool()
+	c.StoreDashboardSHA256 = providerConfig.StoreDashboardSha256.ValueBool()
+	c.ReadOnly = providerConfig.ReadOnly.ValueBool()
 
 	return c, nil
 }
 
-func createGrafanaAPIClient(client *common.Client, providerConfig ProviderConfig) error {
-	tlsClientConfig, err := parseTLSconfig(providerConfig)
-	if err != nil {
-		return err
-	}
-
+func createGrafanaAPIClient(client *common.Client, providerConfig ProviderConfig, transport http.RoundTripper, tlsClientConfig *tls.Config) error {
+	var err error
 	client.GrafanaAPIURL = providerConfig.URL.ValueString()
 	client.GrafanaAPIURLParsed, err = url.Parse(providerConfig.URL.ValueString())
 	if err != nil {
@@ -107,37 +117,51 @@ func createGrafanaAPIClient(client *common.Client, providerConfig ProviderConfig
 	client.GrafanaAPI = goapi.NewHTTPClientWithConfig(strfmt.Default, &cfg)
 	client.GrafanaAPIConfig = &cfg
 
-	return nil
+	// The OpenAPI client always wraps the default transport, swap it for the shared one so that proxy and TLS settings apply.
+	// The TLS config is still set above, since it's what the client falls back to if its transport is rebuilt (ex: by WithOrgID, see common.GrafanaAPIWithOrgID)
+	return common.SetGrafanaAPITransport(client.GrafanaAPI, transport)
 }
 
-func createMLClient(client *common.Client, providerConfig ProviderConfig) error {
+func createMLClient(client *common.Client, providerConfig ProviderConfig, transport http.RoundTripper) error {
 	mlcfg := mlapi.Config{
 		BasicAuth:   client.GrafanaAPIConfig.BasicAuth,
 		BearerToken: client.GrafanaAPIConfig.APIKey,
-		Client:      getRetryClient(providerConfig),
+		Client:      getRetryClient(providerConfig, transport),
 		NumRetries:  client.GrafanaAPIConfig.NumRetries,
 	}
-	mlURL := client.GrafanaAPIURL
-	if !strings.HasSuffix(mlURL, "/") {
-		mlURL += "/"
+	mlURL := providerConfig.MLURL.ValueString()
+	if mlURL == "" {
+		mlURL = client.GrafanaAPIURL
+		if !strings.HasSuffix(mlURL, "/") {
+			mlURL += "/"
+		}
+		mlURL += "api/plugins/grafana-ml-app/resources"
 	}
-	mlURL += "api/plugins/grafana-ml-app/resources"
 	var err error
 	client.MLAPI, err = mlapi.New(mlURL, mlcfg)
 	return err
 }
 
-func createSLOClient(client *common.Client, providerConfig ProviderConfig) error {
+func createSLOClient(client *common.Client, providerConfig ProviderConfig, transport http.RoundTripper) error {
 	sloConfig := slo.NewConfiguration()
 	sloConfig.Host = client.GrafanaAPIURLParsed.Host
 	sloConfig.Scheme = client.GrafanaAPIURLParsed.Scheme
+	if sloURL := providerConfig.SLOURL.ValueString(); sloURL != "" {
+		parsedURL, err := url.Parse(sloURL)
+		if err != nil {
+			return fmt.Errorf("failed to parse SLO url: %v", err.Error())
+		}
+		sloConfig.Host = parsedURL.Host
+		sloConfig.Scheme = parsedURL.Scheme
+		sloConfig.Servers = slo.ServerConfigurations{{URL: strings.TrimSuffix(parsedURL.Path, "/")}}
+	}
 	sloConfig.DefaultHeader["Authorization"] = "Bearer " + providerConfig.Auth.ValueString()
-	sloConfig.HTTPClient = getRetryClient(providerConfig)
+	sloConfig.HTTPClient = getRetryClient(providerConfig, transport)
 	client.SLOClient = slo.NewAPIClient(sloConfig)
 	return nil
 }
 
-func createCloudClient(client *common.Client, providerConfig ProviderConfig) error {
+func createCloudClient(client *common.Client, providerConfig ProviderConfig, transport http.RoundTripper) error {
 	openAPIConfig := gcom.NewConfiguration()
 	parsedURL, err := url.Parse(providerConfig.CloudAPIURL.ValueString())
 	if err != nil {
@@ -145,7 +169,7 @@ func createCloudClient(client *common.Client, providerConfig ProviderConfig) err
 	}
 	openAPIConfig.Host = parsedURL.Host
 	openAPIConfig.Scheme = "https"
-	openAPIConfig.HTTPClient = getRetryClient(providerConfig)
+	openAPIConfig.HTTPClient = getRetryClient(providerConfig, transport)
 	openAPIConfig.DefaultHeader["Authorization"] = "Bearer " + providerConfig.CloudAccessPolicyToken.ValueString()
 	httpHeaders, err := getHTTPHeadersMap(providerConfig)
 	if err != nil {
@@ -159,8 +183,10 @@ func createCloudClient(client *common.Client, providerConfig ProviderConfig) err
 	return nil
 }
 
-func createOnCallClient(providerConfig ProviderConfig) (*onCallAPI.Client, error) {
-	return onCallAPI.New(providerConfig.OncallURL.ValueString(), providerConfig.OncallAccessToken.ValueString())
+// createOnCallClient creates the OnCall client. Unlike the other clients, it can't use the shared transport:
+// its HTTP client isn't configurable, so the TLS and proxy settings of the provider don't apply to it.
+func createOnCallClient(oncallURL string, providerConfig ProviderConfig) (*onCallAPI.Client, error) {
+	return onCallAPI.New(oncallURL, providerConfig.OncallAccessToken.ValueString())
 }
 
 // Sets a custom HTTP Header on all requests coming from the Grafana Terraform Provider to Grafana-Terraform-Provider: true
@@ -244,8 +270,15 @@ func parseTLSconfig(providerConfig ProviderConfig) (*tls.Config, error) {
 		if err != nil {
 			return nil, err
 		}
-		pool := x509.NewCertPool()
-		pool.AppendCertsFromPEM(ca)
+		// The TLS config is shared by all clients, some of which talk to public endpoints (ex: grafana.com)
+		// so the CA bundle is added to the system pool rather than replacing it
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no valid certificates found in the CA bundle")
+		}
 		tlsClientConfig.RootCAs = pool
 	}
 	if tlsKeyFile != "" && tlsCertFile != "" {
@@ -270,8 +303,58 @@ func setToStringArray(set []attr.Value) []string {
 	return result
 }
 
-func getRetryClient(providerConfig ProviderConfig) *http.Client {
+// createTransport creates the HTTP transport shared by the service clients.
+// It carries the TLS settings and, if one is configured, the proxy which is used instead of the standard proxy environment variables.
+func createTransport(providerConfig ProviderConfig) (*http.Transport, error) {
+	tlsClientConfig, err := parseTLSconfig(providerConfig)
+	if err != nil {
+		return nil, err
+	}
+	transport := cleanhttp.DefaultPooledTransport()
+	transport.TLSClientConfig = tlsClientConfig
+
+	proxyURL := providerConfig.ProxyURL.ValueString()
+	if proxyURL == "" {
+		return transport, nil
+	}
+	if err := validateProxyURL(proxyURL); err != nil {
+		return nil, err
+	}
+
+	proxyConfig := httpproxy.Config{
+		HTTPProxy:  proxyURL,
+		HTTPSProxy: proxyURL,
+		NoProxy:    providerConfig.NoProxy.ValueString(),
+	}
+	proxyFunc := proxyConfig.ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	return transport, nil
+}
+
+// proxyURLSchemes are the schemes of the supported proxies
+var proxyURLSchemes = []string{"http", "https", "socks5"}
+
+// validateProxyURL checks the `proxy_url` attribute. It is also checked when the provider is configured, since it may come from an environment variable.
+func validateProxyURL(proxyURL string) error {
+	parsedProxyURL, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse proxy url: %v", err.Error())
+	}
+	if parsedProxyURL.Scheme == "" || parsedProxyURL.Host == "" {
+		return fmt.Errorf("invalid proxy url %q: a scheme and host are required", parsedProxyURL.Redacted())
+	}
+	if !slices.Contains(proxyURLSchemes, parsedProxyURL.Scheme) {
+		return fmt.Errorf("invalid proxy url %q: the scheme must be one of %s", parsedProxyURL.Redacted(), strings.Join(proxyURLSchemes, ", "))
+	}
+	return nil
+}
+
+func getRetryClient(providerConfig ProviderConfig, transport http.RoundTripper) *http.Client {
 	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient.Transport = transport
 	retryClient.RetryMax = int(providerConfig.Retries.ValueInt64())
 	if wait := providerConfig.RetryWait.ValueInt64(); wait > 0 {
 		retryClient.RetryWaitMin = time.Second * time.Duration(wait)
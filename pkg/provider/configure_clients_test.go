@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/resources/grafana"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateClients_orgScopedClients(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		configure func(t *testing.T, cfg *ProviderConfig) *requestLog
+	}{
+		{
+			name: "through a proxy",
+			configure: func(t *testing.T, cfg *ProviderConfig) *requestLog {
+				// The Grafana host doesn't resolve, so the requests only succeed if they go through the proxy
+				proxy, requests := newTestServer(t, false)
+				cfg.URL = types.StringValue("http://grafana.test")
+				cfg.ProxyURL = types.StringValue(proxy.URL)
+				return requests
+			},
+		},
+		{
+			name: "with a custom CA",
+			configure: func(t *testing.T, cfg *ProviderConfig) *requestLog {
+				server, requests := newTestServer(t, true)
+				cfg.URL = types.StringValue(server.URL)
+				cfg.CACert = types.StringValue(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})))
+				return requests
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := ProviderConfig{
+				Auth:    types.StringValue("admin:admin"),
+				Retries: types.Int64Value(0),
+			}
+			requests := tc.configure(t, &cfg)
+			require.NoError(t, cfg.SetDefaults())
+			client, err := CreateClients(context.Background(), cfg)
+			require.NoError(t, err)
+
+			orgClient, _, _ := grafana.OAPIClientFromExistingOrgResource(client, "2:folder")
+			_, err = orgClient.Folders.GetFolderByUID("folder")
+			require.NoError(t, err)
+
+			globalClient, err := grafana.OAPIGlobalClient(client)
+			require.NoError(t, err)
+			_, err = globalClient.Folders.GetFolderByUID("folder")
+			require.NoError(t, err)
+
+			// The org of the provider client is unchanged
+			_, err = client.GrafanaAPI.Folders.GetFolderByUID("folder")
+			require.NoError(t, err)
+
+			var orgs []string
+			for _, request := range requests.get() {
+				orgs = append(orgs, request.Path+" (org "+request.OrgID+")")
+			}
+			assert.Equal(t, []string{"/api/folders/folder (org 2)", "/api/folders/folder (org )", "/api/folders/folder (org 1)"}, orgs)
+		})
+	}
+}
+
+func TestCreateClients_proxy(t *testing.T) {
+	proxy, requests := newTestServer(t, false)
+
+	// The hosts don't resolve, so the requests only succeed if they go through the proxy
+	cfg := ProviderConfig{
+		URL:      types.StringValue("http://grafana.test"),
+		Auth:     types.StringValue("token"),
+		Retries:  types.Int64Value(0),
+		ProxyURL: types.StringValue(proxy.URL),
+		NoProxy:  types.StringValue(".internal.test"),
+		SLOURL:   types.StringValue("http://slo.test/api/plugins/grafana-slo-app/resources"),
+	}
+	require.NoError(t, cfg.SetDefaults())
+	client, err := CreateClients(context.Background(), cfg)
+	require.NoError(t, err)
+
+	_, err = client.GrafanaAPI.Folders.GetFolderByUID("folder")
+	require.NoError(t, err)
+	_, err = client.MLAPI.Job(context.Background(), "job")
+	require.NoError(t, err)
+	_, _, err = client.SLOClient.DefaultAPI.V1SloGet(context.Background()).Execute()
+	require.NoError(t, err)
+
+	// Hosts that match no_proxy are called directly
+	cfg.MLURL = types.StringValue("http://ml.internal.test/api/plugins/grafana-ml-app/resources")
+	client, err = CreateClients(context.Background(), cfg)
+	require.NoError(t, err)
+	_, err = client.MLAPI.Job(context.Background(), "job")
+	require.Error(t, err)
+
+	var urls []string
+	for _, request := range requests.get() {
+		urls = append(urls, request.Host+request.Path)
+	}
+	assert.Equal(t, []string{
+		"grafana.test/api/folders/folder",
+		"grafana.test/api/plugins/grafana-ml-app/resources/manage/api/v1/jobs/job",
+		"slo.test/api/plugins/grafana-slo-app/resources/v1/slo",
+	}, urls)
+}
+
+func TestCreateClients_invalidProxyURL(t *testing.T) {
+	for _, proxyURL := range []string{"proxy.test:3128", "ftp://proxy.test"} {
+		cfg := ProviderConfig{
+			URL:      types.StringValue("http://grafana.test"),
+			Auth:     types.StringValue("token"),
+			ProxyURL: types.StringValue(proxyURL),
+		}
+		require.NoError(t, cfg.SetDefaults())
+		_, err := CreateClients(context.Background(), cfg)
+		assert.ErrorContains(t, err, "invalid proxy url", proxyURL)
+
+		// The framework provider validates the attribute like the SDK provider
+		var resp validator.StringResponse
+		proxyURLValidator{}.ValidateString(context.Background(), validator.StringRequest{ConfigValue: types.StringValue(proxyURL)}, &resp)
+		assert.True(t, resp.Diagnostics.HasError(), proxyURL)
+	}
+
+	var resp validator.StringResponse
+	proxyURLValidator{}.ValidateString(context.Background(), validator.StringRequest{ConfigValue: types.StringValue("socks5://proxy.test:1080")}, &resp)
+	assert.False(t, resp.Diagnostics.HasError())
+}
+
+// testRequest is a request received by a test server
+type testRequest struct {
+	Host  string
+	Path  string
+	OrgID string
+}
+
+// requestLog records the requests received by a test server
+type requestLog struct {
+	mu       sync.Mutex
+	requests []testRequest
+}
+
+func (l *requestLog) add(request testRequest) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.requests = append(l.requests, request)
+}
+
+func (l *requestLog) get() []testRequest {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.requests
+}
+
+// newTestServer starts a server that answers all requests with an empty JSON object (or an empty list of SLOs), and records them.
+// As an HTTP proxy, it answers the requests itself instead of forwarding them.
+func newTestServer(t *testing.T, tls bool) (*httptest.Server, *requestLog) {
+	requests := &requestLog{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.add(testRequest{Host: r.Host, Path: r.URL.Path, OrgID: r.Header.Get(goapi.OrgIDHeader)})
+		if strings.HasSuffix(r.URL.Path, "/v1/slo") {
+			jsonHandler(`{"slos": []}`)(w, r)
+			return
+		}
+		jsonHandler(`{}`)(w, r)
+	})
+	server := httptest.NewUnstartedServer(handler)
+	if tls {
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+	t.Cleanup(server.Close)
+	return server, requests
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-com-public-clients/go/gcom"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v2/internal/resources/cloud"
+)
+
+const (
+	defaultSMURL     = "https://synthetic-monitoring-api.grafana.net"
+	defaultOncallURL = "https://oncall-prod-us-central-0.grafana.net/oncall"
+	// oncallURLFormat is the format of the OnCall API URLs of Grafana Cloud, by cluster slug (ex: prod-us-central-0)
+	oncallURLFormat = "https://oncall-%s.grafana.net/oncall"
+
+	smPluginID     = "grafana-synthetic-monitoring-app"
+	oncallPluginID = "grafana-oncall-app"
+
+	cloudStackDomain = ".grafana.net"
+	discoveryTimeout = 10 * time.Second
+)
+
+type pluginSettings struct {
+	Enabled  bool           `json:"enabled"`
+	JSONData map[string]any `json:"jsonData"`
+}
+
+// resolveSMURL returns the Synthetic Monitoring API URL, in order of precedence:
+// - The `sm_url` attribute
+// - The API host configured in the Synthetic Monitoring plugin of the Grafana instance
+// - The API URL of the stack's region, if the Grafana instance is a Grafana Cloud stack and Cloud credentials are set
+// - The default URL
+func resolveSMURL(ctx context.Context, client *common.Client, providerConfig ProviderConfig) string {
+	if smURL := providerConfig.SMURL.ValueString(); smURL != "" {
+		return smURL
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+
+	if smURL, err := discoverPluginURL(ctx, client, smPluginID, "apiHost"); err != nil {
+		log.Printf("[WARN] could not discover the Synthetic Monitoring API URL from the Grafana instance: %v", err)
+	} else if smURL != "" {
+		return smURL
+	}
+
+	if stack, err := discoverCloudStack(ctx, client); err != nil {
+		log.Printf("[WARN] could not discover the Synthetic Monitoring API URL from the Grafana Cloud stack: %v", err)
+	} else if stack != nil {
+		return cloud.SMAPIURLForRegion(stack.RegionSlug)
+	}
+
+	return defaultSMURL
+}
+
+// ResolveOncallURL returns the OnCall API URL, in order of precedence:
+// - The `oncall_url` attribute
+// - The API URL configured in the OnCall plugin of the Grafana instance
+// - The API URL of the stack's cluster, if the Grafana instance is a Grafana Cloud stack and Cloud credentials are set
+// - The default URL
+//
+// It is exported for the tests that record API calls, which proxy the OnCall client since it doesn't accept a custom transport.
+func ResolveOncallURL(ctx context.Context, client *common.Client, providerConfig ProviderConfig) string {
+	if oncallURL := providerConfig.OncallURL.ValueString(); oncallURL != "" {
+		return oncallURL
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+
+	if oncallURL, err := discoverPluginURL(ctx, client, oncallPluginID, "onCallApiUrl"); err != nil {
+		log.Printf("[WARN] could not discover the OnCall API URL from the Grafana instance: %v", err)
+	} else if oncallURL != "" {
+		return oncallURL
+	}
+
+	if stack, err := discoverCloudStack(ctx, client); err != nil {
+		log.Printf("[WARN] could not discover the OnCall API URL from the Grafana Cloud stack: %v", err)
+	} else if stack != nil && stack.ClusterSlug != "" {
+		return fmt.Sprintf(oncallURLFormat, stack.ClusterSlug)
+	}
+
+	return defaultOncallURL
+}
+
+// discoverPluginURL reads a URL from the settings of an app plugin installed on the Grafana instance.
+// The request goes through the Grafana API client, with its auth, org, headers, TLS and retry settings.
+// It returns an empty string without error if there is no Grafana client or if the plugin is not installed or not enabled.
+func discoverPluginURL(ctx context.Context, client *common.Client, pluginID, jsonDataKey string) (string, error) {
+	if client.GrafanaAPI == nil {
+		return "", nil
+	}
+
+	var settings pluginSettings
+	err := common.SubmitGrafanaJSON(ctx, client.GrafanaAPI, client.GrafanaAPIConfig.Schemes, http.MethodGet, fmt.Sprintf("/plugins/%s/settings", pluginID), nil, &settings)
+	if common.IsNotFoundError(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the %s plugin settings: %w", pluginID, err)
+	}
+	if !settings.Enabled {
+		return "", nil
+	}
+	pluginURL, _ := settings.JSONData[jsonDataKey].(string)
+	return strings.TrimSuffix(pluginURL, "/"), nil
+}
+
+// discoverCloudStack returns the Grafana Cloud stack that the provider is configured for.
+// It returns nil without error if the Grafana URL is not a Grafana Cloud stack URL or if Cloud credentials are not set.
+func discoverCloudStack(ctx context.Context, client *common.Client) (*gcom.FormattedApiInstance, error) {
+	slug := cloudStackSlug(client)
+	if slug == "" || client.GrafanaCloudAPI == nil {
+		return nil, nil
+	}
+	stack, _, err := client.GrafanaCloudAPI.InstancesAPI.GetInstance(ctx, slug).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the stack %q: %w", slug, err)
+	}
+	return stack, nil
+}
+
+// cloudStackSlug returns the slug of the Grafana Cloud stack that the provider is configured for.
+// It returns an empty string if the Grafana URL is not a Grafana Cloud stack URL.
+func cloudStackSlug(client *common.Client) string {
+	if client.GrafanaAPIURLParsed == nil {
+		return ""
+	}
+	host := client.GrafanaAPIURLParsed.Hostname()
+	if !strings.HasSuffix(host, cloudStackDomain) {
+		return ""
+	}
+	slug := strings.TrimSuffix(host, cloudStackDomain)
+	if strings.Contains(slug, ".") {
+		return ""
+	}
+	return slug
+}
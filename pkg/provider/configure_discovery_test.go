@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePluginURLs(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		grafanaURL      string
+		cloudToken      bool
+		handlers        map[string]http.HandlerFunc
+		expectedSMURL   string
+		expectedOncall  string
+		expectedHeaders map[string]string
+	}{
+		{
+			name:       "from the plugin settings",
+			grafanaURL: "https://mystack.grafana.net",
+			cloudToken: true,
+			handlers: map[string]http.HandlerFunc{
+				"/api/plugins/grafana-synthetic-monitoring-app/settings": jsonHandler(`{"enabled": true, "jsonData": {"apiHost": "https://sm.example.com/"}}`),
+				"/api/plugins/grafana-oncall-app/settings":               jsonHandler(`{"enabled": true, "jsonData": {"onCallApiUrl": "https://oncall.example.com"}}`),
+			},
+			expectedSMURL:   "https://sm.example.com",
+			expectedOncall:  "https://oncall.example.com",
+			expectedHeaders: map[string]string{"Authorization": "Bearer token", "X-Custom": "value"},
+		},
+		{
+			name:       "from the Grafana Cloud stack",
+			grafanaURL: "https://mystack.grafana.net",
+			cloudToken: true,
+			handlers: map[string]http.HandlerFunc{
+				"/api/plugins/grafana-synthetic-monitoring-app/settings": jsonHandler(`{"enabled": false, "jsonData": {"apiHost": "https://sm.example.com"}}`),
+				"/api/instances/mystack":                                 jsonHandler(`{"slug": "mystack", "regionSlug": "prod-eu-west-0", "clusterSlug": "prod-eu-west-0"}`),
+			},
+			expectedSMURL:  "https://synthetic-monitoring-api-eu-west-0.grafana.net",
+			expectedOncall: "https://oncall-prod-eu-west-0.grafana.net/oncall",
+		},
+		{
+			name:           "without Cloud credentials",
+			grafanaURL:     "https://mystack.grafana.net",
+			handlers:       map[string]http.HandlerFunc{},
+			expectedSMURL:  defaultSMURL,
+			expectedOncall: defaultOncallURL,
+		},
+		{
+			name:       "not a Grafana Cloud stack",
+			grafanaURL: "https://grafana.example.com",
+			cloudToken: true,
+			handlers: map[string]http.HandlerFunc{
+				"/api/instances/grafana": jsonHandler(`{"slug": "grafana", "regionSlug": "prod-eu-west-0", "clusterSlug": "prod-eu-west-0"}`),
+			},
+			expectedSMURL:  defaultSMURL,
+			expectedOncall: defaultOncallURL,
+		},
+		{
+			name:       "failures",
+			grafanaURL: "https://mystack.grafana.net",
+			cloudToken: true,
+			handlers: map[string]http.HandlerFunc{
+				"/api/plugins/grafana-synthetic-monitoring-app/settings": errorHandler(http.StatusInternalServerError),
+				"/api/plugins/grafana-oncall-app/settings":               errorHandler(http.StatusForbidden),
+				"/api/instances/mystack":                                 errorHandler(http.StatusUnauthorized),
+			},
+			expectedSMURL:  defaultSMURL,
+			expectedOncall: defaultOncallURL,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			for path, handler := range tc.handlers {
+				path, handler := path, handler
+				mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+					for k, v := range tc.expectedHeaders {
+						assert.Equal(t, v, r.Header.Get(k), "header %s of %s", k, path)
+					}
+					handler(w, r)
+				})
+			}
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			cfg := ProviderConfig{
+				URL:         types.StringValue(tc.grafanaURL),
+				Auth:        types.StringValue("token"),
+				HTTPHeaders: types.MapValueMust(types.StringType, map[string]attr.Value{"X-Custom": types.StringValue("value")}),
+				Retries:     types.Int64Value(0),
+				WrapTransport: func(transport http.RoundTripper) http.RoundTripper {
+					return redirectTransport{server: server, transport: transport}
+				},
+			}
+			if tc.cloudToken {
+				cfg.CloudAccessPolicyToken = types.StringValue("cloud-token")
+			}
+			require.NoError(t, cfg.SetDefaults())
+			client, err := CreateClients(context.Background(), cfg)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedSMURL, resolveSMURL(context.Background(), client, cfg))
+			assert.Equal(t, tc.expectedOncall, ResolveOncallURL(context.Background(), client, cfg))
+
+			// The attributes take precedence over the discovered URLs
+			cfg.SMURL = types.StringValue("https://sm.attribute.com")
+			cfg.OncallURL = types.StringValue("https://oncall.attribute.com")
+			assert.Equal(t, "https://sm.attribute.com", resolveSMURL(context.Background(), client, cfg))
+			assert.Equal(t, "https://oncall.attribute.com", ResolveOncallURL(context.Background(), client, cfg))
+		})
+	}
+}
+
+func TestResolvePluginURLs_canceled(t *testing.T) {
+	server := httptest.NewServer(jsonHandler(`{"enabled": true, "jsonData": {"apiHost": "https://sm.example.com"}}`))
+	defer server.Close()
+
+	cfg := ProviderConfig{
+		URL:     types.StringValue(server.URL),
+		Auth:    types.StringValue("token"),
+		Retries: types.Int64Value(0),
+	}
+	require.NoError(t, cfg.SetDefaults())
+	client, err := CreateClients(context.Background(), cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, defaultSMURL, resolveSMURL(ctx, client, cfg))
+	assert.Equal(t, "https://sm.example.com", resolveSMURL(context.Background(), client, cfg))
+}
+
+func jsonHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func errorHandler(statusCode int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(`{"message": "error"}`))
+	}
+}
+
+// redirectTransport sends all the requests to a test server, whatever their host
+type redirectTransport struct {
+	server    *httptest.Server
+	transport http.RoundTripper
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	serverURL, err := url.Parse(t.server.URL)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.URL.Scheme = serverURL.Scheme
+	req.URL.Host = serverURL.Host
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/grafana/terraform-provider-grafana/v2/internal/common"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// sdkPlanWarningsServer adds the warnings of the SDKv2 resources (see common.PlanWarningsFunc) to the plans that create or update them
+type sdkPlanWarningsServer struct {
+	tfprotov5.ProviderServer
+	provider *schema.Provider
+	funcs    map[string]common.PlanWarningsFunc
+}
+
+func newSDKPlanWarningsServer(provider *schema.Provider) sdkPlanWarningsServer {
+	return sdkPlanWarningsServer{
+		ProviderServer: provider.GRPCProvider(),
+		provider:       provider,
+		funcs:          planWarningsFuncs(),
+	}
+}
+
+func (s sdkPlanWarningsServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	warningsFunc, ok := s.funcs[req.TypeName]
+	if err != nil || !ok || resp.PlannedState == nil || s.provider.Meta() == nil {
+		return resp, err
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			return resp, nil
+		}
+	}
+
+	// The values can't be decoded if the SDK failed to, it already returned an error then
+	valueType := s.provider.ResourcesMap[req.TypeName].CoreConfigSchema().ImpliedType()
+	priorState, err := msgpack.Unmarshal(req.PriorState.MsgPack, valueType)
+	if err != nil {
+		return resp, nil
+	}
+	plannedState, err := msgpack.Unmarshal(resp.PlannedState.MsgPack, valueType)
+	if err != nil || plannedState.IsNull() || plannedState.RawEquals(priorState) {
+		return resp, nil
+	}
+	config, err := msgpack.Unmarshal(req.Config.MsgPack, valueType)
+	if err != nil {
+		return resp, nil
+	}
+
+	for _, d := range warningsFunc(ctx, config, s.provider.Meta()) {
+		severity := tfprotov5.DiagnosticSeverityWarning
+		if d.Severity == diag.Error {
+			severity = tfprotov5.DiagnosticSeverityError
+		}
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: severity,
+			Summary:  d.Summary,
+			Detail:   d.Detail,
+		})
+	}
+	return resp, nil
+}
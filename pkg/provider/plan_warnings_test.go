@@ -0,0 +1,114 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v2/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v2/pkg/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanWarnings(t *testing.T) {
+	testutils.IsUnitTest(t)
+	testutils.NewMockGrafana(t)
+
+	ctx := context.Background()
+	server, err := provider.MakeProviderServer(ctx, "dev")
+	require.NoError(t, err)
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	// The provider is configured from the environment variables set by the mock server
+	providerConfig := objectValue(t, schemas.Provider.ValueType(), nil)
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: dynamicValue(t, providerConfig)})
+	require.NoError(t, err)
+	require.Empty(t, configureResp.Diagnostics)
+
+	dashboardType := schemas.ResourceSchemas["grafana_dashboard"].ValueType()
+	planConfig := func(values map[string]tftypes.Value) []*tfprotov5.Diagnostic {
+		config := objectValue(t, dashboardType, values)
+		resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "grafana_dashboard",
+			PriorState:       dynamicValue(t, tftypes.NewValue(dashboardType, nil)),
+			ProposedNewState: dynamicValue(t, config),
+			Config:           dynamicValue(t, config),
+		})
+		require.NoError(t, err)
+		return resp.Diagnostics
+	}
+	plan := func(validateReferences string) []*tfprotov5.Diagnostic {
+		return planConfig(map[string]tftypes.Value{
+			"config_json":         tftypes.NewValue(tftypes.String, `{"title": "References", "panels": [{"datasource": {"uid": "prometheus"}}]}`),
+			"validate_references": tftypes.NewValue(tftypes.String, validateReferences),
+		})
+	}
+	migrate := func(schemaVersion int) []*tfprotov5.Diagnostic {
+		return planConfig(map[string]tftypes.Value{
+			"config_json":    tftypes.NewValue(tftypes.String, fmt.Sprintf(`{"title": "Migration", "schemaVersion": %d}`, schemaVersion)),
+			"migrate_schema": tftypes.NewValue(tftypes.Bool, true),
+		})
+	}
+
+	t.Run("warn", func(t *testing.T) {
+		diags := plan("warn")
+		require.Len(t, diags, 1)
+		assert.Equal(t, tfprotov5.DiagnosticSeverityWarning, diags[0].Severity)
+		assert.Equal(t, "Broken reference at panels[0].datasource", diags[0].Summary)
+		assert.Equal(t, `data source "prometheus" was not found`, diags[0].Detail)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		diags := plan("error")
+		require.Len(t, diags, 1)
+		assert.Equal(t, tfprotov5.DiagnosticSeverityError, diags[0].Severity)
+		assert.Contains(t, diags[0].Summary, `panels[0].datasource: data source "prometheus" was not found`)
+	})
+
+	t.Run("unchecked", func(t *testing.T) {
+		assert.Empty(t, plan(""))
+	})
+
+	t.Run("partially migrated", func(t *testing.T) {
+		diags := migrate(20)
+		require.Len(t, diags, 1)
+		assert.Equal(t, tfprotov5.DiagnosticSeverityWarning, diags[0].Severity)
+		assert.Equal(t, "The dashboard can't be fully migrated", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "`config_json` has schema version 20 and it is only migrated to schema version 27")
+	})
+
+	t.Run("not migrated", func(t *testing.T) {
+		diags := migrate(10)
+		require.Len(t, diags, 1)
+		assert.Contains(t, diags[0].Detail, "schema versions older than 13 aren't supported")
+	})
+
+	t.Run("latest schema version", func(t *testing.T) {
+		assert.Empty(t, migrate(39))
+	})
+}
+
+// objectValue returns a value of an object type, whose attributes are null unless they're given
+func objectValue(t *testing.T, valueType tftypes.Type, values map[string]tftypes.Value) tftypes.Value {
+	objectType, ok := valueType.(tftypes.Object)
+	require.True(t, ok, "expected an object type, got %s", valueType)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+func dynamicValue(t *testing.T, value tftypes.Value) *tfprotov5.DynamicValue {
+	dynamicValue, err := tfprotov5.NewDynamicValue(value.Type(), value)
+	require.NoError(t, err)
+	return &dynamicValue
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
@@ -10,21 +11,32 @@ import (
 )
 
 func MakeProviderServer(ctx context.Context, version string) (tfprotov5.ProviderServer, error) {
+	return MakeProviderServerWithTransport(ctx, version, nil)
+}
+
+// MakeProviderServerWithTransport works like MakeProviderServer, but the HTTP transport of the API clients is wrapped with the given function.
+// This is meant for tests, to record or replay the API calls.
+func MakeProviderServerWithTransport(ctx context.Context, version string, wrapTransport func(http.RoundTripper) http.RoundTripper) (tfprotov5.ProviderServer, error) {
 	// While we still have the SDK2 provider, we have to use the provider v5 protocol
 	// See https://developer.hashicorp.com/terraform/plugin/mux/translating-protocol-version-6-to-5
 	downgradedFrameworkProvider, err := tf6to5server.DowngradeServer(
 		context.Background(),
-		providerserver.NewProtocol6(FrameworkProvider(version)),
+		providerserver.NewProtocol6(&frameworkProvider{version: version, wrapTransport: wrapTransport}),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	sdkProvider := Provider(version)
+	sdkProvider.ConfigureContextFunc = configure(version, sdkProvider, wrapTransport)
+
 	providers := []func() tfprotov5.ProviderServer{
 		func() tfprotov5.ProviderServer {
 			return downgradedFrameworkProvider
 		},
-		Provider(version).GRPCProvider,
+		func() tfprotov5.ProviderServer {
+			return newSDKPlanWarningsServer(sdkProvider)
+		},
 	}
 	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
 	if err != nil {
@@ -37,6 +37,17 @@ func resourceMap() map[string]*schema.Resource {
 	return result
 }
 
+func planWarningsFuncs() map[string]common.PlanWarningsFunc {
+	result := make(map[string]common.PlanWarningsFunc)
+	for _, r := range Resources() {
+		if r.Schema == nil || r.PlanWarningsFunc == nil {
+			continue
+		}
+		result[r.Name] = r.PlanWarningsFunc
+	}
+	return result
+}
+
 func mergeResourceMaps(maps ...map[string]*schema.Resource) map[string]*schema.Resource {
 	result := make(map[string]*schema.Resource)
 	for _, m := range maps {